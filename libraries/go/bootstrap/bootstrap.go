@@ -110,6 +110,12 @@ func (s *Service) HandleFunc(method, path string, handler func(context.Context,
 	s.router.HandleFunc(method, path, handler)
 }
 
+// HandleStreamFunc registers a new taxi-style streaming handler
+// with the application's router for the specified method and path.
+func (s *Service) HandleStreamFunc(method, path string, handler func(context.Context, taxi.Decoder, taxi.Sender) error) {
+	s.router.HandleStreamFunc(method, path, handler)
+}
+
 // HandleRaw registers a new http.Handler with the application's
 // router for the specified method and path.
 func (s *Service) HandleRaw(method, path string, handler http.Handler) {
@@ -0,0 +1,115 @@
+package device
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/firehose"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// StateChangedChannel is the firehose channel
+// that DeviceStateChangedEvents are published to
+const StateChangedChannel = "device-state-changed"
+
+// StateCache keeps the last known state of every device that a
+// DeviceStateChangedEvent has been received for. It allows services
+// to read the current state of a device without asking its controller.
+// Events can be handled out of order, e.g. when they are retried, so
+// events that are older than the cached state are ignored. Events
+// without a changed_at time are always applied.
+type StateCache struct {
+	devices map[string]*cachedDevice
+	mu      sync.RWMutex
+}
+
+type cachedDevice struct {
+	header    *devicedef.Header
+	state     map[string]interface{}
+	changedAt time.Time
+}
+
+var _ firehose.Handler = (*StateCache)(nil)
+
+// NewStateCache returns an empty StateCache
+func NewStateCache() *StateCache {
+	return &StateCache{
+		devices: make(map[string]*cachedDevice),
+	}
+}
+
+// Subscribe registers the cache as the handler
+// for DeviceStateChangedEvents on the firehose
+func (c *StateCache) Subscribe(s firehose.Subscriber) {
+	s.Subscribe(StateChangedChannel, c)
+}
+
+// HandleEvent updates the cache from a DeviceStateChangedEvent
+func (c *StateCache) HandleEvent(ctx context.Context, decode firehose.Decoder) firehose.Result {
+	return devicedef.DeviceStateChangedEventHandler(c.handle).HandleEvent(ctx, decode)
+}
+
+func (c *StateCache) handle(_ context.Context, event *devicedef.DeviceStateChangedEvent) firehose.Result {
+	if err := event.Validate(); err != nil {
+		return firehose.Discard(err)
+	}
+
+	state, ok := event.GetState().(map[string]interface{})
+	if !ok {
+		return firehose.Discard(oops.BadRequest("state of device %q is not an object", event.Header.GetId()))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	changedAt, set := event.GetChangedAt()
+	if d, ok := c.devices[event.Header.GetId()]; ok && set && changedAt.Before(d.changedAt) {
+		// A newer state has already been cached
+		return firehose.Success()
+	}
+
+	c.devices[event.Header.GetId()] = &cachedDevice{
+		header:    event.Header,
+		state:     state,
+		changedAt: changedAt,
+	}
+
+	return firehose.Success()
+}
+
+// State returns a copy of the last known state of the device.
+// The second return value is false if the state is not known.
+func (c *StateCache) State(deviceID string) (map[string]interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	d, ok := c.devices[deviceID]
+	if !ok {
+		return nil, false
+	}
+
+	state := make(map[string]interface{}, len(d.state))
+	for k, v := range d.state {
+		state[k] = v
+	}
+
+	return state, true
+}
+
+// Header returns a copy of the device's header from the last
+// DeviceStateChangedEvent that was received for the device.
+// The second return value is false if no events have been received.
+func (c *StateCache) Header(deviceID string) (*devicedef.Header, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	d, ok := c.devices[deviceID]
+	if !ok {
+		return nil, false
+	}
+
+	header := *d.header
+	return &header, true
+}
@@ -0,0 +1,122 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/firehose"
+)
+
+// decoderFor returns a Decoder that decodes
+// the JSON encoding of v, like the firehose
+func decoderFor(t *testing.T, v interface{}) firehose.Decoder {
+	b, err := json.Marshal(v)
+	assert.NilError(t, err)
+
+	return func(v interface{}) error {
+		return json.Unmarshal(b, v)
+	}
+}
+
+func TestStateCache(t *testing.T) {
+	c := NewStateCache()
+	ctx := context.Background()
+
+	_, ok := c.State("lamp")
+	assert.Assert(t, !ok)
+
+	header := (&devicedef.Header{}).
+		SetId("lamp").
+		SetName("Lamp").
+		SetType("dmx.megapar").
+		SetKind("lamp").
+		SetControllerName("dmx")
+
+	for _, state := range []map[string]interface{}{
+		{"power": false, "brightness": 10},
+		{"power": true, "brightness": 100},
+	} {
+		event := (&devicedef.DeviceStateChangedEvent{}).SetHeader(*header).SetState(state)
+		res := c.HandleEvent(ctx, decoderFor(t, event))
+		assert.NilError(t, res.Err)
+	}
+
+	state, ok := c.State("lamp")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, map[string]interface{}{"power": true, "brightness": float64(100)}, state)
+
+	// The returned state is a copy
+	state["power"] = false
+	state, _ = c.State("lamp")
+	assert.Equal(t, true, state["power"])
+
+	h, ok := c.Header("lamp")
+	assert.Assert(t, ok)
+	assert.Equal(t, "dmx", h.GetControllerName())
+}
+
+func TestStateCache_outOfOrder(t *testing.T) {
+	c := NewStateCache()
+	ctx := context.Background()
+
+	header := (&devicedef.Header{}).
+		SetId("lamp").
+		SetName("Lamp").
+		SetType("dmx.megapar").
+		SetKind("lamp").
+		SetControllerName("dmx")
+
+	changed := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	handle := func(state map[string]interface{}, changedAt time.Time) {
+		event := (&devicedef.DeviceStateChangedEvent{}).SetHeader(*header).SetState(state)
+		if !changedAt.IsZero() {
+			event.SetChangedAt(changedAt)
+		}
+		res := c.HandleEvent(ctx, decoderFor(t, event))
+		assert.NilError(t, res.Err)
+	}
+
+	// The newer event is handled first
+	handle(map[string]interface{}{"brightness": 100}, changed.Add(time.Second))
+	handle(map[string]interface{}{"brightness": 10}, changed)
+
+	state, _ := c.State("lamp")
+	assert.Equal(t, float64(100), state["brightness"])
+
+	handle(map[string]interface{}{"brightness": 50}, changed.Add(2*time.Second))
+	state, _ = c.State("lamp")
+	assert.Equal(t, float64(50), state["brightness"])
+
+	// Events without a time can't be ordered so they are applied
+	handle(map[string]interface{}{"brightness": 20}, time.Time{})
+	state, _ = c.State("lamp")
+	assert.Equal(t, float64(20), state["brightness"])
+}
+
+func TestStateCache_invalidEvent(t *testing.T) {
+	c := NewStateCache()
+	ctx := context.Background()
+
+	// Missing header
+	res := c.HandleEvent(ctx, decoderFor(t, map[string]interface{}{
+		"state": map[string]interface{}{"power": true},
+	}))
+	assert.ErrorContains(t, res.Err, "header")
+	assert.Assert(t, !res.Retry)
+
+	// State is not an object
+	res = c.HandleEvent(ctx, decoderFor(t, (&devicedef.DeviceStateChangedEvent{}).
+		SetHeader(*(&devicedef.Header{}).SetId("lamp").SetName("Lamp").SetType("t").SetKind("k").SetControllerName("c")).
+		SetState(true),
+	))
+	assert.ErrorContains(t, res.Err, `state of device "lamp" is not an object`)
+	assert.Assert(t, !res.Retry)
+
+	_, ok := c.State("lamp")
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,34 @@
+package device
+
+import (
+	"context"
+	"fmt"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+)
+
+// Every device controller that supports commands serves
+// the standard command RPC with this method and path
+const (
+	CommandMethod = "POST"
+	CommandPath   = "/command"
+)
+
+// InvokeCommand makes the standard command RPC to the named controller
+func InvokeCommand(
+	ctx context.Context,
+	dispatcher taxi.Dispatcher,
+	controllerName string,
+	body *devicedef.InvokeCommandRequest,
+) error {
+	if err := body.Validate(); err != nil {
+		return err
+	}
+
+	return dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: CommandMethod,
+		URL:    fmt.Sprintf("http://%s%s", controllerName, CommandPath),
+		Body:   body,
+	}).DecodeResponse(&devicedef.InvokeCommandResponse{})
+}
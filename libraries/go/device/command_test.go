@@ -0,0 +1,64 @@
+package device
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+)
+
+func TestInvokeCommand(t *testing.T) {
+	var host string
+	var got *devicedef.InvokeCommandRequest
+
+	r := taxi.NewRouter()
+	r.UseMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			host = req.URL.Host
+			next.ServeHTTP(w, req)
+		})
+	})
+	r.HandleFunc(CommandMethod, CommandPath, func(_ context.Context, decode taxi.Decoder) (interface{}, error) {
+		got = &devicedef.InvokeCommandRequest{}
+		if err := decode(got); err != nil {
+			return nil, err
+		}
+
+		if got.GetCommand() != "volume" {
+			return nil, oops.BadRequest("command %q not known", got.GetCommand())
+		}
+
+		return &devicedef.InvokeCommandResponse{}, nil
+	})
+
+	c := &taxi.MockClient{Handler: r}
+	ctx := context.Background()
+
+	err := InvokeCommand(ctx, c, "service.infrared", (&devicedef.InvokeCommandRequest{}).
+		SetDeviceId("tv").
+		SetCommand("volume").
+		SetArgs(map[string]interface{}{"delta": -3}))
+	assert.NilError(t, err)
+	assert.Equal(t, "service.infrared", host)
+	assert.Equal(t, "tv", got.GetDeviceId())
+	args, _ := got.GetArgs()
+	assert.DeepEqual(t, map[string]interface{}{"delta": float64(-3)}, args)
+
+	// Errors from the controller are returned
+	err = InvokeCommand(ctx, c, "service.infrared", (&devicedef.InvokeCommandRequest{}).
+		SetDeviceId("tv").
+		SetCommand("explode"))
+	assert.Assert(t, oops.Is(err, oops.ErrBadRequest))
+	assert.ErrorContains(t, err, `command "explode" not known`)
+
+	// Invalid requests are not sent
+	got = nil
+	err = InvokeCommand(ctx, c, "service.infrared", (&devicedef.InvokeCommandRequest{}).SetCommand("volume"))
+	assert.Assert(t, err != nil)
+	assert.Assert(t, got == nil)
+}
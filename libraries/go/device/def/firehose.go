@@ -18,8 +18,9 @@ func (m *DeviceStateChangedEvent) Publish(ctx context.Context, p firehose.Publis
 	return p.Publish(ctx, "device-state-changed", m)
 }
 
-// DeviceStateChangedEventHandler implements the necessary functions to be a Firehose handler
-type DeviceStateChangedEventHandler func(*DeviceStateChangedEvent) firehose.Result
+// DeviceStateChangedEventHandler implements the necessary functions to be a Firehose handler.
+// The context carries the trace ID of the chain that published the event.
+type DeviceStateChangedEventHandler func(context.Context, *DeviceStateChangedEvent) firehose.Result
 
 // HandleEvent handles the Firehose event
 func (h DeviceStateChangedEventHandler) HandleEvent(ctx context.Context, decode firehose.Decoder) firehose.Result {
@@ -27,5 +28,5 @@ func (h DeviceStateChangedEventHandler) HandleEvent(ctx context.Context, decode
 	if err := decode(&body); err != nil {
 		return firehose.Discard(oops.WithMessage(err, "failed to unmarshal payload"))
 	}
-	return h(&body)
+	return h(ctx, &body)
 }
@@ -3,9 +3,36 @@
 package devicedef
 
 import (
+	fmt "fmt"
+	time "time"
+	utf8 "unicode/utf8"
+
 	oops "github.com/jakewright/home-automation/libraries/go/oops"
 )
 
+// prefixFieldPath returns a copy of an error returned by the Validate
+// function of a nested message with the path of the nested field
+// prepended to the field path in the error's metadata
+func prefixFieldPath(err error, path string) error {
+	e, ok := err.(*oops.Error)
+	if !ok {
+		return err
+	}
+
+	metadata := map[string]string{}
+	for k, v := range e.GetMetadata() {
+		metadata[k] = v
+	}
+
+	if field, ok := metadata["field"]; ok {
+		path += "." + field
+	}
+	metadata["field"] = path
+
+	// Validate functions only return bad request errors
+	return oops.BadRequest("%s", e.GetMessage(), metadata)
+}
+
 // Header is defined in the .def file
 type Header struct {
 	Id             *string                `json:"id,omitempty"`
@@ -146,22 +173,45 @@ func (m *Header) SetRoomId(v string) *Header {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *Header) Validate() error {
 	if m.Id == nil {
-		return oops.BadRequest("field 'id' is required")
+		return oops.BadRequest("field 'id' is required", map[string]string{
+			"field": "id",
+		})
 	}
+	if m.Id != nil && utf8.RuneCountInString(*m.Id) < 1 {
+		return oops.BadRequest("field 'id' should have length ≥ 1", map[string]string{
+			"field": "id",
+		})
+	}
+
 	if m.Name == nil {
-		return oops.BadRequest("field 'name' is required")
+		return oops.BadRequest("field 'name' is required", map[string]string{
+			"field": "name",
+		})
+	}
+	if m.Name != nil && utf8.RuneCountInString(*m.Name) < 1 {
+		return oops.BadRequest("field 'name' should have length ≥ 1", map[string]string{
+			"field": "name",
+		})
 	}
+
 	if m.Type == nil {
-		return oops.BadRequest("field 'type' is required")
+		return oops.BadRequest("field 'type' is required", map[string]string{
+			"field": "type",
+		})
 	}
 	if m.Kind == nil {
-		return oops.BadRequest("field 'kind' is required")
+		return oops.BadRequest("field 'kind' is required", map[string]string{
+			"field": "kind",
+		})
 	}
 	if m.ControllerName == nil {
-		return oops.BadRequest("field 'controller_name' is required")
+		return oops.BadRequest("field 'controller_name' is required", map[string]string{
+			"field": "controller_name",
+		})
 	}
 	return nil
 }
@@ -255,16 +305,17 @@ func (m *Property) SetOptions(v []*Option) *Property {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *Property) Validate() error {
 	if m.Type == nil {
-		return oops.BadRequest("field 'type' is required")
+		return oops.BadRequest("field 'type' is required", map[string]string{
+			"field": "type",
+		})
 	}
-	if m.Options != nil {
-		for _, r := range m.Options {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+	for i, r := range m.Options {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("options[%d]", i))
 		}
 	}
 
@@ -292,7 +343,8 @@ func (m *Command) SetArgs(v map[string]*Arg) *Command {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *Command) Validate() error {
 	return nil
 }
@@ -386,19 +438,22 @@ func (m *Arg) SetOptions(v []*Option) *Arg {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *Arg) Validate() error {
 	if m.Required == nil {
-		return oops.BadRequest("field 'required' is required")
+		return oops.BadRequest("field 'required' is required", map[string]string{
+			"field": "required",
+		})
 	}
 	if m.Type == nil {
-		return oops.BadRequest("field 'type' is required")
+		return oops.BadRequest("field 'type' is required", map[string]string{
+			"field": "type",
+		})
 	}
-	if m.Options != nil {
-		for _, r := range m.Options {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+	for i, r := range m.Options {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("options[%d]", i))
 		}
 	}
 
@@ -443,21 +498,191 @@ func (m *Option) SetName(v string) *Option {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *Option) Validate() error {
 	if m.Value == nil {
-		return oops.BadRequest("field 'value' is required")
+		return oops.BadRequest("field 'value' is required", map[string]string{
+			"field": "value",
+		})
 	}
 	if m.Name == nil {
-		return oops.BadRequest("field 'name' is required")
+		return oops.BadRequest("field 'name' is required", map[string]string{
+			"field": "name",
+		})
+	}
+	if m.Name != nil && utf8.RuneCountInString(*m.Name) < 1 {
+		return oops.BadRequest("field 'name' should have length ≥ 1", map[string]string{
+			"field": "name",
+		})
+	}
+
+	return nil
+}
+
+// InvokeCommandRequest is defined in the .def file
+type InvokeCommandRequest struct {
+	DeviceId *string                `json:"device_id,omitempty"`
+	Command  *string                `json:"command,omitempty"`
+	Args     map[string]interface{} `json:"args,omitempty"`
+}
+
+// GetDeviceId returns the de-referenced value of DeviceId.
+// If the field is nil, the function panics because device_id is marked as required.
+func (m *InvokeCommandRequest) GetDeviceId() (val string) {
+	if m.DeviceId == nil {
+		panic("device_id marked as required but was not set. This should have been caught by the validate function.")
+	}
+
+	return *m.DeviceId
+}
+
+// SetDeviceId sets the value of DeviceId
+func (m *InvokeCommandRequest) SetDeviceId(v string) *InvokeCommandRequest {
+	m.DeviceId = &v
+	return m
+}
+
+// GetCommand returns the de-referenced value of Command.
+// If the field is nil, the function panics because command is marked as required.
+func (m *InvokeCommandRequest) GetCommand() (val string) {
+	if m.Command == nil {
+		panic("command marked as required but was not set. This should have been caught by the validate function.")
+	}
+
+	return *m.Command
+}
+
+// SetCommand sets the value of Command
+func (m *InvokeCommandRequest) SetCommand(v string) *InvokeCommandRequest {
+	m.Command = &v
+	return m
+}
+
+// GetArgs returns the de-referenced value of Args.
+// The second return value states whether the field was set.
+func (m *InvokeCommandRequest) GetArgs() (val map[string]interface{}, set bool) {
+	if m.Args == nil {
+		return
+	}
+
+	return m.Args, true
+}
+
+// SetArgs sets the value of Args
+func (m *InvokeCommandRequest) SetArgs(v map[string]interface{}) *InvokeCommandRequest {
+	m.Args = v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *InvokeCommandRequest) Validate() error {
+	if m.DeviceId == nil {
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
+	}
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
+	}
+
+	if m.Command == nil {
+		return oops.BadRequest("field 'command' is required", map[string]string{
+			"field": "command",
+		})
+	}
+	if m.Command != nil && utf8.RuneCountInString(*m.Command) < 1 {
+		return oops.BadRequest("field 'command' should have length ≥ 1", map[string]string{
+			"field": "command",
+		})
+	}
+
+	return nil
+}
+
+// InvokeCommandResponse is defined in the .def file
+type InvokeCommandResponse struct {
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *InvokeCommandResponse) Validate() error {
+	return nil
+}
+
+// ProvideStateRequest is defined in the .def file
+type ProvideStateRequest struct {
+	DeviceId *string `json:"device_id,omitempty"`
+}
+
+// GetDeviceId returns the de-referenced value of DeviceId.
+// If the field is nil, the function panics because device_id is marked as required.
+func (m *ProvideStateRequest) GetDeviceId() (val string) {
+	if m.DeviceId == nil {
+		panic("device_id marked as required but was not set. This should have been caught by the validate function.")
+	}
+
+	return *m.DeviceId
+}
+
+// SetDeviceId sets the value of DeviceId
+func (m *ProvideStateRequest) SetDeviceId(v string) *ProvideStateRequest {
+	m.DeviceId = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *ProvideStateRequest) Validate() error {
+	if m.DeviceId == nil {
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
+	}
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
+	}
+
+	return nil
+}
+
+// ProvideStateResponse is defined in the .def file
+type ProvideStateResponse struct {
+	State map[string]interface{} `json:"state,omitempty"`
+}
+
+// GetState returns the de-referenced value of State.
+// The second return value states whether the field was set.
+func (m *ProvideStateResponse) GetState() (val map[string]interface{}, set bool) {
+	if m.State == nil {
+		return
 	}
+
+	return m.State, true
+}
+
+// SetState sets the value of State
+func (m *ProvideStateResponse) SetState(v map[string]interface{}) *ProvideStateResponse {
+	m.State = v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *ProvideStateResponse) Validate() error {
 	return nil
 }
 
 // DeviceStateChangedEvent is defined in the .def file
 type DeviceStateChangedEvent struct {
-	Header *Header     `json:"header,omitempty"`
-	State  interface{} `json:"state,omitempty"`
+	Header    *Header     `json:"header,omitempty"`
+	State     interface{} `json:"state,omitempty"`
+	ChangedAt *time.Time  `json:"changed_at,omitempty"`
 }
 
 // GetHeader returns the de-referenced value of Header.
@@ -492,17 +717,40 @@ func (m *DeviceStateChangedEvent) SetState(v interface{}) *DeviceStateChangedEve
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// GetChangedAt returns the de-referenced value of ChangedAt.
+// The second return value states whether the field was set.
+func (m *DeviceStateChangedEvent) GetChangedAt() (val time.Time, set bool) {
+	if m.ChangedAt == nil {
+		return
+	}
+
+	return *m.ChangedAt, true
+}
+
+// SetChangedAt sets the value of ChangedAt
+func (m *DeviceStateChangedEvent) SetChangedAt(v time.Time) *DeviceStateChangedEvent {
+	m.ChangedAt = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *DeviceStateChangedEvent) Validate() error {
-	if err := m.Header.Validate(); err != nil {
-		return err
+	if m.Header != nil {
+		if err := m.Header.Validate(); err != nil {
+			return prefixFieldPath(err, "header")
+		}
 	}
 
 	if m.Header == nil {
-		return oops.BadRequest("field 'header' is required")
+		return oops.BadRequest("field 'header' is required", map[string]string{
+			"field": "header",
+		})
 	}
 	if m.State == nil {
-		return oops.BadRequest("field 'state' is required")
+		return oops.BadRequest("field 'state' is required", map[string]string{
+			"field": "state",
+		})
 	}
 	return nil
 }
@@ -0,0 +1,70 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+// Header is defined in the .def file
+export interface Header {
+  id: string;
+  name: string;
+  type: string;
+  kind: string;
+  controller_name: string;
+  attributes?: { [key: string]: any };
+  state_providers?: string[];
+  room_id?: string;
+}
+
+// Property is defined in the .def file
+export interface Property {
+  type: string;
+  min?: number;
+  max?: number;
+  interpolation?: string;
+  options?: Option[];
+}
+
+// Command is defined in the .def file
+export interface Command {
+  args?: { [key: string]: Arg };
+}
+
+// Arg is defined in the .def file
+export interface Arg {
+  required: boolean;
+  type: string;
+  min?: number;
+  max?: number;
+  options?: Option[];
+}
+
+// Option is defined in the .def file
+export interface Option {
+  value: string;
+  name: string;
+}
+
+// InvokeCommandRequest is defined in the .def file
+export interface InvokeCommandRequest {
+  device_id: string;
+  command: string;
+  args?: { [key: string]: any };
+}
+
+// InvokeCommandResponse is defined in the .def file
+export interface InvokeCommandResponse {
+}
+
+// ProvideStateRequest is defined in the .def file
+export interface ProvideStateRequest {
+  device_id: string;
+}
+
+// ProvideStateResponse is defined in the .def file
+export interface ProvideStateResponse {
+  state?: { [key: string]: any };
+}
+
+// DeviceStateChangedEvent is defined in the .def file
+export interface DeviceStateChangedEvent {
+  header: Header;
+  state: any;
+  changed_at?: string;
+}
@@ -0,0 +1,110 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+)
+
+// Every service that acts as a state provider serves the
+// standard provide-state RPC with this method and path
+const (
+	ProvideStateMethod = "GET"
+	ProvideStatePath   = "/provide-state"
+)
+
+// ProviderTimeout is the maximum amount of time
+// that each state provider has to respond
+var ProviderTimeout = 2 * time.Second
+
+// LoadProvidedState makes the provide-state RPC to each of the providers
+// concurrently and merges the states that they return. Providers are
+// expected to provide different properties of the device. If more than
+// one provider returns the same property, the values must be equal or
+// an error is returned. If any provider fails, the error returned is
+// from the first failing provider in the list.
+func LoadProvidedState(
+	ctx context.Context,
+	dispatcher taxi.Dispatcher,
+	deviceID string,
+	providers []string,
+) (map[string]interface{}, error) {
+	states := make([]map[string]interface{}, len(providers))
+	errs := make([]error, len(providers))
+
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider string) {
+			defer wg.Done()
+			states[i], errs[i] = provideState(ctx, dispatcher, provider, deviceID)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, oops.WithMessage(err, "state provider %q failed", providers[i], map[string]string{
+				"device_id": deviceID,
+				"provider":  providers[i],
+			})
+		}
+	}
+
+	state := make(map[string]interface{})
+	providedBy := make(map[string]string)
+	for i, provided := range states {
+		for property, value := range provided {
+			if other, ok := providedBy[property]; ok {
+				if !reflect.DeepEqual(state[property], value) {
+					return nil, oops.InternalService(
+						"state providers %q and %q provided conflicting values for property %q",
+						other, providers[i], property,
+						map[string]string{
+							"device_id": deviceID,
+							"property":  property,
+						},
+					)
+				}
+				continue
+			}
+
+			state[property] = value
+			providedBy[property] = providers[i]
+		}
+	}
+
+	return state, nil
+}
+
+func provideState(
+	ctx context.Context,
+	dispatcher taxi.Dispatcher,
+	provider, deviceID string,
+) (map[string]interface{}, error) {
+	pctx, cancel := context.WithTimeout(ctx, ProviderTimeout)
+	defer cancel()
+
+	rsp := &devicedef.ProvideStateResponse{}
+	if err := dispatcher.Dispatch(pctx, &taxi.RPC{
+		Method:     ProvideStateMethod,
+		URL:        fmt.Sprintf("http://%s%s", provider, ProvideStatePath),
+		Body:       (&devicedef.ProvideStateRequest{}).SetDeviceId(deviceID),
+		Idempotent: true,
+	}).DecodeResponse(rsp); err != nil {
+		// Only report a timeout if it was this provider's
+		// deadline that passed rather than the caller's
+		if ctx.Err() == nil && pctx.Err() == context.DeadlineExceeded {
+			return nil, oops.Timeout("no response after %s", ProviderTimeout)
+		}
+		return nil, err
+	}
+
+	return rsp.State, nil
+}
@@ -0,0 +1,93 @@
+package device
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+)
+
+type doerFunc func(r *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) { return f(r) }
+
+// providerClient returns a client that serves the provide-state
+// RPC by returning the state in the map keyed by the request's host
+func providerClient(t *testing.T, states map[string]map[string]interface{}) *taxi.Client {
+	return taxi.NewClientUsing(doerFunc(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, ProvideStateMethod, r.Method)
+		assert.Equal(t, ProvideStatePath, r.URL.Path)
+
+		switch r.URL.Host {
+		case "broken":
+			return nil, errors.New("connection refused")
+		case "slow":
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}
+
+		b, err := json.Marshal(map[string]interface{}{
+			"data": map[string]interface{}{"state": states[r.URL.Host]},
+		})
+		assert.NilError(t, err)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	}))
+}
+
+func TestLoadProvidedState(t *testing.T) {
+	c := providerClient(t, map[string]map[string]interface{}{
+		"hue":     {"power": true, "brightness": float64(100)},
+		"plug":    {"power": true, "energy": float64(7)},
+		"other":   {"power": false},
+		"nothing": nil,
+	})
+	ctx := context.Background()
+
+	state, err := LoadProvidedState(ctx, c, "lamp", []string{"hue", "plug", "nothing"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{
+		"power":      true,
+		"brightness": float64(100),
+		"energy":     float64(7),
+	}, state)
+
+	state, err = LoadProvidedState(ctx, c, "lamp", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(state))
+
+	_, err = LoadProvidedState(ctx, c, "lamp", []string{"hue", "other"})
+	assert.ErrorContains(t, err, `state providers "hue" and "other" provided conflicting values for property "power"`)
+	assert.Equal(t, "power", err.(*oops.Error).GetMetadata()["property"])
+}
+
+func TestLoadProvidedState_providerFailure(t *testing.T) {
+	defer func(d time.Duration) { ProviderTimeout = d }(ProviderTimeout)
+	ProviderTimeout = 50 * time.Millisecond
+
+	c := providerClient(t, map[string]map[string]interface{}{
+		"hue": {"power": true},
+	})
+	ctx := context.Background()
+
+	_, err := LoadProvidedState(ctx, c, "lamp", []string{"hue", "broken"})
+	assert.ErrorContains(t, err, `state provider "broken" failed`)
+	assert.Equal(t, "broken", err.(*oops.Error).GetMetadata()["provider"])
+
+	_, err = LoadProvidedState(ctx, c, "lamp", []string{"slow", "hue"})
+	assert.ErrorContains(t, err, `state provider "slow" failed`)
+	assert.Assert(t, oops.Is(err, oops.ErrTimeout))
+}
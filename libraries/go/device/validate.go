@@ -0,0 +1,149 @@
+package device
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/util"
+)
+
+// ValidateState checks the values in the state against the device's
+// properties. All of the invalid properties are reported in a single
+// BadRequest error, with the problem with each property set in the
+// error's metadata keyed by the property's name.
+func ValidateState(state map[string]interface{}, properties map[string]*devicedef.Property) error {
+	problems := make(map[string]string)
+
+	for name, value := range state {
+		property, ok := properties[name]
+		if !ok || property == nil {
+			problems[name] = "unknown property"
+			continue
+		}
+
+		problem, err := validateProperty(property, value)
+		if err != nil {
+			return oops.WithMessage(err, "failed to validate property %q", name)
+		}
+
+		if problem != "" {
+			problems[name] = problem
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(problems))
+	for name := range problems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	descriptions := make([]string, len(names))
+	for i, name := range names {
+		descriptions[i] = fmt.Sprintf("%s %s", name, problems[name])
+	}
+
+	return oops.BadRequest("invalid state: %s", strings.Join(descriptions, "; "), problems)
+}
+
+// validateProperty returns a description of the problem with the value,
+// or an error if the property itself is invalid
+func validateProperty(property *devicedef.Property, value interface{}) (string, error) {
+	switch property.GetType() {
+	case TypeBool:
+		if _, ok := value.(bool); !ok {
+			return "should be a bool", nil
+		}
+
+	case TypeInt:
+		v, ok := ToInt(value)
+		if !ok {
+			return "should be an integer", nil
+		}
+
+		if min, ok := property.GetMin(); ok && float64(v) < min {
+			return fmt.Sprintf("should be ≥ %v", min), nil
+		}
+
+		if max, ok := property.GetMax(); ok && float64(v) > max {
+			return fmt.Sprintf("should be ≤ %v", max), nil
+		}
+
+	case TypeString:
+		v, ok := value.(string)
+		if !ok {
+			return "should be a string", nil
+		}
+
+		if options, ok := property.GetOptions(); ok && len(options) > 0 {
+			for _, option := range options {
+				if option.GetValue() == v {
+					return "", nil
+				}
+			}
+
+			return fmt.Sprintf("has invalid option %q", v), nil
+		}
+
+	case TypeRGB:
+		if !isRGB(value) {
+			return "should be a hex color", nil
+		}
+
+	default:
+		return "", oops.InternalService("unknown property type %q", property.GetType())
+	}
+
+	return "", nil
+}
+
+// ToInt converts integral values of any integer kind to an
+// int64. Numbers decoded from JSON are float64 so they are
+// allowed as long as they don't have a fractional part.
+// Values that do not fit in an int64 are rejected.
+func ToInt(value interface{}) (int64, bool) {
+	if value == nil {
+		return 0, false
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := v.Uint()
+		if u > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(u), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+
+	return 0, false
+}
+
+func isRGB(value interface{}) bool {
+	switch v := value.(type) {
+	case util.RGB, *util.RGB, color.RGBA:
+		return true
+	case string:
+		_, err := util.HexToColor(v)
+		return err == nil
+	}
+
+	return false
+}
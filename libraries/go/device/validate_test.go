@@ -0,0 +1,122 @@
+package device
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/util"
+)
+
+func testProperties() map[string]*devicedef.Property {
+	return map[string]*devicedef.Property{
+		"power":      (&devicedef.Property{}).SetType(TypeBool),
+		"brightness": (&devicedef.Property{}).SetType(TypeInt).SetMin(0).SetMax(255),
+		"color":      (&devicedef.Property{}).SetType(TypeRGB),
+		"input": (&devicedef.Property{}).SetType(TypeString).SetOptions([]*devicedef.Option{
+			(&devicedef.Option{}).SetValue("GAME").SetName("Game"),
+			(&devicedef.Option{}).SetValue("TV_CD").SetName("TV"),
+		}),
+		"label": (&devicedef.Property{}).SetType(TypeString),
+	}
+}
+
+func TestValidateState(t *testing.T) {
+	tests := []struct {
+		name  string
+		state map[string]interface{}
+	}{
+		{"empty", nil},
+		{"bool", map[string]interface{}{"power": true}},
+		{"int", map[string]interface{}{"brightness": 255}},
+		{"int from JSON", map[string]interface{}{"brightness": float64(0)}},
+		{"uint8", map[string]interface{}{"brightness": uint8(255)}},
+		{"option", map[string]interface{}{"input": "GAME"}},
+		{"string without options", map[string]interface{}{"label": "anything"}},
+		{"hex color", map[string]interface{}{"color": "#FF0000"}},
+		{"short hex color", map[string]interface{}{"color": "#F00"}},
+		{"RGB", map[string]interface{}{"color": util.RGB{R: 255}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NilError(t, ValidateState(tc.state, testProperties()))
+		})
+	}
+}
+
+func TestValidateState_invalid(t *testing.T) {
+	err := ValidateState(map[string]interface{}{
+		"power":      "on",
+		"brightness": 256,
+		"color":      "red",
+		"input":      "AUX",
+		"label":      7,
+		"volume":     10,
+	}, testProperties())
+
+	assert.Assert(t, oops.Is(err, oops.ErrBadRequest))
+	assert.DeepEqual(t, map[string]string{
+		"power":      "should be a bool",
+		"brightness": "should be ≤ 255",
+		"color":      "should be a hex color",
+		"input":      `has invalid option "AUX"`,
+		"label":      "should be a string",
+		"volume":     "unknown property",
+	}, err.(*oops.Error).GetMetadata())
+	assert.ErrorContains(t, err, `invalid state: brightness should be ≤ 255; color should be a hex color; input has invalid option "AUX"`)
+
+	err = ValidateState(map[string]interface{}{"brightness": 1.5}, testProperties())
+	assert.Equal(t, "should be an integer", err.(*oops.Error).GetMetadata()["brightness"])
+
+	err = ValidateState(map[string]interface{}{"brightness": -1}, testProperties())
+	assert.Equal(t, "should be ≥ 0", err.(*oops.Error).GetMetadata()["brightness"])
+}
+
+func TestToInt(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		want   int64
+		wantOK bool
+	}{
+		{"int", -7, -7, true},
+		{"int8", int8(-128), -128, true},
+		{"int16", int16(300), 300, true},
+		{"int32", int32(-70000), -70000, true},
+		{"int64", int64(1) << 40, 1 << 40, true},
+		{"uint", uint(7), 7, true},
+		{"uint8", uint8(255), 255, true},
+		{"uint16", uint16(65535), 65535, true},
+		{"uint32", uint32(4294967295), 4294967295, true},
+		{"uint64", uint64(1) << 40, 1 << 40, true},
+		{"uint64 overflow", uint64(1) << 63, 0, false},
+		{"float32", float32(12), 12, true},
+		{"float64", float64(-3), -3, true},
+		{"fractional float64", 1.5, 0, false},
+		{"fractional float32", float32(0.5), 0, false},
+		{"float64 overflow", 1e19, 0, false},
+		{"string", "1", 0, false},
+		{"bool", true, 0, false},
+		{"nil", nil, 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ToInt(tc.value)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateState_invalidProperty(t *testing.T) {
+	err := ValidateState(map[string]interface{}{"power": true}, map[string]*devicedef.Property{
+		"power": (&devicedef.Property{}).SetType("boolean"),
+	})
+
+	assert.Assert(t, oops.Is(err, oops.ErrInternalService))
+	assert.ErrorContains(t, err, `unknown property type "boolean"`)
+}
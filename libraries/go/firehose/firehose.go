@@ -11,6 +11,7 @@ import (
 	"github.com/jakewright/home-automation/libraries/go/oops"
 	"github.com/jakewright/home-automation/libraries/go/queuer"
 	"github.com/jakewright/home-automation/libraries/go/slog"
+	"github.com/jakewright/home-automation/libraries/go/trace"
 )
 
 const firehoseStream = "firehose"
@@ -141,13 +142,19 @@ func NewClient(opts *ClientOptions) *Client {
 	}
 }
 
-// Publish emits a message to the firehose
+// Publish emits a message to the firehose. The trace ID carried
+// by the context is published with the message. If there isn't
+// one, a new ID is generated because this is the start of a chain.
+// Callers that start a chain and go on to make other calls should
+// use trace.Ensure first so that every call shares the same ID.
 func (c *Client) Publish(ctx context.Context, channel string, message interface{}) error {
 	data, err := json.Marshal(message)
 	if err != nil {
 		return oops.WithMessage(err, "failed to marshal payload")
 	}
 
+	ctx, traceID := trace.Ensure(ctx, trace.ID(ctx))
+
 	if err := c.p.Publish(ctx, &queuer.Message{
 		Stream: firehoseStream,
 		Values: map[string]interface{}{
@@ -155,6 +162,7 @@ func (c *Client) Publish(ctx context.Context, channel string, message interface{
 			"consumer": c.consumer,
 			"channel":  channel,
 			"data":     data,
+			"trace_id": traceID,
 		},
 	}); err != nil {
 		return oops.WithMessage(err, "failed to publish event")
@@ -206,6 +214,10 @@ func (c *Client) Start(ctx context.Context) error {
 			))
 		}
 
+		// Events published by older clients won't have a trace ID
+		traceID, _ := m.Values["trace_id"].(string)
+		ctx, _ = trace.Ensure(ctx, traceID)
+
 		decode := func(v interface{}) error {
 			if err := json.Unmarshal([]byte(data), &v); err != nil {
 				return oops.WithMessage(err, "failed to decode Firehose event")
@@ -218,7 +230,10 @@ func (c *Client) Start(ctx context.Context) error {
 	}
 
 	var deadLetterHandler = func(ctx context.Context, m *queuer.Message) queuer.Result {
-		slog.Errorf("dropping Firehose message %q", m.ID, map[string]string{
+		traceID, _ := m.Values["trace_id"].(string)
+		ctx = trace.WithID(ctx, traceID)
+
+		slog.FromContext(ctx).Errorf("dropping Firehose message %q", m.ID, map[string]string{
 			"channel": m.Values["channel"].(string),
 			"id":      m.ID,
 		})
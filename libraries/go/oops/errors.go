@@ -22,6 +22,7 @@ const (
 	ErrPreconditionFailed Code = "precondition_failed"
 	ErrTimeout            Code = "timeout"
 	ErrUnauthorized       Code = "unauthorized"
+	ErrUnavailable        Code = "unavailable"
 )
 
 var httpStatusByCode = map[Code]int{
@@ -32,6 +33,7 @@ var httpStatusByCode = map[Code]int{
 	ErrPreconditionFailed: http.StatusPreconditionFailed,
 	ErrTimeout:            http.StatusRequestTimeout,
 	ErrUnauthorized:       http.StatusUnauthorized,
+	ErrUnavailable:        http.StatusServiceUnavailable,
 }
 
 var codeByHTTPStatus map[int]Code
@@ -117,6 +119,16 @@ func (e *Error) GetMetadata() map[string]string {
 	return e.metadata
 }
 
+// Unwrap returns the cause of the error so that
+// errors.Is and errors.As can inspect the chain
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+
+	return e.cause
+}
+
 // HTTPStatus returns an appropriate HTTP status code to use when returning the error in a response
 func (e *Error) HTTPStatus() int {
 	if status := httpStatusByCode[e.GetCode()]; status != 0 {
@@ -182,6 +194,12 @@ func Unauthorized(format string, a ...interface{}) *Error {
 	return newError(ErrUnauthorized, format, a, nil)
 }
 
+// Unavailable creates a new error indicating that a remote service is not
+// currently able to handle requests and so the request was not attempted.
+func Unavailable(format string, a ...interface{}) *Error {
+	return newError(ErrUnavailable, format, a, nil)
+}
+
 // FromHTTPStatus returns an error where the code is derived from the HTTP status code
 func FromHTTPStatus(status int, format string, a ...interface{}) *Error {
 	code := codeByHTTPStatus[status]
@@ -192,6 +210,16 @@ func FromHTTPStatus(status int, format string, a ...interface{}) *Error {
 	return newError(code, format, a, nil)
 }
 
+// GetCode returns the code of the error. If the error is not
+// an *Error, ErrInternalService is returned.
+func GetCode(err error) Code {
+	if v, ok := err.(*Error); ok {
+		return v.GetCode()
+	}
+
+	return ErrInternalService
+}
+
 // Is returns whether the code matches that of the error
 func Is(err error, code Code) bool {
 	if v, ok := err.(*Error); ok {
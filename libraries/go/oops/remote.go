@@ -0,0 +1,81 @@
+package oops
+
+import (
+	"errors"
+)
+
+// Remote is a representation of an error that can be sent to another
+// service and turned back into an equivalent *Error. Each level of the
+// cause chain is kept so that GetCode, GetMessage and GetMetadata
+// behave the same on both sides.
+type Remote struct {
+	Code     Code              `json:"code,omitempty"`
+	Message  string            `json:"message,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Cause    *Remote           `json:"cause,omitempty"`
+}
+
+// ToRemote converts the error into a Remote. If the error is not an
+// *Error, it is given the code ErrInternalService, matching the code
+// that would be returned by GetCode.
+func ToRemote(err error) *Remote {
+	r := toRemote(err)
+	if r != nil && r.Code == "" && r.Cause == nil {
+		r.Code = ErrInternalService
+	}
+	return r
+}
+
+func toRemote(err error) *Remote {
+	if err == nil {
+		return nil
+	}
+
+	v, ok := err.(*Error)
+	if !ok {
+		// Only the message of other errors is kept
+		return &Remote{Message: err.Error()}
+	}
+
+	return &Remote{
+		Code:     v.code,
+		Message:  v.message,
+		Metadata: v.metadata,
+		Cause:    toRemote(v.cause),
+	}
+}
+
+// FromRemote converts the Remote back into an *Error. The stack
+// trace of the returned error is that of the caller.
+func FromRemote(r *Remote) *Error {
+	if r == nil {
+		return nil
+	}
+
+	code := r.Code
+	if code == "" && r.Cause == nil {
+		code = ErrInternalService
+	}
+
+	return &Error{
+		code:     code,
+		message:  r.Message,
+		metadata: r.Metadata,
+		cause:    fromRemoteCause(r.Cause),
+		stack:    stack(),
+	}
+}
+
+func fromRemoteCause(r *Remote) error {
+	if r == nil {
+		return nil
+	}
+
+	// A level with no code or cause must have
+	// come from an error that was not an *Error
+	if r.Code == "" && r.Cause == nil {
+		return errors.New(r.Message)
+	}
+
+	return FromRemote(r)
+}
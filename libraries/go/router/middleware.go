@@ -18,10 +18,10 @@ func panicRecovery(next http.Handler) http.Handler {
 					"stack": string(stack),
 				})
 				if err := taxi.WriteError(w, err); err != nil {
-					slog.Errorf("Failed to write response: %v", err)
+					slog.FromContext(r.Context()).Errorf("Failed to write response: %v", err)
 				}
 
-				slog.Error(err)
+				slog.FromContext(r.Context()).Error(err)
 			}
 		}()
 
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+)
+
+var (
+	openAPIDocument []byte
+	openAPIMu       sync.RWMutex // guards openAPIDocument
+)
+
+// RegisterOpenAPI sets the OpenAPI document that describes the service's
+// RPCs. It is called by the code that jrpc generates from the service's
+// def file so it should not normally need to be called directly.
+func RegisterOpenAPI(doc []byte) {
+	openAPIMu.Lock()
+	defer openAPIMu.Unlock()
+
+	openAPIDocument = doc
+}
+
+// OpenAPIHandler serves the document set by RegisterOpenAPI
+func OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	openAPIMu.RLock()
+	doc := openAPIDocument
+	openAPIMu.RUnlock()
+
+	if doc == nil {
+		_ = taxi.WriteError(w, oops.NotFound("no OpenAPI document registered"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(doc)
+}
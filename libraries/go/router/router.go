@@ -34,7 +34,9 @@ func New(svc Service) *Router {
 	config.Load(&conf)
 
 	// Create the router
-	router := taxi.NewRouter().WithLogger(slog.Errorf)
+	router := taxi.NewRouter().WithContextLogger(func(ctx context.Context, format string, v ...interface{}) {
+		slog.FromContext(ctx).Errorf(format, v...)
+	})
 	router.UseMiddleware(panicRecovery, revision(svc.Revision()))
 	router.HandleFunc(http.MethodGet, "/ping", PingHandler)
 
@@ -44,6 +46,8 @@ func New(svc Service) *Router {
 		Provider: svc,
 	})
 
+	router.HandleRaw(http.MethodGet, "/openapi.json", http.HandlerFunc(OpenAPIHandler))
+
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", conf.Port),
 		Handler: router,
@@ -97,6 +101,11 @@ func (r *Router) HandleFunc(method, path string, handler func(context.Context, t
 	r.router.HandleFunc(method, path, handler)
 }
 
+// HandleStreamFunc adds a route to the router with a taxi stream handler func
+func (r *Router) HandleStreamFunc(method, path string, handler func(context.Context, taxi.Decoder, taxi.Sender) error) {
+	r.router.HandleStreamFunc(method, path, handler)
+}
+
 // HandleRaw adds a route to the router with an http.Handler
 func (r *Router) HandleRaw(method, path string, handler http.Handler) {
 	r.router.HandleRaw(method, path, handler)
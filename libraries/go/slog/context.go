@@ -0,0 +1,54 @@
+package slog
+
+import (
+	"context"
+
+	"github.com/jakewright/home-automation/libraries/go/trace"
+)
+
+// ContextLogger logs events with extra metadata taken from a context.
+// Currently this is the trace ID, which allows all of the logs from a
+// chain of RPCs and Firehose events to be found together.
+type ContextLogger struct {
+	ctx context.Context
+}
+
+// FromContext returns a logger that adds metadata from ctx to events
+func FromContext(ctx context.Context) *ContextLogger {
+	return &ContextLogger{ctx: ctx}
+}
+
+// Debugf logs with DEBUG severity
+func (l *ContextLogger) Debugf(format string, a ...interface{}) {
+	l.log(newEventFromFormat(DebugSeverity, format, a...))
+}
+
+// Infof logs with INFO severity
+func (l *ContextLogger) Infof(format string, a ...interface{}) {
+	l.log(newEventFromFormat(InfoSeverity, format, a...))
+}
+
+// Warnf logs with WARNING severity
+func (l *ContextLogger) Warnf(format string, a ...interface{}) {
+	l.log(newEventFromFormat(WarnSeverity, format, a...))
+}
+
+// Errorf logs with ERROR severity
+func (l *ContextLogger) Errorf(format string, a ...interface{}) {
+	l.log(newEventFromFormat(ErrorSeverity, format, a...))
+}
+
+// Error logs with ERROR severity
+func (l *ContextLogger) Error(v interface{}) {
+	l.log(newEvent(ErrorSeverity, v))
+}
+
+func (l *ContextLogger) log(e *Event) {
+	if id := trace.ID(l.ctx); id != "" {
+		e.Metadata = mergeMetadata(e.Metadata, map[string]string{
+			trace.MetadataKey: id,
+		})
+	}
+
+	mustGetDefaultLogger().Log(e)
+}
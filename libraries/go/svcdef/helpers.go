@@ -2,6 +2,8 @@ package svcdef
 
 import (
 	"fmt"
+	"math"
+	"regexp"
 	"strings"
 )
 
@@ -70,65 +72,340 @@ func mergeMaps(m, n map[string]*Message) error {
 	return nil
 }
 
-func qualifyMessageTypes(messages []*Message, byQualifiedName map[string]*Message) error {
+// enumsByQualifiedName returns a map of all fully-qualified
+// enum names to enums including those from imported files
+func enumsByQualifiedName(file *File, prefix string) (map[string]*Enum, []*Enum, error) {
+	byQualifiedMap := make(map[string]*Enum)
+	var byQualifiedSlice []*Enum
+
+	for _, e := range file.Enums {
+		e.QualifiedName = prefix + "." + e.Name
+
+		if _, ok := byQualifiedMap[e.QualifiedName]; ok {
+			return nil, nil, fmt.Errorf("duplicate enum found: %s", e.QualifiedName)
+		}
+
+		byQualifiedMap[e.QualifiedName] = e
+		byQualifiedSlice = append(byQualifiedSlice, e)
+	}
+
+	for alias, i := range file.Imports {
+		importedMap, importedSlice, err := enumsByQualifiedName(i.File, alias)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for k, v := range importedMap {
+			if _, ok := byQualifiedMap[k]; ok {
+				return nil, nil, fmt.Errorf("duplicate enum found: %s", v.QualifiedName)
+			}
+			byQualifiedMap[k] = v
+		}
+
+		byQualifiedSlice = append(byQualifiedSlice, importedSlice...)
+	}
+
+	return byQualifiedMap, byQualifiedSlice, nil
+}
+
+// typeSet holds all of the named types that
+// can be referenced from within a def file
+type typeSet struct {
+	messages map[string]*Message
+	enums    map[string]*Enum
+}
+
+// validate returns an error if a message and
+// an enum have the same fully-qualified name
+func (ts *typeSet) validate() error {
+	for name := range ts.enums {
+		if _, ok := ts.messages[name]; ok {
+			return fmt.Errorf("enum %s has the same name as a message", name)
+		}
+	}
+	return nil
+}
+
+// exists returns whether a message or enum with the fully-qualified
+// name exists and, if so, whether it is an enum
+func (ts *typeSet) exists(qualified string) (found bool, enum bool) {
+	if _, ok := ts.messages[qualified]; ok {
+		return true, false
+	}
+
+	if _, ok := ts.enums[qualified]; ok {
+		return true, true
+	}
+
+	return false, false
+}
+
+func qualifyMessageTypes(messages []*Message, ts *typeSet) error {
 	for _, m := range messages {
 		for _, f := range m.Fields {
 			var err error
 
 			if f.Type.Map {
-				f.Type.MapKey.Qualified, err = qualifyType(f.Type.MapKey.Name, m.QualifiedName, byQualifiedName)
+				f.Type.MapKey.Qualified, f.Type.MapKey.Enum, err = ts.qualify(f.Type.MapKey.Name, m.QualifiedName)
 				if err != nil {
 					return fmt.Errorf("failed to qualify key type %s on field %s in message %s", f.Type.Name, f.Name, m.QualifiedName)
 				}
 
-				f.Type.MapValue.Qualified, err = qualifyType(f.Type.MapValue.Name, m.QualifiedName, byQualifiedName)
+				f.Type.MapValue.Qualified, f.Type.MapValue.Enum, err = ts.qualify(f.Type.MapValue.Name, m.QualifiedName)
 				if err != nil {
 					return fmt.Errorf("failed to qualify value type %s on field %s in message %s", f.Type.Name, f.Name, m.QualifiedName)
 				}
 			} else {
-				f.Type.Qualified, err = qualifyType(f.Type.Name, m.QualifiedName, byQualifiedName)
+				f.Type.Qualified, f.Type.Enum, err = ts.qualify(f.Type.Name, m.QualifiedName)
 				if err != nil {
 					return fmt.Errorf("failed to qualify type %s on field %s in message %s", f.Type.Name, f.Name, m.QualifiedName)
 				}
 			}
 		}
 
-		if err := qualifyMessageTypes(m.Nested, byQualifiedName); err != nil {
+		if err := qualifyMessageTypes(m.Nested, ts); err != nil {
 			return fmt.Errorf("failed to qualify nested message field types: %v", err)
 		}
 	}
 	return nil
 }
 
-// qualifyType returns the fully-qualified type by looking
+// qualify returns the fully-qualified type by looking
 //   - for an imported type (top-level from imported file only)
 //   - for a scoped type
 //   - for a top-level local type
-func qualifyType(typ, scope string, messagesByQualifiedName map[string]*Message) (string, error) {
+//
+// The second return value is true if the type is an enum.
+func (ts *typeSet) qualify(typ, scope string) (string, bool, error) {
 	// If it contains a dot, assume imported type but verify.
 	if parts := strings.SplitN(typ, ".", 2); len(parts) == 2 {
 		// We should be able to look this up directly
-		if _, ok := messagesByQualifiedName[typ]; ok {
-			return typ, nil
+		if found, enum := ts.exists(typ); found {
+			return typ, enum, nil
 		}
-		return "", fmt.Errorf("failed to find imported message matching %s", typ)
+		return "", false, fmt.Errorf("failed to find imported message or enum matching %s", typ)
 	}
 
 	// If this type has a scope, i.e. it is inside a message
 	if scope != "" {
 		// Look for a message in the scope
-		if _, ok := messagesByQualifiedName[scope+"."+typ]; ok {
-			return scope + "." + typ, nil
+		if found, enum := ts.exists(scope + "." + typ); found {
+			return scope + "." + typ, enum, nil
 		}
 	}
 
-	// Look for a message defined at the top level
-	if _, ok := messagesByQualifiedName["."+typ]; ok {
-		return "." + typ, nil
+	// Look for a message or enum defined at the top level
+	if found, enum := ts.exists("." + typ); found {
+		return "." + typ, enum, nil
 	}
 
 	// This must be a simple type. Return the empty string
 	// because it doesn't make sense to have a qualified
-	// type for anything but messages.
-	return "", nil
+	// type for anything but messages and enums.
+	return "", false, nil
+}
+
+// validateFieldOptions returns an error if the deprecated, default or
+// validation constraint options on any of the messages' fields are invalid.
+// It must be called after the field types have been qualified so that
+// enums are known.
+func validateFieldOptions(messages []*Message, ts *typeSet) error {
+	for _, m := range messages {
+		for _, f := range m.Fields {
+			if v, ok := f.Options["deprecated"]; ok {
+				if _, ok := v.(bool); !ok {
+					return fmt.Errorf("deprecated option on field %s in message %s must be a bool", f.Name, m.QualifiedName)
+				}
+			}
+
+			if err := validateConstraints(f); err != nil {
+				return fmt.Errorf("invalid option on field %s in message %s: %v", f.Name, m.QualifiedName, err)
+			}
+
+			def, ok := f.Options["default"]
+			if !ok {
+				continue
+			}
+
+			switch {
+			case f.Type.Repeated, f.Type.Map:
+				return fmt.Errorf("field %s in message %s cannot have a default because it is not a scalar type", f.Name, m.QualifiedName)
+			case f.Oneof != "":
+				return fmt.Errorf("field %s in message %s cannot have a default because it is in a oneof", f.Name, m.QualifiedName)
+			case f.Options["required"] == true:
+				return fmt.Errorf("field %s in message %s cannot have a default because it is required", f.Name, m.QualifiedName)
+			case f.Options["deprecated"] == true:
+				// The default would make it look like
+				// clients are still setting the field.
+				return fmt.Errorf("field %s in message %s cannot have a default because it is deprecated", f.Name, m.QualifiedName)
+			}
+
+			if err := ts.validateDefault(f.Type, def); err != nil {
+				return fmt.Errorf("invalid default on field %s in message %s: %v", f.Name, m.QualifiedName, err)
+			}
+		}
+
+		if err := validateFieldOptions(m.Nested, ts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateConstraints returns an error if any of the min_len, max_len,
+// pattern, non_empty or unique options cannot be applied to the field
+func validateConstraints(f *Field) error {
+	isString := f.Type.Name == "string" && !f.Type.Repeated && !f.Type.Map
+
+	lens := map[string]int64{}
+	for _, opt := range []string{"min_len", "max_len"} {
+		v, ok := f.Options[opt]
+		if !ok {
+			continue
+		}
+
+		if !isString && !f.Type.Repeated {
+			return fmt.Errorf("%s can only be used on strings and repeated fields", opt)
+		}
+
+		i, ok := v.(int64)
+		if !ok || i < 0 {
+			return fmt.Errorf("%s must be a non-negative integer", opt)
+		}
+
+		lens[opt] = i
+	}
+
+	if min, ok := lens["min_len"]; ok {
+		if max, ok := lens["max_len"]; ok && min > max {
+			return fmt.Errorf("min_len %d is greater than max_len %d", min, max)
+		}
+	}
+
+	if v, ok := f.Options["pattern"]; ok {
+		if !isString {
+			return fmt.Errorf("pattern can only be used on strings")
+		}
+
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("pattern must be a string")
+		}
+
+		if _, err := regexp.Compile(s); err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+	}
+
+	if v, ok := f.Options["non_empty"]; ok {
+		if !f.Type.Map {
+			return fmt.Errorf("non_empty can only be used on maps")
+		}
+
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("non_empty must be a bool")
+		}
+	}
+
+	if v, ok := f.Options["unique"]; ok {
+		if !f.Type.Repeated || !(f.Type.Enum || isComparableScalar(f.Type.Name)) {
+			return fmt.Errorf("unique can only be used on repeated scalars")
+		}
+
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("unique must be a bool")
+		}
+	}
+
+	return nil
+}
+
+// isComparableScalar returns whether values of the
+// simple type can be compared for equality
+func isComparableScalar(name string) bool {
+	switch name {
+	case "bool", "string", "float32", "float64",
+		"int8", "int32", "int64", "uint8", "uint32", "uint64":
+		return true
+	}
+
+	return false
+}
+
+// validateDefault returns an error if the literal value
+// cannot be assigned to a field of the given type
+func (ts *typeSet) validateDefault(t *Type, v interface{}) error {
+	if t.Enum {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected a string for enum %s but got %T", t.Qualified, v)
+		}
+
+		for _, value := range ts.enums[t.Qualified].Values {
+			if value.Value == s {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%q is not a value of enum %s", s, t.Qualified)
+	}
+
+	if t.Qualified != "" {
+		return fmt.Errorf("message type %s cannot have a default", t.Qualified)
+	}
+
+	switch t.Name {
+	case "bool":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("expected a bool but got %T", v)
+		}
+
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("expected a string but got %T", v)
+		}
+
+	case "float32", "float64":
+		switch v.(type) {
+		case int64, float64:
+		default:
+			return fmt.Errorf("expected a number but got %T", v)
+		}
+
+	case "int8", "int32", "int64", "uint8", "uint32", "uint64":
+		i, ok := v.(int64)
+		if !ok {
+			return fmt.Errorf("expected an integer but got %T", v)
+		}
+
+		if min, max := intRange(t.Name); i < min || i > max {
+			return fmt.Errorf("%d overflows %s", i, t.Name)
+		}
+
+	default:
+		return fmt.Errorf("type %s cannot have a default", t.Name)
+	}
+
+	return nil
+}
+
+// intRange returns the smallest and largest values of the integer type
+// that can be written as a literal. Literals larger than the maximum
+// int64 cannot be parsed so that is the limit for uint64.
+func intRange(name string) (int64, int64) {
+	switch name {
+	case "int8":
+		return math.MinInt8, math.MaxInt8
+	case "int32":
+		return math.MinInt32, math.MaxInt32
+	case "uint8":
+		return 0, math.MaxUint8
+	case "uint32":
+		return 0, math.MaxUint32
+	case "uint64":
+		return 0, math.MaxInt64
+	}
+
+	return math.MinInt64, math.MaxInt64
 }
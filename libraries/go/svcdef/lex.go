@@ -63,6 +63,8 @@ const (
 	tokService      tokenType = "service"       // service keyword
 	tokRPC          tokenType = "rpc"           // rpc keyword
 	tokMessage      tokenType = "message"       // message keyword
+	tokEnum         tokenType = "enum"          // enum keyword
+	tokOneof        tokenType = "oneof"         // oneof keyword
 	tokEOF          tokenType = "eof"           // end of file
 )
 
@@ -71,6 +73,8 @@ var key = map[string]tokenType{
 	"service": tokService,
 	"rpc":     tokRPC,
 	"message": tokMessage,
+	"enum":    tokEnum,
+	"oneof":   tokOneof,
 }
 
 var symbol = map[rune]tokenType{
@@ -99,24 +99,47 @@ func (p *Parser) Parse(filename string) (file *File, err error) {
 	}
 	p.f.FlatMessages = byQualifiedSlice
 
+	enumsByName, enumsSlice, err := enumsByQualifiedName(p.f, "")
+	if err != nil {
+		p.error("failed to get enums by qualified name: %v", err)
+	}
+	p.f.FlatEnums = enumsSlice
+
+	ts := &typeSet{
+		messages: byQualifiedName,
+		enums:    enumsByName,
+	}
+
+	if err := ts.validate(); err != nil {
+		p.error("%v", err)
+	}
+
 	if p.f.Service != nil {
 		for _, r := range p.f.Service.RPCs {
-			r.InputType.Qualified, err = qualifyType(r.InputType.Name, "", byQualifiedName)
+			r.InputType.Qualified, r.InputType.Enum, err = ts.qualify(r.InputType.Name, "")
 			if err != nil {
 				p.error("failed to qualify %s input type %s: %v", r.Name, r.InputType.Name, err)
 			}
 
-			r.OutputType.Qualified, err = qualifyType(r.OutputType.Name, "", byQualifiedName)
+			r.OutputType.Qualified, r.OutputType.Enum, err = ts.qualify(r.OutputType.Name, "")
 			if err != nil {
 				p.error("failed to qualify %s output type %s: %v", r.Name, r.OutputType.Name, err)
 			}
+
+			if r.InputType.Enum || r.OutputType.Enum {
+				p.error("RPC %s cannot have an enum as its input or output type", r.Name)
+			}
 		}
 	}
 
-	if err := qualifyMessageTypes(p.f.Messages, byQualifiedName); err != nil {
+	if err := qualifyMessageTypes(p.f.Messages, ts); err != nil {
 		p.error("failed to qualify message field types: %v", err)
 	}
 
+	if err := validateFieldOptions(p.f.Messages, ts); err != nil {
+		p.error("%v", err)
+	}
+
 	return p.f, nil
 }
 
@@ -214,6 +237,7 @@ type parseFn func(*Parser) parseFn
 //   - import statement
 //   - service definition
 //   - message definition
+//   - enum definition
 //   - option e.g. foo = "bar"
 // or and end-of-file token.
 //
@@ -228,6 +252,8 @@ func parse(p *Parser) parseFn {
 			return parseService
 		case tokMessage:
 			p.f.addMessage(parseMessage(p, nil))
+		case tokEnum:
+			p.f.addEnum(parseEnum(p))
 		case tokIdentifier:
 			key, val := parseOption(p)
 			p.f.addOption(key, val)
@@ -305,18 +331,34 @@ func parseInsideService(p *Parser) parseFn {
 //           file or in another (denoted by prefixing the type with
 //           the import alias and a dot, e.g. user.Address)
 //
+// the response type can be prefixed with "stream" to denote that
+// the RPC returns a stream of responses rather than just one, e.g.
+// rpc WatchFoo(WatchFooRequest) stream Foo {
+//
 // inside the braces, arbitrary options can be defined, e.g.
 //   method = "GET"
 //   ⬑ must be a valid identifier
 //            ⬑ the value can be a quoted string, a number, or a boolean (true or false)
 func parseRPC(p *Parser) parseFn {
-	ts := p.expectn(tokRPC, tokIdentifier, tokOpenParen, tokIdentifier, tokCloseParen, tokIdentifier, tokOpenBrace)
+	ts := p.expectn(tokRPC, tokIdentifier, tokOpenParen, tokIdentifier, tokCloseParen)
+
+	// "stream" is not a keyword so that it can still be
+	// used as a name elsewhere. It is only special if it
+	// is followed by another identifier here.
+	var stream bool
+	if ps := p.peekn(2); ps[0].typ == tokIdentifier && ps[0].val == "stream" && ps[1].typ == tokIdentifier {
+		p.expect(tokIdentifier)
+		stream = true
+	}
+
+	ts = append(ts, p.expectn(tokIdentifier, tokOpenBrace)...)
 
 	inType := ts[3].val
 	outType := ts[5].val
 
 	rpc := &RPC{
-		Name: ts[1].val,
+		Name:   ts[1].val,
+		Stream: stream,
 		InputType: &Type{ // RPC types cannot be optional, repeated or map types
 			Name:     inType,
 			Original: inType,
@@ -409,30 +451,164 @@ Loop:
 			continue
 		}
 
-		typ := parseType(p)
-		f := p.expect(tokIdentifier) // field name
+		// If this is a oneof block
+		if p.peek().typ == tokOneof {
+			parseOneof(p, message)
+			continue
+		}
+
+		message.addField(parseField(p))
+	}
+
+	return message
+}
+
+// parseField parses a field definition of the form
+//   string name (required)
+//   ⬑ the type
+//          ⬑ the field name
+//               ⬑ optional field options
+func parseField(p *Parser) *Field {
+	typ := parseType(p)
+	f := p.expect(tokIdentifier) // field name
+
+	// If a field option is defined
+	var opts map[string]interface{}
+	if p.peek().typ == tokOpenParen {
+		opts = parseFieldOptions(p)
+		if len(opts) == 0 {
+			opts = nil // This makes tests cleaner
+		}
+	}
+
+	if p.peek().typ == tokComment {
+		p.expect(tokComment) // Ignore end-of-line comments
+	}
+
+	return &Field{
+		Name:    f.val,
+		Type:    typ,
+		Options: opts,
+	}
+}
+
+// parseOneof parses a oneof block inside a message
+//   oneof kind {
+//         ⬑ must be a valid identifier
+//     string func
+//     string command
+//     ⬑ fields are defined in the same way as in messages
+//       but cannot be repeated, maps or required
+//   }
+//
+// The fields are added to the message's fields as
+// well as to the oneof, with Field.Oneof set.
+func parseOneof(p *Parser, message *Message) {
+	ts := p.expectn(tokOneof, tokIdentifier, tokOpenBrace)
+
+	oneof := &Oneof{
+		Name: ts[1].val,
+	}
 
-		// If a field option is defined
-		var opts map[string]interface{}
-		if p.peek().typ == tokOpenParen {
-			opts = parseFieldOptions(p)
-			if len(opts) == 0 {
-				opts = nil // This makes tests cleaner
+Loop:
+	for {
+		switch t := p.peek(); t.typ {
+		case tokCloseBrace: // end of the oneof
+			p.nextNonSpace()
+			break Loop
+		case tokComment:
+			p.expect(tokComment) // comments are ignored
+		default:
+			f := parseField(p)
+			if f.Type.Repeated || f.Type.Map {
+				p.error("field %s in oneof %s cannot be repeated or a map", f.Name, oneof.Name)
 			}
+			if _, ok := f.Options["required"]; ok {
+				p.error("field %s in oneof %s cannot be required", f.Name, oneof.Name)
+			}
+
+			f.Oneof = oneof.Name
+			oneof.Fields = append(oneof.Fields, f)
+			message.addField(f)
 		}
+	}
 
-		if p.peek().typ == tokComment {
-			p.expect(tokComment) // Ignore end-of-line comments
+	if len(oneof.Fields) < 2 {
+		p.error("oneof %s should have at least two fields", oneof.Name)
+	}
+
+	message.Oneofs = append(message.Oneofs, oneof)
+}
+
+// parseEnum parses an enum definition of the form
+//   enum Input {
+//        ⬑ must be a valid identifier
+//     BD_DVD
+//     ⬑ each value must be a valid identifier
+//     BOOLEAN = "boolean"
+//               ⬑ optionally, a quoted string can be given
+//                 to use when the value is marshaled
+//   }
+//
+// Enums can only be defined at the top level of the file.
+func parseEnum(p *Parser) *Enum {
+	ts := p.expectn(tokEnum, tokIdentifier, tokOpenBrace)
+
+	enum := &Enum{
+		Name: ts[1].val,
+		// The qualified name is set when the file
+		// has been parsed, as for messages.
+		QualifiedName: ts[1].val,
+	}
+
+	names := map[string]bool{}
+	values := map[string]bool{}
+
+Loop:
+	for {
+		switch t := p.peek(); t.typ {
+		case tokCloseBrace: // end of the enum definition
+			p.nextNonSpace()
+			break Loop
+		case tokComment:
+			p.expect(tokComment) // comments are ignored
+		case tokIdentifier:
+			name := p.expect(tokIdentifier).val
+			value := name
+
+			if p.peek().typ == tokAssign {
+				p.expect(tokAssign)
+
+				t := p.expect(tokString)
+				s, err := strconv.Unquote(t.val)
+				if err != nil {
+					p.error("failed to unquote token %s", t)
+				}
+				value = s
+			}
+
+			if names[name] {
+				p.error("duplicate name %s in enum %s", name, enum.Name)
+			}
+			if values[value] {
+				p.error("duplicate value %q in enum %s", value, enum.Name)
+			}
+			names[name], values[value] = true, true
+
+			enum.Values = append(enum.Values, &EnumValue{
+				Name:  name,
+				Value: value,
+			})
+		default:
+			p.error("unexpected token in enum: %s", t)
 		}
+	}
 
-		message.Fields = append(message.Fields, &Field{
-			Name:    f.val,
-			Type:    typ,
-			Options: opts,
-		})
+	if len(enum.Values) == 0 {
+		p.error("enum %s has no values", enum.Name)
 	}
 
-	return message
+	return enum
 }
 
 func parseType(p *Parser) *Type {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"gotest.tools/assert"
+	"gotest.tools/assert/cmp"
 )
 
 func TestParser_Parse_emptyFile(t *testing.T) {
@@ -110,6 +111,40 @@ message GetFooResponse {
 	assert.DeepEqual(t, expected, f)
 }
 
+func TestParser_Parse_streamRPC(t *testing.T) {
+	input := []byte(`service Test {
+	rpc WatchFoo(WatchFooRequest) stream Foo {
+		method = "GET"
+	}
+}
+
+message WatchFooRequest {
+}
+
+message Foo {
+	string stream
+}
+`)
+
+	fr := &mockFileReader{
+		files: map[string][]byte{
+			"test.def": input,
+		},
+	}
+
+	f, err := NewParser(fr).Parse("test.def")
+	assert.NilError(t, err)
+
+	assert.Equal(t, 1, len(f.Service.RPCs))
+	rpc := f.Service.RPCs[0]
+	assert.Equal(t, true, rpc.Stream)
+	assert.Equal(t, ".WatchFooRequest", rpc.InputType.Qualified)
+	assert.Equal(t, ".Foo", rpc.OutputType.Qualified)
+
+	// stream can still be used as a field name
+	assert.Equal(t, "stream", f.Messages[1].Fields[0].Name)
+}
+
 func TestParser_Parse_nestedMessage(t *testing.T) {
 	input := []byte(`message Foo {
 	message Bar {
@@ -204,6 +239,116 @@ func TestParser_Parse_fieldOptions(t *testing.T) {
 	assert.DeepEqual(t, expected, actual)
 }
 
+func TestParser_Parse_fieldDefaults(t *testing.T) {
+	input := []byte(`enum Mode {
+	ON = "on"
+	OFF = "off"
+}
+
+message Foo {
+	int32 brightness (default = 100)
+	float64 level (default = 1)
+	Mode mode (default = "off")
+	string name (deprecated)
+}`)
+
+	fr := &mockFileReader{
+		files: map[string][]byte{
+			"test.def": input,
+		},
+	}
+
+	actual, err := NewParser(fr).Parse("test.def")
+	assert.NilError(t, err)
+
+	fields := actual.Messages[0].Fields
+	assert.Equal(t, len(fields), 4)
+	assert.Equal(t, fields[0].Options["default"], int64(100))
+	assert.Equal(t, fields[1].Options["default"], int64(1))
+	assert.Equal(t, fields[2].Options["default"], "off")
+	assert.Equal(t, fields[3].Options["deprecated"], true)
+}
+
+func TestParser_Parse_invalidFieldDefaults(t *testing.T) {
+	tests := map[string]string{
+		"string on int":        `int32 foo (default = "5")`,
+		"int overflow":         `uint8 foo (default = 256)`,
+		"negative unsigned":    `uint32 foo (default = -1)`,
+		"float on int":         `int64 foo (default = 1.5)`,
+		"number on bool":       `bool foo (default = 1)`,
+		"repeated":             `[]string foo (default = "a")`,
+		"required":             `string foo (required, default = "a")`,
+		"deprecated":           `string foo (deprecated, default = "a")`,
+		"unknown enum value":   `Mode foo (default = "ON")`,
+		"message type":         `Bar foo (default = "a")`,
+		"unsupported type":     `time foo (default = "a")`,
+		"non-bool deprecation": `string foo (deprecated = "yes")`,
+	}
+
+	for name, field := range tests {
+		t.Run(name, func(t *testing.T) {
+			fr := &mockFileReader{
+				files: map[string][]byte{
+					"test.def": []byte("enum Mode {\n\tON = \"on\"\n}\n\nmessage Bar {\n}\n\nmessage Foo {\n\t" + field + "\n}"),
+				},
+			}
+
+			assert.Assert(t, cmp.Panics(func() {
+				_, _ = NewParser(fr).Parse("test.def")
+			}))
+		})
+	}
+}
+
+func TestParser_Parse_fieldConstraints(t *testing.T) {
+	input := []byte(`enum Mode {
+	ON
+}
+
+message Foo {
+	string name (min_len = 1, max_len = 10, pattern = "^[a-z]+$")
+	[]Mode modes (min_len = 1, unique)
+	map[string]string labels (non_empty)
+}`)
+
+	fr := &mockFileReader{
+		files: map[string][]byte{
+			"test.def": input,
+		},
+	}
+
+	_, err := NewParser(fr).Parse("test.def")
+	assert.NilError(t, err)
+}
+
+func TestParser_Parse_invalidFieldConstraints(t *testing.T) {
+	tests := map[string]string{
+		"min_len on int":      `int32 foo (min_len = 1)`,
+		"negative max_len":    `string foo (max_len = -1)`,
+		"min_len > max_len":   `string foo (min_len = 5, max_len = 4)`,
+		"non-string min_len":  `string foo (min_len = "1")`,
+		"pattern on repeated": `[]string foo (pattern = "a")`,
+		"invalid pattern":     `string foo (pattern = "(")`,
+		"non_empty on string": `string foo (non_empty)`,
+		"unique on message":   `[]Bar foo (unique)`,
+		"unique on scalar":    `string foo (unique)`,
+	}
+
+	for name, field := range tests {
+		t.Run(name, func(t *testing.T) {
+			fr := &mockFileReader{
+				files: map[string][]byte{
+					"test.def": []byte("message Bar {\n}\n\nmessage Foo {\n\t" + field + "\n}"),
+				},
+			}
+
+			assert.Assert(t, cmp.Panics(func() {
+				_, _ = NewParser(fr).Parse("test.def")
+			}))
+		})
+	}
+}
+
 func TestParser_Parse_mapType(t *testing.T) {
 	input := []byte(`message Foo {
 	message Bar {
@@ -300,6 +445,65 @@ message Baz {
 	assert.DeepEqual(t, expected, actual)
 }
 
+func TestParser_Parse_oneof(t *testing.T) {
+	input := []byte(`message Action {
+	int32 stage
+	oneof kind {
+		string func
+		// Comments are ignored
+		string command (foo = "bar")
+	}
+}`)
+
+	fr := &mockFileReader{
+		files: map[string][]byte{
+			"test.def": input,
+		},
+	}
+
+	f, err := NewParser(fr).Parse("test.def")
+	assert.NilError(t, err)
+
+	fn := &Field{
+		Name:  "func",
+		Type:  &Type{Name: "string", Original: "string"},
+		Oneof: "kind",
+	}
+	command := &Field{
+		Name:    "command",
+		Type:    &Type{Name: "string", Original: "string"},
+		Options: map[string]interface{}{"foo": "bar"},
+		Oneof:   "kind",
+	}
+
+	action := &Message{
+		Name:          "Action",
+		QualifiedName: ".Action",
+		Fields: []*Field{
+			{
+				Name: "stage",
+				Type: &Type{Name: "int32", Original: "int32"},
+			},
+			fn,
+			command,
+		},
+		Oneofs: []*Oneof{
+			{
+				Name:   "kind",
+				Fields: []*Field{fn, command},
+			},
+		},
+	}
+
+	expected := &File{
+		Path:         "test.def",
+		Messages:     []*Message{action},
+		FlatMessages: []*Message{action},
+	}
+
+	assert.DeepEqual(t, expected, f)
+}
+
 func TestParser_Parse_importedType(t *testing.T) {
 	file1 := []byte(`import bar "../service.bar/bar.def"
 
@@ -377,6 +581,89 @@ message Bar {
 	assert.DeepEqual(t, expected, f)
 }
 
+func TestParser_Parse_enum(t *testing.T) {
+	file1 := []byte(`import bar "../service.bar/bar.def"
+
+enum Input {
+	BD_DVD
+	// Comments are ignored
+	GAME
+}
+
+message Msg {
+	Input input
+	[]bar.Type types
+}
+`)
+
+	file2 := []byte(`enum Type {
+	BOOLEAN = "boolean"
+	NUMBER = "number"
+}`)
+
+	fr := &mockFileReader{
+		files: map[string][]byte{
+			"service.foo/foo.def": file1,
+			"service.bar/bar.def": file2,
+		},
+	}
+
+	f, err := NewParser(fr).Parse("service.foo/foo.def")
+	assert.NilError(t, err)
+
+	input := &Enum{
+		Name:          "Input",
+		QualifiedName: ".Input",
+		Values: []*EnumValue{
+			{Name: "BD_DVD", Value: "BD_DVD"},
+			{Name: "GAME", Value: "GAME"},
+		},
+	}
+	typ := &Enum{
+		Name:          "Type",
+		QualifiedName: "bar.Type",
+		Values: []*EnumValue{
+			{Name: "BOOLEAN", Value: "boolean"},
+			{Name: "NUMBER", Value: "number"},
+		},
+	}
+	msg := &Message{
+		Name:          "Msg",
+		QualifiedName: ".Msg",
+		Fields: []*Field{
+			{
+				Name: "input",
+				Type: &Type{Name: "Input", Original: "Input", Qualified: ".Input", Enum: true},
+			},
+			{
+				Name: "types",
+				Type: &Type{Name: "bar.Type", Original: "[]bar.Type", Qualified: "bar.Type", Enum: true, Repeated: true},
+			},
+		},
+	}
+
+	expected := &File{
+		Path: "service.foo/foo.def",
+		Imports: map[string]*Import{
+			"bar": {
+				File: &File{
+					Path:      "service.bar/bar.def",
+					Enums:     []*Enum{typ},
+					FlatEnums: []*Enum{typ},
+				},
+				Alias: "bar",
+				Path:  "../service.bar/bar.def",
+			},
+		},
+		Messages:     []*Message{msg},
+		FlatMessages: []*Message{msg},
+		Enums:        []*Enum{input},
+		FlatEnums:    []*Enum{input, typ},
+	}
+
+	assert.DeepEqual(t, expected, f)
+}
+
 func TestParser_Parse_circularImport(t *testing.T) {
 	fr := &mockFileReader{
 		files: map[string][]byte{
@@ -27,6 +27,12 @@ type File struct {
 	// nested and imported.
 	FlatMessages []*Message
 
+	// Enums are all of the enum types defined in the file
+	Enums []*Enum
+
+	// FlatEnums contains all of the enums, including imported.
+	FlatEnums []*Enum
+
 	// Options are arbitrary options defined
 	// at the top level in the def file
 	Options map[string]interface{}
@@ -44,6 +50,10 @@ func (f *File) addMessage(msg *Message) {
 	f.Messages = append(f.Messages, msg)
 }
 
+func (f *File) addEnum(e *Enum) {
+	f.Enums = append(f.Enums, e)
+}
+
 func (f *File) addOption(key string, value interface{}) {
 	if f.Options == nil {
 		f.Options = map[string]interface{}{}
@@ -94,6 +104,10 @@ type RPC struct {
 	// OutputType is the output type
 	OutputType *Type
 
+	// Stream is set if the RPC returns a stream of
+	// messages of the output type rather than one
+	Stream bool
+
 	// Options are arbitrary options defined within the RPC
 	Options map[string]interface{}
 }
@@ -121,8 +135,12 @@ type Message struct {
 	QualifiedName string
 
 	// Fields are the type-name pairs defined in the message
+	// including those defined inside oneof blocks
 	Fields []*Field
 
+	// Oneofs are the oneof blocks defined in the message
+	Oneofs []*Oneof
+
 	// Nested is the list of nested messages defined
 	// within this message
 	Nested []*Message
@@ -155,6 +173,42 @@ func (m *Message) Lineage() (string, []string) {
 	return parts[0], parts[1:]
 }
 
+// Enum is a representation of an enum definition
+type Enum struct {
+	// Name is the simple name given in the file, e.g. "Input"
+	Name string
+
+	// QualifiedName is the fully-qualified name. For an
+	// enum defined in the main def file, it will be the
+	// name prefixed with a dot, e.g. ".Input". For an
+	// imported enum, it will be prefixed with the import
+	// alias, e.g. "foo.Input". Enums cannot be nested.
+	QualifiedName string
+
+	// Values are the values defined in the enum
+	Values []*EnumValue
+}
+
+// EnumValue is a representation of a single value of an enum
+type EnumValue struct {
+	// Name is the identifier given in the def file e.g. "BD_DVD"
+	Name string
+
+	// Value is the string that represents the value when
+	// it is marshaled. It defaults to the name.
+	Value string
+}
+
+// Oneof is a representation of a oneof block inside a message.
+// Exactly one of the fields in the block should be set.
+type Oneof struct {
+	// Name is the name given in the def file
+	Name string
+
+	// Fields are the fields defined in the block
+	Fields []*Field
+}
+
 // Field is a representation of a type-name pair
 type Field struct {
 	// name is the name given in the def file
@@ -165,6 +219,10 @@ type Field struct {
 
 	// Options are arbitrary options defined in parenthesis after the field definition
 	Options map[string]interface{}
+
+	// Oneof is the name of the oneof block that the
+	// field is defined in or the empty string
+	Oneof string
 }
 
 // Type is a representation of a type
@@ -191,6 +249,10 @@ type Type struct {
 	//       understand how to interpret the type
 	Qualified string
 
+	// Enum is set if the qualified type
+	// refers to an enum rather than a message
+	Enum bool
+
 	// Message is the message that the type refers to.
 	// It is nil if it is a "simple", non-message type.
 	//Message *Message
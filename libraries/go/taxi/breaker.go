@@ -0,0 +1,259 @@
+package taxi
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// BreakerPolicy configures the circuit breakers used by a Client. A
+// separate breaker is kept for each upstream host. A breaker trips
+// after Threshold consecutive failures, after which requests to the
+// host fail immediately with an oops.ErrUnavailable error. Once the
+// Cooldown has passed, a single trial request is let through. If it
+// succeeds the breaker is reset, otherwise it trips again.
+type BreakerPolicy struct {
+	// Threshold is the number of consecutive failures
+	// after which the breaker trips.
+	Threshold int
+
+	// Cooldown is how long the breaker stays open
+	// before a trial request is let through.
+	Cooldown time.Duration
+}
+
+// DefaultBreakerPolicy trips after five consecutive
+// failures and tries again after thirty seconds.
+var DefaultBreakerPolicy = &BreakerPolicy{
+	Threshold: 5,
+	Cooldown:  30 * time.Second,
+}
+
+type breakerState string
+
+const (
+	breakerClosed   breakerState = "closed"
+	breakerOpen     breakerState = "open"
+	breakerHalfOpen breakerState = "half-open"
+)
+
+// breaker is a circuit breaker for a single upstream host
+type breaker struct {
+	policy   *BreakerPolicy
+	state    breakerState
+	failures int
+	openedAt time.Time
+	mu       sync.Mutex
+
+	// now is overridden in tests
+	now func() time.Time
+}
+
+// allow returns whether a request should be made
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.policy.Cooldown {
+			return false
+		}
+
+		// Let a single trial request through
+		b.state = breakerHalfOpen
+		return true
+
+	case breakerHalfOpen:
+		// A trial request is already in flight
+		return false
+	}
+
+	return true
+}
+
+// record updates the breaker with the outcome of a request
+func (b *breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+
+	if b.state == breakerHalfOpen || b.failures >= b.policy.Threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// abandon is called instead of record when the outcome of a request is
+// unknown. If the request was a trial, the breaker goes back to being
+// open so that the next request becomes the trial.
+func (b *breaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+	}
+}
+
+func (b *breaker) getState() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// breakers holds a circuit breaker for each upstream host
+type breakers struct {
+	policy *BreakerPolicy
+	byHost map[string]*breaker
+	mu     sync.Mutex
+}
+
+func newBreakers(policy *BreakerPolicy) *breakers {
+	return &breakers{
+		policy: policy,
+		byHost: make(map[string]*breaker),
+	}
+}
+
+// get returns the breaker for the host, creating it if necessary.
+// If the set of breakers is nil, nil is returned.
+func (s *breakers) get(host string) *breaker {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.byHost[host]
+	if !ok {
+		b = &breaker{
+			policy: s.policy,
+			state:  breakerClosed,
+			now:    time.Now,
+		}
+		s.byHost[host] = b
+	}
+
+	return b
+}
+
+// do makes the request if the host's breaker allows it and records the
+// outcome. If the set of breakers is nil, the request is always made.
+// The returned bool is false if the request was rejected by the breaker.
+func (s *breakers) do(base Doer, req *http.Request) (*http.Response, bool, error) {
+	b := s.get(req.URL.Host)
+	if b == nil {
+		rsp, err := base.Do(req)
+		return rsp, true, err
+	}
+
+	if !b.allow() {
+		return nil, false, oops.Unavailable("circuit breaker open for %s", req.URL.Host)
+	}
+
+	rsp, err := base.Do(req)
+	if req.Context().Err() != nil {
+		// The caller giving up says nothing
+		// about the health of the upstream
+		b.abandon()
+	} else {
+		b.record(isFailure(rsp, err))
+	}
+
+	return rsp, true, err
+}
+
+// tripped returns a sorted list of hosts whose breakers are not closed
+func (s *breakers) tripped() []string {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var hosts []string
+	for host, b := range s.byHost {
+		if b.getState() != breakerClosed {
+			hosts = append(hosts, host)
+		}
+	}
+
+	sort.Strings(hosts)
+	return hosts
+}
+
+// isFailure returns whether the outcome of a request should
+// count against the upstream. This matches the set of
+// outcomes that the RetryPolicy considers transient.
+func isFailure(rsp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return rsp.StatusCode >= 500 || rsp.StatusCode == http.StatusRequestTimeout
+}
+
+// healthCheck returns an error listing the
+// upstream hosts whose breakers have tripped
+func (s *breakers) healthCheck() error {
+	if hosts := s.tripped(); len(hosts) > 0 {
+		return oops.Unavailable("circuit breaker open for %s", strings.Join(hosts, ", "))
+	}
+
+	return nil
+}
+
+// HealthCheck returns an error listing the upstream hosts whose circuit
+// breakers have tripped. It has the signature of a healthz.HealthCheck
+// so it can be passed directly to healthz.RegisterCheck.
+func (c *Client) HealthCheck(_ context.Context) error {
+	return c.breakers.healthCheck()
+}
+
+// BreakerDoer wraps a Doer with a circuit breaker for each upstream host.
+// It protects upstreams that are not called through a Client, e.g. third
+// party HTTP APIs. While a host's breaker is open, requests to it fail
+// immediately with an oops.ErrUnavailable error.
+type BreakerDoer struct {
+	base     Doer
+	breakers *breakers
+}
+
+// Compile-time assertion that BreakerDoer implements the Doer interface
+var _ Doer = (*BreakerDoer)(nil)
+
+// NewBreakerDoer returns a Doer that makes requests
+// using the base Doer if the host's breaker allows it
+func NewBreakerDoer(base Doer, p *BreakerPolicy) *BreakerDoer {
+	return &BreakerDoer{
+		base:     base,
+		breakers: newBreakers(p),
+	}
+}
+
+// Do makes the request and records the outcome
+func (d *BreakerDoer) Do(req *http.Request) (*http.Response, error) {
+	rsp, _, err := d.breakers.do(d.base, req)
+	return rsp, err
+}
+
+// HealthCheck returns an error listing the upstream hosts whose circuit
+// breakers have tripped. It has the signature of a healthz.HealthCheck.
+func (d *BreakerDoer) HealthCheck(_ context.Context) error {
+	return d.breakers.healthCheck()
+}
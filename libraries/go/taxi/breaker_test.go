@@ -0,0 +1,106 @@
+package taxi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+func TestClient_circuitBreaker(t *testing.T) {
+	var calls int32
+	var healthy int32
+	c := NewClientUsing(doerFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&healthy) == 0 {
+			return nil, errors.New("connection refused")
+		}
+		return (&http.Response{StatusCode: http.StatusOK}), nil
+	})).WithCircuitBreaker(&BreakerPolicy{
+		Threshold: 2,
+		Cooldown:  time.Minute,
+	})
+
+	ctx := context.Background()
+	rpc := &RPC{Method: http.MethodGet, URL: "http://ola/get_dmx"}
+
+	// The first two requests fail and trip the breaker
+	for i := 0; i < 2; i++ {
+		_, err := c.do(ctx, c.base, rpc)
+		assert.ErrorContains(t, err, "connection refused")
+	}
+	assert.ErrorContains(t, c.HealthCheck(ctx), "ola")
+
+	// Subsequent requests fail fast
+	_, err := c.do(ctx, c.base, rpc)
+	assert.Assert(t, oops.Is(err, oops.ErrUnavailable))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// Other hosts are unaffected
+	_, err = c.do(ctx, c.base, &RPC{Method: http.MethodGet, URL: "http://lirc-proxy/send-once"})
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// After the cooldown, a trial request is let through
+	b := c.breakers.get("ola")
+	b.now = func() time.Time { return time.Now().Add(time.Hour) }
+	atomic.StoreInt32(&healthy, 1)
+
+	_, err = c.do(ctx, c.base, rpc)
+	assert.NilError(t, err)
+	assert.Equal(t, breakerClosed, b.getState())
+}
+
+func TestBreaker_halfOpenFailure(t *testing.T) {
+	now := time.Now()
+	b := &breaker{
+		policy: &BreakerPolicy{Threshold: 1, Cooldown: time.Second},
+		state:  breakerClosed,
+		now:    func() time.Time { return now },
+	}
+
+	assert.Assert(t, b.allow())
+	b.record(true)
+	assert.Equal(t, breakerOpen, b.getState())
+	assert.Assert(t, !b.allow())
+
+	// Only one trial request is allowed when half-open
+	now = now.Add(2 * time.Second)
+	assert.Assert(t, b.allow())
+	assert.Equal(t, breakerHalfOpen, b.getState())
+	assert.Assert(t, !b.allow())
+
+	// A failed trial re-opens the breaker
+	b.record(true)
+	assert.Equal(t, breakerOpen, b.getState())
+	assert.Assert(t, !b.allow())
+}
+
+func TestBreakerDoer(t *testing.T) {
+	var calls int32
+	d := NewBreakerDoer(doerFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &http.Response{StatusCode: http.StatusInternalServerError}, nil
+	}), &BreakerPolicy{
+		Threshold: 1,
+		Cooldown:  time.Minute,
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "http://ola/get_dmx", nil)
+	assert.NilError(t, err)
+
+	rsp, err := d.Do(req)
+	assert.NilError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rsp.StatusCode)
+	assert.ErrorContains(t, d.HealthCheck(context.Background()), "ola")
+
+	_, err = d.Do(req)
+	assert.Assert(t, oops.Is(err, oops.ErrUnavailable))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -16,6 +16,7 @@ type Dispatcher interface {
 	Put(ctx context.Context, url string, body interface{}, v interface{}) error
 	Patch(ctx context.Context, url string, body interface{}, v interface{}) error
 	Delete(ctx context.Context, url string, body interface{}, v interface{}) error
+	Stream(ctx context.Context, rpc *RPC) (*Stream, error)
 }
 
 // Doer is an interface that http.Client implements
@@ -25,25 +26,69 @@ type Doer interface {
 
 // Client dispatches RPC requests
 type Client struct {
-	base Doer
+	base        Doer
+	streamBase  Doer
+	retryPolicy *RetryPolicy
+	breakers    *breakers
+	codec       Codec
 }
 
 // NewClient returns an initialised Client
 func NewClient() *Client {
-	return NewClientUsing(&http.Client{
-		Timeout: requestTimeout,
+	return newClient(requestTimeout)
+}
+
+// newClient returns a Client whose requests time out after the given
+// duration. Streams can stay open indefinitely so only the wait for
+// the response headers is bounded.
+func newClient(timeout time.Duration) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = timeout
+
+	c := NewClientUsing(&http.Client{
+		Timeout: timeout,
 	})
+	c.streamBase = &http.Client{
+		Transport: transport,
+	}
+
+	return c
 }
 
 // NewClientUsing returns an initialised client, using the given Doer
 // to make the HTTP requests. The standard http.Client implements the
-// Doer interface.
+// Doer interface. The Doer is also used to open streams, so an
+// http.Client with a Timeout will cut streams off after that time.
 func NewClientUsing(doer Doer) *Client {
 	return &Client{
 		base: doer,
 	}
 }
 
+// WithRetryPolicy sets the policy that the client uses to retry RPCs
+// that fail because of a transient error. If not set, RPCs are only
+// attempted once.
+func (c *Client) WithRetryPolicy(p *RetryPolicy) *Client {
+	c.retryPolicy = p
+	return c
+}
+
+// WithCircuitBreaker enables a circuit breaker for each upstream host
+// that the client makes requests to. While a host's breaker is open,
+// RPCs to it fail immediately with an oops.ErrUnavailable error.
+func (c *Client) WithCircuitBreaker(p *BreakerPolicy) *Client {
+	c.breakers = newBreakers(p)
+	return c
+}
+
+// WithCodec sets the codec that the client uses to encode request bodies.
+// The server is asked to respond using the same codec. If not set, JSON
+// is used. Responses can be decoded with any of the built-in codecs.
+func (c *Client) WithCodec(codec Codec) *Client {
+	c.codec = codec
+	return c
+}
+
 // Dispatch makes a request and returns a Future
 // that represents the in-flight request
 func (c *Client) Dispatch(ctx context.Context, rpc *RPC) *Future {
@@ -52,14 +97,14 @@ func (c *Client) Dispatch(ctx context.Context, rpc *RPC) *Future {
 
 	go func() {
 		defer close(done)
-		rsp, err := c.do(ctx, rpc)
+		rsp, err := c.do(ctx, c.base, rpc)
 
 		ftr.decode = func(v interface{}) error {
 			if err != nil {
 				return err
 			}
 
-			return decodeResponse(rsp, v)
+			return decodeResponse(rsp, v, c.codecs())
 		}
 	}()
 
@@ -96,13 +141,47 @@ func (c *Client) Delete(ctx context.Context, url string, body interface{}, v int
 	return c.Dispatch(ctx, r).DecodeResponse(v)
 }
 
-func (c *Client) do(ctx context.Context, rpc *RPC) (*http.Response, error) {
-	req, err := rpc.ToRequest(ctx)
-	if err != nil {
-		return nil, err
+func (c *Client) do(ctx context.Context, base Doer, rpc *RPC) (*http.Response, error) {
+	retryable := c.retryPolicy.retryable(rpc)
+
+	for i := 0; ; i++ {
+		// A new request is created for each attempt
+		// because the body can only be read once.
+		req, err := rpc.toRequest(ctx, c.getCodec())
+		if err != nil {
+			return nil, err
+		}
+
+		rsp, allowed, err := c.breakers.do(base, req)
+		if !allowed {
+			return nil, err
+		}
+
+		if !retryable || !c.retryPolicy.shouldRetry(ctx, i, rsp, err) {
+			return rsp, err
+		}
+
+		discard(rsp)
+
+		if err := c.retryPolicy.wait(ctx, i); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) getCodec() Codec {
+	if c.codec == nil {
+		return JSONCodec
 	}
+	return c.codec
+}
 
-	return c.base.Do(req)
+// codecs returns the set of codecs that the client can decode responses with
+func (c *Client) codecs() codecs {
+	if c.codec == nil {
+		return defaultCodecs
+	}
+	return defaultCodecs.with(c.codec)
 }
 
 // Future represents an in-flight remote procedure call
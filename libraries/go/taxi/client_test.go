@@ -0,0 +1,112 @@
+package taxi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+var testRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     &backoff.Backoff{Min: time.Millisecond, Max: time.Millisecond},
+}
+
+// flakyServer returns a server that responds with the
+// given error until it has been called n times.
+func flakyServer(n int32, err error) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= n {
+			_ = WriteError(w, err)
+			return
+		}
+		_ = WriteSuccess(w, map[string]string{"foo": "bar"})
+	}))
+	return srv, &calls
+}
+
+func TestClient_retriesInternalErrors(t *testing.T) {
+	srv, calls := flakyServer(2, oops.InternalService("boom"))
+	defer srv.Close()
+
+	c := NewClient().WithRetryPolicy(testRetryPolicy)
+
+	var rsp map[string]string
+	err := c.Get(context.Background(), srv.URL, nil, &rsp)
+	assert.NilError(t, err)
+	assert.Equal(t, "bar", rsp["foo"])
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestClient_givesUpAfterMaxAttempts(t *testing.T) {
+	srv, calls := flakyServer(5, oops.Timeout("slow"))
+	defer srv.Close()
+
+	c := NewClient().WithRetryPolicy(testRetryPolicy)
+
+	err := c.Get(context.Background(), srv.URL, nil, nil)
+	assert.Assert(t, oops.Is(err, oops.ErrTimeout))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestClient_doesNotRetryBadRequests(t *testing.T) {
+	srv, calls := flakyServer(5, oops.BadRequest("nope"))
+	defer srv.Close()
+
+	c := NewClient().WithRetryPolicy(testRetryPolicy)
+
+	err := c.Get(context.Background(), srv.URL, nil, nil)
+	assert.Assert(t, oops.Is(err, oops.ErrBadRequest))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestClient_doesNotRetryNonIdempotentMethods(t *testing.T) {
+	srv, calls := flakyServer(5, oops.InternalService("boom"))
+	defer srv.Close()
+
+	c := NewClient().WithRetryPolicy(testRetryPolicy)
+
+	err := c.Post(context.Background(), srv.URL, nil, nil)
+	assert.Assert(t, oops.Is(err, oops.ErrInternalService))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestClient_retriesIdempotentRPCs(t *testing.T) {
+	srv, calls := flakyServer(1, oops.InternalService("boom"))
+	defer srv.Close()
+
+	c := NewClient().WithRetryPolicy(testRetryPolicy)
+
+	err := c.Dispatch(context.Background(), &RPC{
+		Method:     http.MethodPatch,
+		URL:        srv.URL,
+		Idempotent: true,
+	}).Wait()
+	assert.NilError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+type doerFunc func(r *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestClient_retriesNetworkErrors(t *testing.T) {
+	var calls int32
+	c := NewClientUsing(doerFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("connection refused")
+	})).WithRetryPolicy(testRetryPolicy)
+
+	err := c.Get(context.Background(), "http://foo", nil, nil)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,84 @@
+package taxi
+
+import (
+	"encoding/json"
+	"mime"
+	"strings"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// Codec marshals and unmarshals request and response bodies. Struct
+// fields are named according to their json tags, whichever codec is used.
+type Codec interface {
+	// ContentType returns the value of the Content-Type
+	// header for bodies encoded with this codec
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec encodes bodies as JSON. It is the default codec.
+var JSONCodec Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string                        { return contentTypeJSON }
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// defaultCodecs are understood by all Routers and Clients
+var defaultCodecs = codecs{JSONCodec, MsgpackCodec}
+
+// codecs is an ordered set of codecs. Earlier
+// codecs take precedence over later ones.
+type codecs []Codec
+
+// with returns a new set with the given codecs taking precedence
+func (cs codecs) with(c ...Codec) codecs {
+	out := make(codecs, 0, len(c)+len(cs))
+	out = append(out, c...)
+	return append(out, cs...)
+}
+
+// lookup returns the codec for the given Content-Type. An empty
+// Content-Type is assumed to be JSON for backwards compatibility.
+func (cs codecs) lookup(contentType string) (Codec, error) {
+	if contentType == "" {
+		contentType = contentTypeJSON
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, oops.Wrap(err, oops.ErrBadRequest, "failed to parse content type %q", contentType)
+	}
+
+	for _, c := range cs {
+		if t, _, _ := mime.ParseMediaType(c.ContentType()); t == mediaType {
+			return c, nil
+		}
+	}
+
+	return nil, oops.BadRequest("unsupported content type %q", contentType)
+}
+
+// negotiate returns the first codec in the Accept header that is in the
+// set. Quality values are ignored. If none match, JSON is returned.
+func (cs codecs) negotiate(accept string) Codec {
+	for _, t := range strings.Split(accept, ",") {
+		if t = strings.TrimSpace(t); t == "" {
+			continue
+		}
+
+		if c, err := cs.lookup(t); err == nil {
+			return c
+		}
+	}
+
+	c, err := cs.lookup(contentTypeJSON)
+	if err != nil {
+		return JSONCodec
+	}
+
+	return c
+}
@@ -0,0 +1,79 @@
+package taxi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+func TestRouter_contentNegotiation(t *testing.T) {
+	var gotContentType string
+
+	r := NewRouter()
+	r.HandleFunc(http.MethodPut, "/frame", func(ctx context.Context, decode Decoder) (interface{}, error) {
+		var body struct {
+			Universe int    `json:"universe"`
+			Values   []byte `json:"values"`
+		}
+		if err := decode(&body); err != nil {
+			return nil, err
+		}
+
+		if body.Universe == 0 {
+			return nil, oops.BadRequest("universe not set")
+		}
+
+		return &body, nil
+	})
+
+	r.UseMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req)
+			gotContentType = w.Header().Get("Content-Type")
+		})
+	})
+
+	type frame struct {
+		Universe int    `json:"universe"`
+		Values   []byte `json:"values"`
+	}
+
+	for _, codec := range []Codec{JSONCodec, MsgpackCodec} {
+		c := &MockClient{Handler: r, Codec: codec}
+
+		in := &frame{Universe: 1, Values: []byte{1, 2, 3}}
+		out := &frame{}
+
+		err := c.Dispatch(context.Background(), &RPC{
+			Method: http.MethodPut,
+			URL:    "/frame",
+			Body:   in,
+		}).DecodeResponse(out)
+		assert.NilError(t, err)
+		assert.Equal(t, codec.ContentType(), gotContentType)
+		assert.Equal(t, 1, out.Universe)
+		assert.DeepEqual(t, in.Values, out.Values)
+
+		// Errors are encoded with the same codec
+		err = c.Put(context.Background(), "/frame", &frame{}, nil)
+		assert.Assert(t, oops.Is(err, oops.ErrBadRequest))
+		assert.Equal(t, codec.ContentType(), gotContentType)
+	}
+}
+
+func TestCodecs_negotiate(t *testing.T) {
+	cs := defaultCodecs
+
+	assert.Equal(t, JSONCodec, cs.negotiate(""))
+	assert.Equal(t, JSONCodec, cs.negotiate("*/*"))
+	assert.Equal(t, MsgpackCodec, cs.negotiate("application/msgpack"))
+	assert.Equal(t, MsgpackCodec, cs.negotiate("text/html, application/msgpack;q=0.9"))
+	assert.Equal(t, JSONCodec, cs.negotiate("application/json, application/msgpack"))
+
+	_, err := cs.lookup("application/xml")
+	assert.Assert(t, oops.Is(err, oops.ErrBadRequest))
+}
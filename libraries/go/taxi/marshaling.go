@@ -1,8 +1,8 @@
 package taxi
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"time"
@@ -17,10 +17,19 @@ const (
 	contentTypeText = "text/plain"
 )
 
-// DecodeRequest unmarshals URL parameters and the JSON body
-// of the given request into the value pointed to by v.
-// It is exported because it might be useful, e.g. in middleware.
+// maxRequestBodySize is the size in bytes of the largest request body
+// that will be decoded. It stops clients exhausting the server's memory.
+const maxRequestBodySize = 10 << 20
+
+// DecodeRequest unmarshals URL parameters and the body of the given
+// request into the value pointed to by v. The body is decoded with
+// the codec that matches the request's Content-Type, defaulting to
+// JSON if there isn't a match. It is exported because it might be useful, e.g. in middleware.
 func DecodeRequest(r *http.Request, v interface{}) error {
+	return decodeRequest(r, v, defaultCodecs)
+}
+
+func decodeRequest(r *http.Request, v interface{}, cs codecs) error {
 	// This does a load of reflection to unmarshal a map into the type of v
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook:       mapstructure.StringToTimeHookFunc(time.RFC3339),
@@ -62,92 +71,154 @@ func DecodeRequest(r *http.Request, v interface{}) error {
 	}
 
 	defer func() { _ = r.Body.Close() }()
-	body, err := ioutil.ReadAll(r.Body)
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBodySize+1))
 	if err != nil {
 		return oops.Wrap(err, oops.ErrInternalService, "failed to read request body")
 	}
 
+	if len(body) > maxRequestBodySize {
+		return oops.BadRequest("request body is larger than %d bytes", maxRequestBodySize)
+	}
+
 	if len(body) == 0 {
 		return nil
 	}
 
-	// Assume the body is JSON and unmarshal into v
-	if err := json.Unmarshal(body, v); err != nil {
+	// Bodies with an unknown Content-Type are assumed to be JSON, as they
+	// were before other codecs were supported, because some clients (e.g.
+	// curl) send a default Content-Type like x-www-form-urlencoded.
+	codec, err := cs.lookup(r.Header.Get("Content-Type"))
+	if err != nil {
+		codec = JSONCodec
+	}
+
+	if err := codec.Unmarshal(body, v); err != nil {
 		return oops.Wrap(err, oops.ErrBadRequest, "failed to unmarshal request body")
 	}
 
 	return nil
 }
 
-// WriteSuccess writes the data to the ResponseWriter.
+// WriteSuccess writes the data to the ResponseWriter as JSON.
 // A status code of 200 is set.
 func WriteSuccess(w http.ResponseWriter, v interface{}) error {
+	return writeSuccess(w, v, JSONCodec)
+}
+
+func writeSuccess(w http.ResponseWriter, v interface{}, codec Codec) error {
 	payload := struct {
 		Data interface{} `json:"data"`
 	}{
 		Data: v,
 	}
 
-	rsp, err := json.Marshal(&payload)
+	rsp, err := codec.Marshal(&payload)
 	if err != nil {
 		// Best effort attempt to respond
-		err = oops.Wrap(err, oops.ErrInternalService, "failed to marshal payload to JSON")
-		_ = WriteError(w, err)
+		err = oops.Wrap(err, oops.ErrInternalService, "failed to marshal payload")
+		_ = writeError(w, err, codec)
 		return err
 	}
 
-	w.Header().Set("Content-Type", contentTypeJSON)
+	w.Header().Set("Content-Type", codec.ContentType())
 	w.WriteHeader(http.StatusOK)
 	_, err = w.Write(rsp)
 
 	return err
 }
 
-// WriteError writes the error to the ResponseWriter. If the
-// error is an oops.Error, the status code is taken from that,
+// WriteError writes the error to the ResponseWriter as JSON. If
+// the error is an oops.Error, the status code is taken from that,
 // otherwise a status code of 500 is set.
 func WriteError(w http.ResponseWriter, err error) error {
-	status := http.StatusInternalServerError
-	payload := struct {
-		Error string `json:"error"`
-		Stack string `json:"stack,omitempty"`
-	}{Error: err.Error()}
-
-	if oerr, ok := err.(*oops.Error); ok {
-		status = oerr.HTTPStatus()
-		payload.Error = oerr.GetMessage()
+	return writeError(w, err, JSONCodec)
+}
 
-		// See the comment on the Format() function of
-		// github.com/pkg/errors.StackTrace for formatting options
-		payload.Stack = fmt.Sprintf("%+v", oerr.StackTrace())
-	}
+func writeError(w http.ResponseWriter, err error, codec Codec) error {
+	status, payload := newErrorPayload(err)
 
-	rsp, err := json.Marshal(&payload)
+	rsp, err := codec.Marshal(payload)
 	if err != nil {
 		// Best effort attempt to respond
 		w.Header().Set("Content-Type", contentTypeText)
 		w.WriteHeader(500)
-		_, _ = fmt.Fprintf(w, "Failed to marshal error response payload to JSON: %s", err)
-		return oops.WithMessage(err, "failed to marshal response payload to JSON")
+		_, _ = fmt.Fprintf(w, "Failed to marshal error response payload: %s", err)
+		return oops.WithMessage(err, "failed to marshal response payload")
 	}
 
-	w.Header().Set("Content-Type", contentTypeJSON)
+	w.Header().Set("Content-Type", codec.ContentType())
 	w.WriteHeader(status)
 	_, err = w.Write(rsp)
 
 	return err
 }
 
-// decodeResponse unmarshals the response
-// to an RPC into the value point to by v
-func decodeResponse(rsp *http.Response, v interface{}) error {
-	var wrapper struct {
-		Error string          `json:"error"`
-		Data  json.RawMessage `json:"data"`
+// errorPayload is the body of an error response. The error field holds the
+// full message and is enough to reconstruct a basic error. The embedded
+// oops.Remote carries the code, metadata and cause chain so that the
+// client can reconstruct an equivalent *oops.Error.
+type errorPayload struct {
+	Error string `json:"error"`
+	Stack string `json:"stack,omitempty"`
+	oops.Remote
+}
+
+// newErrorPayload returns the HTTP status and payload to use
+// when responding with the given error
+func newErrorPayload(err error) (int, *errorPayload) {
+	status := http.StatusInternalServerError
+	payload := &errorPayload{
+		Error:  err.Error(),
+		Remote: *oops.ToRemote(err),
 	}
 
+	if oerr, ok := err.(*oops.Error); ok {
+		status = oerr.HTTPStatus()
+		payload.Error = oerr.GetMessage()
+
+		// See the comment on the Format() function of
+		// github.com/pkg/errors.StackTrace for formatting options
+		payload.Stack = fmt.Sprintf("%+v", oerr.StackTrace())
+	}
+
+	return status, payload
+}
+
+// toError converts the payload back into an error. The status is the HTTP
+// status code of the response, which is used to derive the error's code if
+// the payload doesn't have one (e.g. if it came from an older service).
+func (p *errorPayload) toError(status int) error {
+	if p.Code == "" && p.Cause == nil {
+		return oops.FromHTTPStatus(status, "%s", p.Error)
+	}
+
+	return oops.FromRemote(&p.Remote)
+}
+
+// decodeResponse unmarshals the response to an RPC into the value
+// pointed to by v. The body is decoded with the codec that matches the
+// response's Content-Type. If there isn't one, JSON is assumed.
+func decodeResponse(rsp *http.Response, v interface{}, cs codecs) error {
 	defer func() { _ = rsp.Body.Close() }()
-	if err := json.NewDecoder(rsp.Body).Decode(&wrapper); err != nil {
+
+	codec, err := cs.lookup(rsp.Header.Get("Content-Type"))
+	if err != nil {
+		codec = JSONCodec
+	}
+
+	body, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return oops.WithMessage(err, "failed to read response body")
+	}
+
+	// The data is decoded directly into v. If v is nil,
+	// the data is decoded into a map and then discarded.
+	wrapper := struct {
+		errorPayload
+		Data interface{} `json:"data"`
+	}{Data: v}
+
+	if err := codec.Unmarshal(body, &wrapper); err != nil {
 		return oops.WithMessage(
 			err,
 			"received %d %s from server but could not decode response",
@@ -157,15 +228,7 @@ func decodeResponse(rsp *http.Response, v interface{}) error {
 	}
 
 	if wrapper.Error != "" {
-		return oops.FromHTTPStatus(rsp.StatusCode, wrapper.Error)
-	}
-
-	if v == nil {
-		return nil
-	}
-
-	if err := json.Unmarshal(wrapper.Data, v); err != nil {
-		return oops.WithMessage(err, "failed to decode data")
+		return wrapper.errorPayload.toError(rsp.StatusCode)
 	}
 
 	return nil
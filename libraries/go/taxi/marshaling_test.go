@@ -2,10 +2,15 @@ package taxi
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
 	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
 )
 
 func TestDecodeQuery(t *testing.T) {
@@ -37,6 +42,41 @@ func TestDecodeBody(t *testing.T) {
 	assert.Equal(t, v.Foo, "bar")
 }
 
+func TestDecodeBody_unknownContentType(t *testing.T) {
+	for _, contentType := range []string{
+		"application/x-www-form-urlencoded",
+		"text/plain",
+		"not a media type",
+	} {
+		t.Run(contentType, func(t *testing.T) {
+			body := []byte("{\"foo\":\"bar\"}")
+			r, err := http.NewRequest("POST", "/foo", bytes.NewBuffer(body))
+			assert.NilError(t, err)
+			r.Header.Set("Content-Type", contentType)
+
+			var v struct {
+				Foo string
+			}
+
+			err = DecodeRequest(r, &v)
+			assert.NilError(t, err)
+
+			assert.Equal(t, v.Foo, "bar")
+		})
+	}
+}
+
+func TestDecodeBody_tooLarge(t *testing.T) {
+	body := bytes.Repeat([]byte(" "), maxRequestBodySize+1)
+	r, err := http.NewRequest("POST", "/foo", bytes.NewBuffer(body))
+	assert.NilError(t, err)
+
+	var v interface{}
+	err = DecodeRequest(r, &v)
+	assert.Assert(t, oops.Is(err, oops.ErrBadRequest))
+	assert.ErrorContains(t, err, "request body is larger than")
+}
+
 func TestDecodeIntoMap(t *testing.T) {
 	body := []byte("{\"foo\":\"bar\"}")
 	r, err := http.NewRequest("GET", "/baz?baz=qux", bytes.NewBuffer(body))
@@ -67,3 +107,64 @@ func TestDecodeComplexParamNames(t *testing.T) {
 	assert.Equal(t, v.AnimalColor, "black")
 	assert.Equal(t, v.HouseName, "Buckingham Palace")
 }
+
+func TestErrorRoundTrip(t *testing.T) {
+	deviceOffline := oops.Code("device_offline")
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "wrapped",
+			err: oops.WithMessage(
+				oops.NotFound("device %s not found", "foo", map[string]string{"id": "foo"}),
+				"failed to load %s", "bar", map[string]string{"room": "kitchen"},
+			),
+		},
+		{
+			name: "custom code",
+			err:  oops.Wrap(errors.New("no route to host"), deviceOffline, "failed to set state"),
+		},
+		{
+			name: "not an oops error",
+			err:  errors.New("100% broken"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRouter()
+			r.HandleFunc(http.MethodGet, "/", func(context.Context, Decoder) (interface{}, error) {
+				return nil, tc.err
+			})
+
+			err := (&MockClient{Handler: r}).Get(context.Background(), "/", nil, nil)
+			oerr, ok := err.(*oops.Error)
+			assert.Assert(t, ok, "%T is not an *oops.Error", err)
+
+			assert.Equal(t, oops.GetCode(tc.err), oerr.GetCode())
+
+			if local, ok := tc.err.(*oops.Error); ok {
+				assert.Equal(t, local.Error(), oerr.Error())
+				assert.DeepEqual(t, local.GetMetadata(), oerr.GetMetadata())
+			} else {
+				assert.Equal(t, tc.err.Error(), oerr.GetMessage())
+			}
+		})
+	}
+}
+
+func TestErrorRoundTrip_legacyPayload(t *testing.T) {
+	// Errors from services that don't send a code
+	// fall back to a code based on the HTTP status
+	rsp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"Content-Type": []string{contentTypeJSON}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error": "not here"}`)),
+	}
+
+	err := decodeResponse(rsp, nil, defaultCodecs)
+	assert.Assert(t, oops.Is(err, oops.ErrNotFound))
+	assert.ErrorContains(t, err, "not here")
+}
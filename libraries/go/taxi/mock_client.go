@@ -11,6 +11,9 @@ import (
 // tests as it allows endpoints to be mocked.
 type MockClient struct {
 	Handler http.Handler
+
+	// Codec is used to encode request bodies. If nil, JSON is used.
+	Codec Codec
 }
 
 var _ Dispatcher = (*MockClient)(nil)
@@ -31,7 +34,7 @@ func (c *MockClient) Dispatch(ctx context.Context, rpc *RPC) *Future {
 				return err
 			}
 
-			return decodeResponse(rsp, v)
+			return decodeResponse(rsp, v, c.codecs())
 		}
 	}()
 
@@ -39,7 +42,7 @@ func (c *MockClient) Dispatch(ctx context.Context, rpc *RPC) *Future {
 }
 
 func (c *MockClient) do(ctx context.Context, rpc *RPC) (*http.Response, error) {
-	req, err := rpc.ToRequest(ctx)
+	req, err := rpc.toRequest(ctx, c.getCodec())
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +53,32 @@ func (c *MockClient) do(ctx context.Context, rpc *RPC) (*http.Response, error) {
 	return w.Result(), nil
 }
 
+// Stream gives the request to the client's handler and returns a Stream
+// of the messages that it sent. Because the response is recorded, the
+// handler must return before the Stream is returned.
+func (c *MockClient) Stream(ctx context.Context, rpc *RPC) (*Stream, error) {
+	rsp, err := c.do(ctx, rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStream(rsp, c.codecs())
+}
+
+func (c *MockClient) getCodec() Codec {
+	if c.Codec == nil {
+		return JSONCodec
+	}
+	return c.Codec
+}
+
+func (c *MockClient) codecs() codecs {
+	if c.Codec == nil {
+		return defaultCodecs
+	}
+	return defaultCodecs.with(c.Codec)
+}
+
 // Get dispatches a GET RPC
 func (c *MockClient) Get(ctx context.Context, url string, body interface{}, v interface{}) error {
 	r := &RPC{Method: http.MethodGet, URL: url, Body: body}
@@ -0,0 +1,944 @@
+package taxi
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// MsgpackCodec encodes bodies as MessagePack (https://msgpack.org). It
+// is much more compact than JSON for numeric data, e.g. DMX frames.
+//
+// Values are encoded in the same shape that encoding/json would use, so
+// the same types can be used with either codec: structs are encoded as
+// maps keyed by their json field names, and types that implement
+// encoding.TextMarshaler are encoded as strings. Types that implement
+// json.Marshaler and json.Unmarshaler (e.g. generated enums and messages
+// with oneofs or defaults) are converted via their JSON representation so
+// that their validation and defaults still apply. Other byte slices and
+// arrays are encoded as binary data. Numbers decoded into an interface{}
+// are float64, like encoding/json. Extension types are not supported.
+// Arrays and maps can be nested up to msgpackMaxDepth levels deep.
+var MsgpackCodec Codec = msgpackCodec{}
+
+const contentTypeMsgpack = "application/msgpack"
+
+// msgpackMaxDepth is the maximum nesting depth of decoded arrays and
+// maps. It is the same as encoding/json's limit and stops malicious
+// input from overflowing the stack.
+const msgpackMaxDepth = 10000
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) ContentType() string { return contentTypeMsgpack }
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	e := &msgpackEncoder{}
+	if err := e.encode(reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("msgpack: cannot unmarshal into non-pointer %T", v)
+	}
+
+	d := &msgpackDecoder{data: data}
+	tree, err := d.readValue()
+	if err != nil {
+		return err
+	}
+
+	if d.off != len(d.data) {
+		return fmt.Errorf("msgpack: %d bytes of trailing data", len(d.data)-d.off)
+	}
+
+	return assignMsgpack(tree, rv)
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// msgpackField is an encodable field of a struct
+type msgpackField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+var msgpackFieldCache sync.Map // map[reflect.Type][]*msgpackField
+
+// msgpackFields returns the fields of the struct type t, following
+// the same rules as encoding/json for names and embedded structs.
+func msgpackFields(t reflect.Type) []*msgpackField {
+	if f, ok := msgpackFieldCache.Load(t); ok {
+		return f.([]*msgpackField)
+	}
+
+	var fields []*msgpackField
+	seen := map[string]int{} // name -> index in fields
+
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+
+			name, opts := tag, ""
+			if j := strings.IndexByte(tag, ','); j >= 0 {
+				name, opts = tag[:j], tag[j+1:]
+			}
+
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			idx := append(append([]int{}, index...), i)
+
+			// Promote the fields of untagged embedded structs
+			if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				walk(ft, idx)
+				continue
+			}
+
+			if f.PkgPath != "" {
+				// Unexported
+				continue
+			}
+
+			if name == "" {
+				name = f.Name
+			}
+
+			field := &msgpackField{
+				name:      name,
+				index:     idx,
+				omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			}
+
+			// Shallower fields take precedence
+			if j, ok := seen[name]; ok {
+				if len(fields[j].index) > len(idx) {
+					fields[j] = field
+				}
+				continue
+			}
+
+			seen[name] = len(fields)
+			fields = append(fields, field)
+		}
+	}
+	walk(t, nil)
+
+	msgpackFieldCache.Store(t, fields)
+	return fields
+}
+
+// fieldByIndex returns the field of the struct v. If alloc is true, nil
+// embedded pointers are allocated, otherwise an invalid Value is returned.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+type msgpackEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *msgpackEncoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		e.buf.WriteByte(0xc0)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			e.buf.WriteByte(0xc0)
+			return nil
+		}
+	}
+
+	if m, ok := jsonMarshaler(v); ok {
+		return e.encodeJSON(v.Type(), m)
+	}
+
+	if v.Kind() != reflect.Ptr && v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return fmt.Errorf("msgpack: failed to marshal %s: %w", v.Type(), err)
+		}
+		e.writeString(string(b))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return e.encode(v.Elem())
+
+	case reflect.Bool:
+		if v.Bool() {
+			e.buf.WriteByte(0xc3)
+		} else {
+			e.buf.WriteByte(0xc2)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.writeInt(v.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.writeUint(v.Uint())
+
+	case reflect.Float32:
+		e.buf.WriteByte(0xca)
+		e.writeBig(uint64(math.Float32bits(float32(v.Float()))), 4)
+
+	case reflect.Float64:
+		e.buf.WriteByte(0xcb)
+		e.writeBig(math.Float64bits(v.Float()), 8)
+
+	case reflect.String:
+		e.writeString(v.String())
+
+	case reflect.Slice:
+		if v.IsNil() {
+			e.buf.WriteByte(0xc0)
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.writeBin(v.Bytes())
+			return nil
+		}
+		return e.encodeArray(v)
+
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			e.writeBin(b)
+			return nil
+		}
+		return e.encodeArray(v)
+
+	case reflect.Map:
+		if v.IsNil() {
+			e.buf.WriteByte(0xc0)
+			return nil
+		}
+		e.writeLen(v.Len(), 0x80, 0xde, 0xdf)
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := e.encode(iter.Key()); err != nil {
+				return err
+			}
+			if err := e.encode(iter.Value()); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		return e.encodeStruct(v)
+
+	default:
+		return fmt.Errorf("msgpack: unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// jsonMarshaler returns the json.Marshaler implemented by v or, if v is
+// addressable, by a pointer to v. This matches when encoding/json would
+// call MarshalJSON.
+func jsonMarshaler(v reflect.Value) (json.Marshaler, bool) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	if v.Type().Implements(jsonMarshalerType) {
+		return v.Interface().(json.Marshaler), true
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(jsonMarshalerType) {
+		return v.Addr().Interface().(json.Marshaler), true
+	}
+
+	return nil, false
+}
+
+// encodeJSON encodes the JSON representation of a value
+func (e *msgpackEncoder) encodeJSON(t reflect.Type, m json.Marshaler) error {
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("msgpack: failed to marshal %s: %w", t, err)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return fmt.Errorf("msgpack: failed to decode JSON from %s: %w", t, err)
+	}
+
+	return e.encode(reflect.ValueOf(fromJSONNumbers(v)))
+}
+
+// fromJSONNumbers replaces the json.Numbers in a decoded JSON value with
+// integers where possible so that they are encoded compactly
+func fromJSONNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = fromJSONNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = fromJSONNumbers(e)
+		}
+	}
+	return v
+}
+
+func (e *msgpackEncoder) encodeArray(v reflect.Value) error {
+	e.writeLen(v.Len(), 0x90, 0xdc, 0xdd)
+	for i := 0; i < v.Len(); i++ {
+		if err := e.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *msgpackEncoder) encodeStruct(v reflect.Value) error {
+	var fields []*msgpackField
+	var values []reflect.Value
+
+	for _, f := range msgpackFields(v.Type()) {
+		fv := fieldByIndex(v, f.index, false)
+		if !fv.IsValid() || (f.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+		fields = append(fields, f)
+		values = append(values, fv)
+	}
+
+	e.writeLen(len(fields), 0x80, 0xde, 0xdf)
+	for i, f := range fields {
+		e.writeString(f.name)
+		if err := e.encode(values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *msgpackEncoder) writeInt(i int64) {
+	switch {
+	case i >= 0:
+		e.writeUint(uint64(i))
+	case i >= -32:
+		e.buf.WriteByte(byte(i))
+	case i >= math.MinInt8:
+		e.buf.WriteByte(0xd0)
+		e.buf.WriteByte(byte(i))
+	case i >= math.MinInt16:
+		e.buf.WriteByte(0xd1)
+		e.writeBig(uint64(i), 2)
+	case i >= math.MinInt32:
+		e.buf.WriteByte(0xd2)
+		e.writeBig(uint64(i), 4)
+	default:
+		e.buf.WriteByte(0xd3)
+		e.writeBig(uint64(i), 8)
+	}
+}
+
+func (e *msgpackEncoder) writeUint(u uint64) {
+	switch {
+	case u <= math.MaxInt8:
+		e.buf.WriteByte(byte(u))
+	case u <= math.MaxUint8:
+		e.buf.WriteByte(0xcc)
+		e.buf.WriteByte(byte(u))
+	case u <= math.MaxUint16:
+		e.buf.WriteByte(0xcd)
+		e.writeBig(u, 2)
+	case u <= math.MaxUint32:
+		e.buf.WriteByte(0xce)
+		e.writeBig(u, 4)
+	default:
+		e.buf.WriteByte(0xcf)
+		e.writeBig(u, 8)
+	}
+}
+
+func (e *msgpackEncoder) writeString(s string) {
+	if len(s) < 32 {
+		e.buf.WriteByte(0xa0 | byte(len(s)))
+	} else {
+		e.writeSize(len(s), 0xd9, 0xda, 0xdb)
+	}
+	e.buf.WriteString(s)
+}
+
+func (e *msgpackEncoder) writeBin(b []byte) {
+	e.writeSize(len(b), 0xc4, 0xc5, 0xc6)
+	e.buf.Write(b)
+}
+
+// writeLen writes the header of an array or map
+func (e *msgpackEncoder) writeLen(n int, fix, b16, b32 byte) {
+	switch {
+	case n < 16:
+		e.buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		e.buf.WriteByte(b16)
+		e.writeBig(uint64(n), 2)
+	default:
+		e.buf.WriteByte(b32)
+		e.writeBig(uint64(n), 4)
+	}
+}
+
+// writeSize writes the header of a string or binary value
+func (e *msgpackEncoder) writeSize(n int, b8, b16, b32 byte) {
+	switch {
+	case n <= math.MaxUint8:
+		e.buf.WriteByte(b8)
+		e.buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		e.buf.WriteByte(b16)
+		e.writeBig(uint64(n), 2)
+	default:
+		e.buf.WriteByte(b32)
+		e.writeBig(uint64(n), 4)
+	}
+}
+
+// writeBig writes the low n bytes of u in big-endian order
+func (e *msgpackEncoder) writeBig(u uint64, n int) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], u)
+	e.buf.Write(b[8-n:])
+}
+
+// isEmptyValue matches the definition of empty used by encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// msgpackMap is a decoded map. The order of the entries is preserved.
+type msgpackMap []msgpackEntry
+
+type msgpackEntry struct {
+	key, value interface{}
+}
+
+// msgpackDecoder decodes a msgpack value into a tree of nil, bool,
+// int64, uint64, float64, string, []byte, []interface{} and msgpackMap
+// values. The tree is then assigned to the destination value.
+type msgpackDecoder struct {
+	data  []byte
+	off   int
+	depth int
+}
+
+func (d *msgpackDecoder) read(n int) ([]byte, error) {
+	if n < 0 || d.off+n > len(d.data) {
+		return nil, fmt.Errorf("msgpack: unexpected end of data")
+	}
+	b := d.data[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+// readBig reads an n byte big-endian unsigned integer
+func (d *msgpackDecoder) readBig(n int) (uint64, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return 0, err
+	}
+
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+func (d *msgpackDecoder) readValue() (interface{}, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == 0x80:
+		return d.readMap(int(c & 0x0f))
+	case c&0xf0 == 0x90:
+		return d.readArray(int(c & 0x0f))
+	case c&0xe0 == 0xa0:
+		return d.readString(int(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.readBig(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		bin, err := d.read(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, bin...), nil
+	case 0xca:
+		u, err := d.readBig(4)
+		return float64(math.Float32frombits(uint32(u))), err
+	case 0xcb:
+		u, err := d.readBig(8)
+		return math.Float64frombits(u), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return d.readBig(1 << (c - 0xcc))
+	case 0xd0:
+		u, err := d.readBig(1)
+		return int64(int8(u)), err
+	case 0xd1:
+		u, err := d.readBig(2)
+		return int64(int16(u)), err
+	case 0xd2:
+		u, err := d.readBig(4)
+		return int64(int32(u)), err
+	case 0xd3:
+		u, err := d.readBig(8)
+		return int64(u), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.readBig(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.readString(int(n))
+	case 0xdc, 0xdd:
+		n, err := d.readBig(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.readArray(int(n))
+	case 0xde, 0xdf:
+		n, err := d.readBig(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.readMap(int(n))
+	}
+
+	return nil, fmt.Errorf("msgpack: unsupported type byte 0x%x", c)
+}
+
+func (d *msgpackDecoder) readString(n int) (interface{}, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// enter increments the nesting depth. The returned
+// function must be called when the container ends.
+func (d *msgpackDecoder) enter() (func(), error) {
+	d.depth++
+	if d.depth > msgpackMaxDepth {
+		return nil, oops.BadRequest("msgpack: exceeded max depth of %d", msgpackMaxDepth)
+	}
+	return func() { d.depth-- }, nil
+}
+
+func (d *msgpackDecoder) readArray(n int) (interface{}, error) {
+	leave, err := d.enter()
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+
+	// Each element is at least one byte so this bounds the allocation
+	if n > len(d.data)-d.off {
+		return nil, fmt.Errorf("msgpack: unexpected end of data")
+	}
+
+	a := make([]interface{}, n)
+	for i := range a {
+		v, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		a[i] = v
+	}
+	return a, nil
+}
+
+func (d *msgpackDecoder) readMap(n int) (interface{}, error) {
+	leave, err := d.enter()
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+
+	if 2*n > len(d.data)-d.off {
+		return nil, fmt.Errorf("msgpack: unexpected end of data")
+	}
+
+	m := make(msgpackMap, n)
+	for i := range m {
+		k, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		m[i] = msgpackEntry{key: k, value: v}
+	}
+	return m, nil
+}
+
+// assignMsgpack stores the decoded tree in v, following the same
+// rules as encoding/json where possible.
+func assignMsgpack(tree interface{}, v reflect.Value) error {
+	// Follow pointers, allocating them if necessary
+	for v.Kind() == reflect.Ptr {
+		if tree == nil {
+			if v.CanSet() {
+				v.Set(reflect.Zero(v.Type()))
+			}
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	if tree == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(jsonUnmarshalerType) {
+		b, err := json.Marshal(genericMsgpack(tree, false))
+		if err != nil {
+			return fmt.Errorf("msgpack: failed to convert to JSON for %s: %w", v.Type(), err)
+		}
+		return v.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(b)
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		var text []byte
+		switch t := tree.(type) {
+		case string:
+			text = []byte(t)
+		case []byte:
+			text = t
+		default:
+			return typeError(tree, v)
+		}
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(text)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		// Like encoding/json, decode into the value
+		// that the interface holds if it is a pointer
+		if !v.IsNil() && v.Elem().Kind() == reflect.Ptr && !v.Elem().IsNil() {
+			return assignMsgpack(tree, v.Elem())
+		}
+		if v.NumMethod() != 0 {
+			return typeError(tree, v)
+		}
+		v.Set(reflect.ValueOf(genericMsgpack(tree, true)))
+
+	case reflect.Bool:
+		b, ok := tree.(bool)
+		if !ok {
+			return typeError(tree, v)
+		}
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch t := tree.(type) {
+		case int64:
+			i = t
+		case uint64:
+			if t > math.MaxInt64 {
+				return typeError(tree, v)
+			}
+			i = int64(t)
+		default:
+			return typeError(tree, v)
+		}
+		if v.OverflowInt(i) {
+			return typeError(tree, v)
+		}
+		v.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch t := tree.(type) {
+		case uint64:
+			u = t
+		case int64:
+			if t < 0 {
+				return typeError(tree, v)
+			}
+			u = uint64(t)
+		default:
+			return typeError(tree, v)
+		}
+		if v.OverflowUint(u) {
+			return typeError(tree, v)
+		}
+		v.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		switch t := tree.(type) {
+		case float64:
+			v.SetFloat(t)
+		case int64:
+			v.SetFloat(float64(t))
+		case uint64:
+			v.SetFloat(float64(t))
+		default:
+			return typeError(tree, v)
+		}
+
+	case reflect.String:
+		switch t := tree.(type) {
+		case string:
+			v.SetString(t)
+		case []byte:
+			v.SetString(string(t))
+		default:
+			return typeError(tree, v)
+		}
+
+	case reflect.Slice:
+		switch t := tree.(type) {
+		case []byte:
+			if v.Type().Elem().Kind() != reflect.Uint8 {
+				return typeError(tree, v)
+			}
+			v.SetBytes(append([]byte{}, t...))
+		case []interface{}:
+			s := reflect.MakeSlice(v.Type(), len(t), len(t))
+			for i, e := range t {
+				if err := assignMsgpack(e, s.Index(i)); err != nil {
+					return err
+				}
+			}
+			v.Set(s)
+		default:
+			return typeError(tree, v)
+		}
+
+	case reflect.Array:
+		// Like encoding/json, extra elements are dropped
+		// and missing elements are set to the zero value
+		v.Set(reflect.Zero(v.Type()))
+		switch t := tree.(type) {
+		case []byte:
+			if v.Type().Elem().Kind() != reflect.Uint8 {
+				return typeError(tree, v)
+			}
+			reflect.Copy(v, reflect.ValueOf(t))
+		case []interface{}:
+			for i := 0; i < len(t) && i < v.Len(); i++ {
+				if err := assignMsgpack(t[i], v.Index(i)); err != nil {
+					return err
+				}
+			}
+		default:
+			return typeError(tree, v)
+		}
+
+	case reflect.Map:
+		m, ok := tree.(msgpackMap)
+		if !ok {
+			return typeError(tree, v)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for _, e := range m {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := assignMsgpack(e.key, key); err != nil {
+				return err
+			}
+			value := reflect.New(v.Type().Elem()).Elem()
+			if err := assignMsgpack(e.value, value); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, value)
+		}
+
+	case reflect.Struct:
+		m, ok := tree.(msgpackMap)
+		if !ok {
+			return typeError(tree, v)
+		}
+		fields := msgpackFields(v.Type())
+		for _, e := range m {
+			name, ok := e.key.(string)
+			if !ok {
+				return fmt.Errorf("msgpack: cannot use %T as a field name of %s", e.key, v.Type())
+			}
+
+			f := lookupMsgpackField(fields, name)
+			if f == nil {
+				// Unknown fields are ignored
+				continue
+			}
+
+			if err := assignMsgpack(e.value, fieldByIndex(v, f.index, true)); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return typeError(tree, v)
+	}
+
+	return nil
+}
+
+// lookupMsgpackField returns the field with the given name, preferring
+// an exact match but falling back to a case-insensitive match like
+// encoding/json does.
+func lookupMsgpackField(fields []*msgpackField, name string) *msgpackField {
+	for _, f := range fields {
+		if f.name == name {
+			return f
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, name) {
+			return f
+		}
+	}
+	return nil
+}
+
+// genericMsgpack converts a decoded tree into values that can be stored
+// in an interface{}. Maps become map[string]interface{} to match
+// encoding/json. If floats is true, integers become float64 like they
+// would with encoding/json, otherwise they are left as they are so
+// that they can be converted to JSON without losing precision.
+func genericMsgpack(tree interface{}, floats bool) interface{} {
+	switch t := tree.(type) {
+	case msgpackMap:
+		m := make(map[string]interface{}, len(t))
+		for _, e := range t {
+			m[fmt.Sprint(e.key)] = genericMsgpack(e.value, floats)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(t))
+		for i, e := range t {
+			a[i] = genericMsgpack(e, floats)
+		}
+		return a
+	case int64:
+		if floats {
+			return float64(t)
+		}
+	case uint64:
+		if floats {
+			return float64(t)
+		}
+	}
+	return tree
+}
+
+func typeError(tree interface{}, v reflect.Value) error {
+	kind := "nil"
+	switch tree.(type) {
+	case bool:
+		kind = "bool"
+	case int64, uint64:
+		kind = "integer"
+	case float64:
+		kind = "float"
+	case string:
+		kind = "string"
+	case []byte:
+		kind = "binary"
+	case []interface{}:
+		kind = "array"
+	case msgpackMap:
+		kind = "map"
+	}
+	return fmt.Errorf("msgpack: cannot unmarshal %s into Go value of type %s", kind, v.Type())
+}
@@ -0,0 +1,275 @@
+package taxi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/util"
+)
+
+type msgpackInner struct {
+	Label string `json:"label"`
+}
+
+// MsgpackEmbedded is exported so that DeepEqual can compare it
+type MsgpackEmbedded struct {
+	Universe int `json:"universe"`
+}
+
+type msgpackOuter struct {
+	MsgpackEmbedded
+	Name     string            `json:"name"`
+	Negative int16             `json:"negative"`
+	Big      uint64            `json:"big"`
+	Ratio    float64           `json:"ratio"`
+	On       bool              `json:"on"`
+	Frame    [512]byte         `json:"frame"`
+	Raw      []byte            `json:"raw"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]int    `json:"labels"`
+	Inner    *msgpackInner     `json:"inner"`
+	Missing  *msgpackInner     `json:"missing"`
+	Skipped  string            `json:"skipped,omitempty"`
+	Ignored  string            `json:"-"`
+	At       time.Time         `json:"at"`
+	Any      interface{}       `json:"any"`
+	Nested   map[string][]bool `json:"nested"`
+}
+
+func TestMsgpackCodec_roundTrip(t *testing.T) {
+	in := &msgpackOuter{
+		MsgpackEmbedded: MsgpackEmbedded{Universe: 2},
+		Name:            "Kitchen spotlights with a long name",
+		Negative:        -300,
+		Big:             1 << 40,
+		Ratio:           0.5,
+		On:              true,
+		Raw:             []byte{1, 2, 3},
+		Tags:            []string{"a", "b"},
+		Labels:          map[string]int{"x": 1},
+		Inner:           &msgpackInner{Label: "foo"},
+		Ignored:         "ignored",
+		At:              time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+		Any:             map[string]interface{}{"n": -1.0},
+		Nested:          map[string][]bool{"y": {true, false}},
+	}
+	in.Frame[0] = 255
+	in.Frame[511] = 128
+
+	b, err := MsgpackCodec.Marshal(in)
+	assert.NilError(t, err)
+
+	// The frame should be encoded as binary data rather
+	// than as an array with a type byte for each value
+	assert.Assert(t, len(b) < 800, "encoded length %d", len(b))
+
+	out := &msgpackOuter{}
+	err = MsgpackCodec.Unmarshal(b, out)
+	assert.NilError(t, err)
+
+	in.Ignored = ""
+	assert.DeepEqual(t, in, out)
+}
+
+func TestMsgpackCodec_encoding(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want []byte
+	}{
+		{nil, []byte{0xc0}},
+		{true, []byte{0xc3}},
+		{7, []byte{0x07}},
+		{-1, []byte{0xff}},
+		{200, []byte{0xcc, 0xc8}},
+		{-200, []byte{0xd1, 0xff, 0x38}},
+		{"hi", []byte{0xa2, 'h', 'i'}},
+		{[]int{1, 2}, []byte{0x92, 0x01, 0x02}},
+		{map[string]bool{"a": true}, []byte{0x81, 0xa1, 'a', 0xc3}},
+		{[]byte{1}, []byte{0xc4, 0x01, 0x01}},
+	}
+
+	for _, tc := range tests {
+		b, err := MsgpackCodec.Marshal(tc.v)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, tc.want, b)
+	}
+}
+
+func TestMsgpackCodec_errors(t *testing.T) {
+	var s string
+	err := MsgpackCodec.Unmarshal([]byte{0x07}, &s)
+	assert.ErrorContains(t, err, "cannot unmarshal integer")
+
+	var u uint8
+	err = MsgpackCodec.Unmarshal([]byte{0xcd, 0x01, 0x00}, &u)
+	assert.ErrorContains(t, err, "cannot unmarshal integer")
+
+	err = MsgpackCodec.Unmarshal([]byte{0xa5, 'h'}, &s)
+	assert.ErrorContains(t, err, "unexpected end of data")
+
+	err = MsgpackCodec.Unmarshal([]byte{0xa1, 'h', 0x00}, &s)
+	assert.ErrorContains(t, err, "trailing data")
+
+	err = MsgpackCodec.Unmarshal([]byte{0xc0}, s)
+	assert.ErrorContains(t, err, "non-pointer")
+}
+
+func TestMsgpackCodec_maxDepth(t *testing.T) {
+	// nested returns n nested single element arrays around nil
+	nested := func(n int) []byte {
+		return append(bytes.Repeat([]byte{0x91}, n), 0xc0)
+	}
+
+	var v interface{}
+	assert.NilError(t, MsgpackCodec.Unmarshal(nested(msgpackMaxDepth), &v))
+
+	err := MsgpackCodec.Unmarshal(nested(msgpackMaxDepth+1), &v)
+	assert.Assert(t, oops.Is(err, oops.ErrBadRequest))
+	assert.ErrorContains(t, err, "exceeded max depth")
+
+	// Maps count towards the same limit
+	data := append(bytes.Repeat([]byte{0x81, 0xa1, 'a'}, msgpackMaxDepth+1), 0xc0)
+	err = MsgpackCodec.Unmarshal(data, &v)
+	assert.ErrorContains(t, err, "exceeded max depth")
+
+	// Deep input is rejected without overflowing the stack
+	err = MsgpackCodec.Unmarshal(nested(20<<20), &v)
+	assert.ErrorContains(t, err, "exceeded max depth")
+}
+
+// msgpackEnum mirrors the JSON methods that jrpc generates for enums
+type msgpackEnum string
+
+func (e msgpackEnum) validate() error {
+	if e != "on" && e != "off" {
+		return fmt.Errorf("invalid value %q", string(e))
+	}
+	return nil
+}
+
+func (e msgpackEnum) MarshalJSON() ([]byte, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(e))
+}
+
+func (e *msgpackEnum) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if err := msgpackEnum(s).validate(); err != nil {
+		return err
+	}
+	*e = msgpackEnum(s)
+	return nil
+}
+
+// msgpackMessage mirrors the JSON methods that jrpc
+// generates for messages with oneofs and defaults
+type msgpackMessage struct {
+	Power  *msgpackEnum `json:"power,omitempty"`
+	Level  *int64       `json:"level,omitempty"`
+	Color  *util.RGB    `json:"color,omitempty"`
+	Repeat *int64       `json:"repeat,omitempty"`
+}
+
+func (m *msgpackMessage) validateOneofs() error {
+	if m.Level != nil && m.Color != nil {
+		return fmt.Errorf("only one of level and color can be set")
+	}
+	return nil
+}
+
+func (m *msgpackMessage) MarshalJSON() ([]byte, error) {
+	if err := m.validateOneofs(); err != nil {
+		return nil, err
+	}
+	type alias msgpackMessage
+	return json.Marshal((*alias)(m))
+}
+
+func (m *msgpackMessage) UnmarshalJSON(b []byte) error {
+	type alias msgpackMessage
+	if err := json.Unmarshal(b, (*alias)(m)); err != nil {
+		return err
+	}
+	if m.Repeat == nil {
+		v := int64(3)
+		m.Repeat = &v
+	}
+	return m.validateOneofs()
+}
+
+func TestMsgpackCodec_jsonMarshalers(t *testing.T) {
+	// Colors are encoded as hex strings like they are in JSON
+	b, err := MsgpackCodec.Marshal(&util.RGB{R: 255, A: 255})
+	assert.NilError(t, err)
+	var s string
+	assert.NilError(t, MsgpackCodec.Unmarshal(b, &s))
+	assert.Equal(t, "#FF0000", s)
+
+	power := msgpackEnum("on")
+	level := int64(1 << 40)
+	in := &msgpackMessage{Power: &power, Level: &level}
+	b, err = MsgpackCodec.Marshal(in)
+	assert.NilError(t, err)
+
+	// Defaults are applied when unmarshaling
+	out := &msgpackMessage{}
+	assert.NilError(t, MsgpackCodec.Unmarshal(b, out))
+	assert.Equal(t, power, *out.Power)
+	assert.Equal(t, level, *out.Level)
+	assert.Equal(t, int64(3), *out.Repeat)
+
+	in = &msgpackMessage{Color: &util.RGB{G: 255, A: 255}}
+	b, err = MsgpackCodec.Marshal(in)
+	assert.NilError(t, err)
+	out = &msgpackMessage{}
+	assert.NilError(t, MsgpackCodec.Unmarshal(b, out))
+	assert.DeepEqual(t, util.RGB{G: 255, A: 255}, *out.Color)
+
+	// Invalid enum values are rejected in both directions
+	bogus := msgpackEnum("bogus")
+	_, err = MsgpackCodec.Marshal(&msgpackMessage{Power: &bogus})
+	assert.ErrorContains(t, err, "invalid value")
+
+	b, err = MsgpackCodec.Marshal(map[string]string{"power": "bogus"})
+	assert.NilError(t, err)
+	assert.ErrorContains(t, MsgpackCodec.Unmarshal(b, &msgpackMessage{}), "invalid value")
+
+	// Oneofs are validated in both directions
+	_, err = MsgpackCodec.Marshal(&msgpackMessage{Level: &level, Color: &util.RGB{}})
+	assert.ErrorContains(t, err, "only one of")
+
+	b, err = MsgpackCodec.Marshal(map[string]interface{}{"level": 1, "color": "#000000"})
+	assert.NilError(t, err)
+	assert.ErrorContains(t, MsgpackCodec.Unmarshal(b, &msgpackMessage{}), "only one of")
+}
+
+func TestMsgpackCodec_interfaceNumbers(t *testing.T) {
+	b, err := MsgpackCodec.Marshal(map[string]interface{}{
+		"int":   7,
+		"uint":  uint64(1 << 40),
+		"float": 0.5,
+		"list":  []int{-1},
+	})
+	assert.NilError(t, err)
+
+	// Numbers are float64 like they are with encoding/json
+	var v map[string]interface{}
+	assert.NilError(t, MsgpackCodec.Unmarshal(b, &v))
+	assert.DeepEqual(t, map[string]interface{}{
+		"int":   7.0,
+		"uint":  float64(1 << 40),
+		"float": 0.5,
+		"list":  []interface{}{-1.0},
+	}, v)
+}
@@ -0,0 +1,101 @@
+package taxi
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/jpillora/backoff"
+)
+
+// RetryPolicy describes how a Client should retry RPCs that fail
+// because of a transient problem with the remote service. Only network
+// errors and responses with a status of 5xx or 408 (i.e. internal
+// service and timeout errors) are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times an RPC will be
+	// attempted, including the first. Values less than two
+	// disable retries.
+	MaxAttempts int
+
+	// Backoff is used to decide how long to wait between attempts.
+	// If nil, a Backoff with sensible defaults will be used.
+	Backoff *backoff.Backoff
+}
+
+// DefaultRetryPolicy makes three attempts with
+// exponential backoff and jitter between each.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     defaultBackoff,
+}
+
+var defaultBackoff = &backoff.Backoff{
+	Min:    100 * time.Millisecond,
+	Max:    2 * time.Second,
+	Factor: 2,
+	Jitter: true,
+}
+
+// retryable returns whether the RPC can be safely sent more than once.
+// Requests with non-idempotent methods are only retried if the RPC has
+// been explicitly marked as idempotent.
+func (p *RetryPolicy) retryable(rpc *RPC) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+
+	switch rpc.Method {
+	case http.MethodPost, http.MethodPatch, http.MethodConnect:
+		return rpc.Idempotent
+	}
+
+	return true
+}
+
+// shouldRetry returns whether the result of the given attempt
+// (zero-indexed) warrants another attempt. It must only be
+// called for RPCs that are retryable.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, attempt int, rsp *http.Response, err error) bool {
+	if attempt+1 >= p.MaxAttempts {
+		return false
+	}
+
+	// Don't retry if the caller has given up
+	if ctx.Err() != nil {
+		return false
+	}
+
+	// Errors returned by the Doer are network errors
+	// (e.g. connection refused or a client timeout)
+	return isFailure(rsp, err)
+}
+
+// wait blocks until it is time to make the next attempt
+// or the context is cancelled, whichever happens first.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	b := p.Backoff
+	if b == nil {
+		b = defaultBackoff
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(b.ForAttempt(float64(attempt))):
+		return nil
+	}
+}
+
+// discard drains and closes the body of a response that will not be
+// decoded so that the underlying connection can be reused.
+func discard(rsp *http.Response) {
+	if rsp == nil || rsp.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(ioutil.Discard, rsp.Body)
+	_ = rsp.Body.Close()
+}
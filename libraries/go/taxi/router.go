@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
+
+	"github.com/jakewright/home-automation/libraries/go/trace"
 )
 
 // Decoder is a function that decodes a request body into the given interface
@@ -30,41 +32,69 @@ type Middleware func(http.Handler) http.Handler
 type Router struct {
 	router     *httprouter.Router
 	middleware []Middleware
-	logFunc    func(format string, v ...interface{})
+	logFunc    func(ctx context.Context, format string, v ...interface{})
+	codecs     codecs
 }
 
 // NewRouter returns an initialised Router
 func NewRouter() *Router {
 	return &Router{
 		router: httprouter.New(),
+		codecs: defaultCodecs,
 	}
 }
 
 // WithLogger sets a log function for the router to use when something goes.
 // wrong. If not set, no logs will be output.
 func (r *Router) WithLogger(f func(format string, v ...interface{})) *Router {
+	r.logFunc = func(_ context.Context, format string, v ...interface{}) {
+		f(format, v...)
+	}
+	return r
+}
+
+// WithContextLogger is like WithLogger but the log function is also given
+// the request's context. This allows the trace ID to be added to the logs.
+func (r *Router) WithContextLogger(f func(ctx context.Context, format string, v ...interface{})) *Router {
 	r.logFunc = f
 	return r
 }
 
-// Handle registers a new route
+// WithCodecs registers additional codecs with the router. Request bodies
+// are decoded using the codec that matches the Content-Type header, and
+// responses are encoded using the first codec in the Accept header that
+// is registered. JSON and msgpack are always registered, and JSON is used
+// if the Accept header doesn't match a codec. The given codecs take
+// precedence over the built-in ones.
+func (r *Router) WithCodecs(c ...Codec) *Router {
+	r.codecs = r.codecs.with(c...)
+	return r
+}
+
+// Handle registers a new route. The context passed to the handler
+// carries the trace ID from the request's headers. If the request
+// did not have a trace ID, a new one is generated.
 func (r *Router) Handle(method, path string, handler Handler) {
 	r.HandleRaw(method, path, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
 		decoder := func(v interface{}) error {
-			return DecodeRequest(req, v)
+			return decodeRequest(req, v, r.codecs)
 		}
 
-		rsp, err := handler.ServeRPC(req.Context(), decoder)
+		codec := r.codecs.negotiate(req.Header.Get("Accept"))
+
+		rsp, err := handler.ServeRPC(ctx, decoder)
 		if err != nil {
-			r.log("Failed to handle request: %v", err)
-			if err := WriteError(w, err); err != nil {
-				r.log("Failed to write response: %v", err)
+			r.log(ctx, "Failed to handle request: %v", err)
+			if err := writeError(w, err, codec); err != nil {
+				r.log(ctx, "Failed to write response: %v", err)
 			}
 			return
 		}
 
-		if err := WriteSuccess(w, rsp); err != nil {
-			r.log("Failed to handle request: %v", err)
+		if err := writeSuccess(w, rsp, codec); err != nil {
+			r.log(ctx, "Failed to handle request: %v", err)
 		}
 	}))
 }
@@ -87,8 +117,14 @@ func (r *Router) UseMiddleware(mw ...Middleware) {
 	}
 }
 
-// ServeHTTP dispatches requests to the appropriate handler
+// ServeHTTP dispatches requests to the appropriate handler. The trace ID
+// from the request's headers is added to the request's context before
+// any middleware is run. If there isn't one, a new ID is generated.
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx, traceID := trace.Ensure(req.Context(), req.Header.Get(trace.Header))
+	w.Header().Set(trace.Header, traceID)
+	req = req.WithContext(ctx)
+
 	// Wrap the handler in the middleware functions
 	var handler http.Handler = r.router
 	for i := len(r.middleware) - 1; i >= 0; i-- {
@@ -97,10 +133,10 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler.ServeHTTP(w, req)
 }
 
-func (r *Router) log(format string, v ...interface{}) {
+func (r *Router) log(ctx context.Context, format string, v ...interface{}) {
 	if r.logFunc == nil {
 		return
 	}
 
-	r.logFunc(format, v...)
+	r.logFunc(ctx, format, v...)
 }
@@ -0,0 +1,60 @@
+package taxi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/trace"
+)
+
+func TestRouter_Handle_traceID(t *testing.T) {
+	var got string
+	r := NewRouter()
+	r.HandleFunc(http.MethodGet, "/foo", func(ctx context.Context, _ Decoder) (interface{}, error) {
+		got = trace.ID(ctx)
+		return nil, nil
+	})
+
+	c := &MockClient{Handler: r}
+
+	// The trace ID in the caller's context should be propagated
+	ctx := trace.WithID(context.Background(), "abc123")
+	err := c.Get(ctx, "/foo", nil, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, "abc123", got)
+
+	// A new trace ID should be generated if there isn't one
+	err = c.Get(context.Background(), "/foo", nil, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, got != "")
+	assert.Assert(t, got != "abc123")
+}
+
+func TestRouter_WithContextLogger(t *testing.T) {
+	var middlewareID, loggedID string
+	r := NewRouter().WithContextLogger(func(ctx context.Context, _ string, _ ...interface{}) {
+		loggedID = trace.ID(ctx)
+	})
+	r.UseMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			middlewareID = trace.ID(req.Context())
+			next.ServeHTTP(w, req)
+		})
+	})
+	r.HandleFunc(http.MethodGet, "/foo", func(context.Context, Decoder) (interface{}, error) {
+		return nil, oops.BadRequest("bad request")
+	})
+
+	c := &MockClient{Handler: r}
+
+	// Middleware and logs should see the same trace ID as the handler
+	ctx := trace.WithID(context.Background(), "abc123")
+	err := c.Get(ctx, "/foo", nil, nil)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, "abc123", middlewareID)
+	assert.Equal(t, "abc123", loggedID)
+}
@@ -3,9 +3,10 @@ package taxi
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"github.com/jakewright/home-automation/libraries/go/trace"
 )
 
 // RPC represents a remote procedure call
@@ -13,21 +14,40 @@ type RPC struct {
 	Method string
 	URL    string
 	Body   interface{}
+
+	// Idempotent marks an RPC that uses a non-idempotent
+	// method (e.g. POST or PATCH) as safe to retry.
+	Idempotent bool
 }
 
-// ToRequest converts the RPC into an http.Request
+// ToRequest converts the RPC into an http.Request with a JSON body
 func (r *RPC) ToRequest(ctx context.Context) (*http.Request, error) {
-	buf := bytes.Buffer{}
-	if err := json.NewEncoder(&buf).Encode(r.Body); err != nil {
-		return nil, fmt.Errorf("failed to encode body as JSON: %w", err)
+	return r.toRequest(ctx, JSONCodec)
+}
+
+// toRequest converts the RPC into an http.Request with the body
+// encoded by the codec. The codec's content type is also set as
+// the Accept header so that the response is encoded the same way.
+func (r *RPC) toRequest(ctx context.Context, codec Codec) (*http.Request, error) {
+	body, err := codec.Marshal(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, &buf)
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", contentTypeJSON)
+	req.Header.Set("Content-Type", codec.ContentType())
+	req.Header.Set("Accept", codec.ContentType())
+
+	// Forward the trace ID so that the remote
+	// service's logs can be correlated with ours
+	if id := trace.ID(ctx); id != "" {
+		req.Header.Set(trace.Header, id)
+	}
+
 	return req, nil
 }
 
@@ -0,0 +1,270 @@
+package taxi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// Streams are sent as server-sent events. Each message is sent as the data
+// of an event with no name. If the handler returns an error after the stream
+// has started, it is sent as an "error" event. When the handler returns
+// successfully, an "end" event is sent so that the client can tell the
+// difference between the end of the stream and a dropped connection.
+const (
+	contentTypeEventStream = "text/event-stream"
+
+	eventError = "error"
+	eventEnd   = "end"
+)
+
+// Sender is a function that sends a message to the client. It returns an
+// error if the message could not be sent, e.g. because the client has gone
+// away, after which no more messages should be sent.
+type Sender func(v interface{}) error
+
+// StreamHandler is an interface that wraps the ServeStream method.
+// ServeStream should send messages until the stream is finished or
+// the context is cancelled, which happens when the client goes away.
+type StreamHandler interface {
+	ServeStream(ctx context.Context, decode Decoder, send Sender) error
+}
+
+// StreamHandlerFunc is a type that allows normal functions to be used as StreamHandlers
+type StreamHandlerFunc func(ctx context.Context, decode Decoder, send Sender) error
+
+// ServeStream calls f(ctx, decode, send)
+func (f StreamHandlerFunc) ServeStream(ctx context.Context, decode Decoder, send Sender) error {
+	return f(ctx, decode, send)
+}
+
+// HandleStream registers a new streaming route. If the handler returns an
+// error before sending any messages, a normal error response is written.
+func (r *Router) HandleStream(method, path string, handler StreamHandler) {
+	r.HandleRaw(method, path, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		decoder := func(v interface{}) error {
+			return decodeRequest(req, v, r.codecs)
+		}
+
+		sw := &streamWriter{ctx: ctx, w: w}
+
+		err := handler.ServeStream(ctx, decoder, sw.send)
+		switch {
+		case err != nil && !sw.started:
+			r.log(ctx, "Failed to handle request: %v", err)
+			if err := WriteError(w, err); err != nil {
+				r.log(ctx, "Failed to write response: %v", err)
+			}
+		case err != nil:
+			r.log(ctx, "Failed to handle request: %v", err)
+			if err := sw.writeError(err); err != nil {
+				r.log(ctx, "Failed to write response: %v", err)
+			}
+		case ctx.Err() == nil:
+			if err := sw.writeEvent(eventEnd, []byte("{}")); err != nil {
+				r.log(ctx, "Failed to write response: %v", err)
+			}
+		}
+	}))
+}
+
+// HandleStreamFunc registers a new streaming route
+func (r *Router) HandleStreamFunc(method, path string, handler func(context.Context, Decoder, Sender) error) {
+	r.HandleStream(method, path, StreamHandlerFunc(handler))
+}
+
+// streamWriter writes server-sent events to a ResponseWriter
+type streamWriter struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	started bool
+}
+
+// send marshals v to JSON and writes it as a message event
+func (s *streamWriter) send(v interface{}) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return oops.Wrap(err, oops.ErrInternalService, "failed to marshal message to JSON")
+	}
+
+	return s.writeEvent("", b)
+}
+
+func (s *streamWriter) writeError(err error) error {
+	_, payload := newErrorPayload(err)
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return oops.WithMessage(err, "failed to marshal error payload to JSON")
+	}
+
+	return s.writeEvent(eventError, b)
+}
+
+func (s *streamWriter) writeEvent(event string, data []byte) error {
+	if !s.started {
+		s.w.Header().Set("Content-Type", contentTypeEventStream)
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	var buf bytes.Buffer
+	if event != "" {
+		_, _ = fmt.Fprintf(&buf, "event: %s\n", event)
+	}
+	_, _ = fmt.Fprintf(&buf, "data: %s\n\n", data)
+
+	if _, err := buf.WriteTo(s.w); err != nil {
+		return oops.WithMessage(err, "failed to write event")
+	}
+
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// Stream makes a request to a streaming endpoint and returns a
+// Stream from which the messages can be read. The stream must be
+// closed by the caller. Retries and circuit breakers only apply
+// to the initial request.
+func (c *Client) Stream(ctx context.Context, rpc *RPC) (*Stream, error) {
+	base := c.streamBase
+	if base == nil {
+		base = c.base
+	}
+
+	rsp, err := c.do(ctx, base, rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStream(rsp, c.codecs())
+}
+
+// Stream is a sequence of messages received from a streaming endpoint
+type Stream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	err    error
+}
+
+func newStream(rsp *http.Response, cs codecs) (*Stream, error) {
+	mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if mediaType != contentTypeEventStream {
+		// This is probably an error response but if it's not, this
+		// will return an error because the data cannot be decoded.
+		return nil, decodeResponse(rsp, &struct{}{}, cs)
+	}
+
+	return &Stream{
+		body:   rsp.Body,
+		reader: bufio.NewReader(rsp.Body),
+	}, nil
+}
+
+// Next blocks until the next message is received and then decodes it into
+// the value pointed to by v. It returns io.EOF when the stream has ended.
+// Once an error has been returned, all subsequent calls return the same
+// error. Cancelling the context used to open the stream unblocks Next.
+func (s *Stream) Next(v interface{}) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	event, data, err := s.readEvent()
+	if err != nil {
+		if err == io.EOF {
+			err = oops.InternalService("stream ended unexpectedly")
+		}
+		s.err = err
+		return err
+	}
+
+	switch event {
+	case eventEnd:
+		s.err = io.EOF
+		return s.err
+
+	case eventError:
+		var payload errorPayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			s.err = oops.Wrap(err, oops.ErrInternalService, "failed to unmarshal error event")
+			return s.err
+		}
+		s.err = payload.toError(http.StatusInternalServerError)
+		return s.err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return oops.Wrap(err, oops.ErrInternalService, "failed to unmarshal message")
+	}
+
+	return nil
+}
+
+// Close closes the underlying connection. It is safe to call more than once.
+func (s *Stream) Close() error {
+	if s.err == nil {
+		s.err = io.EOF
+	}
+
+	return s.body.Close()
+}
+
+// readEvent reads lines until a complete event has been received. Comments
+// and fields that are not understood are ignored, as per the SSE spec.
+func (s *Stream) readEvent() (string, []byte, error) {
+	var event string
+	var data [][]byte
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return "", nil, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if data == nil {
+				// Blank line with no preceding data
+				continue
+			}
+
+			return event, bytes.Join(data, []byte("\n")), nil
+		}
+
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, []byte(value))
+		}
+	}
+}
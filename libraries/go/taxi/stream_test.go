@@ -0,0 +1,163 @@
+package taxi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+type streamMessage struct {
+	N int `json:"n"`
+}
+
+func TestRouter_HandleStream(t *testing.T) {
+	r := NewRouter()
+	r.HandleStreamFunc(http.MethodGet, "/count", func(ctx context.Context, decode Decoder, send Sender) error {
+		var body struct {
+			To int `json:"to"`
+		}
+		if err := decode(&body); err != nil {
+			return err
+		}
+
+		if body.To < 0 {
+			return oops.BadRequest("to must not be negative")
+		}
+
+		for i := 1; i <= body.To; i++ {
+			if err := send(&streamMessage{N: i}); err != nil {
+				return err
+			}
+		}
+
+		if body.To > 2 {
+			return oops.InternalService("counted too high")
+		}
+
+		return nil
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	c := NewClient()
+	ctx := context.Background()
+
+	// Messages are received in order followed by io.EOF
+	s, err := c.Stream(ctx, &RPC{Method: http.MethodGet, URL: srv.URL + "/count?to=2"})
+	assert.NilError(t, err)
+
+	var got []int
+	for {
+		var msg streamMessage
+		err := s.Next(&msg)
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		got = append(got, msg.N)
+	}
+	assert.DeepEqual(t, []int{1, 2}, got)
+	assert.Equal(t, io.EOF, s.Next(nil))
+	assert.NilError(t, s.Close())
+
+	// Errors before the stream starts are returned by Stream
+	_, err = c.Stream(ctx, &RPC{Method: http.MethodGet, URL: srv.URL + "/count?to=-1"})
+	assert.Assert(t, oops.Is(err, oops.ErrBadRequest))
+
+	// Errors after the stream starts are returned by Next
+	s, err = c.Stream(ctx, &RPC{Method: http.MethodGet, URL: srv.URL + "/count?to=3"})
+	assert.NilError(t, err)
+	defer func() { _ = s.Close() }()
+
+	for i := 0; i < 3; i++ {
+		assert.NilError(t, s.Next(nil))
+	}
+	assert.ErrorContains(t, s.Next(nil), "counted too high")
+}
+
+func TestRouter_HandleStream_cancellation(t *testing.T) {
+	finished := make(chan error, 1)
+
+	r := NewRouter()
+	r.HandleStreamFunc(http.MethodGet, "/forever", func(ctx context.Context, _ Decoder, send Sender) error {
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := send(&streamMessage{}); err != nil {
+					finished <- err
+					return err
+				}
+			case <-ctx.Done():
+				finished <- ctx.Err()
+				return nil
+			}
+		}
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := NewClient().Stream(ctx, &RPC{Method: http.MethodGet, URL: srv.URL + "/forever"})
+	assert.NilError(t, err)
+	defer func() { _ = s.Close() }()
+
+	assert.NilError(t, s.Next(nil))
+	cancel()
+
+	// The handler should notice that the client has gone away
+	select {
+	case err := <-finished:
+		assert.Assert(t, err != nil)
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler did not return after the client went away")
+	}
+
+	assert.Assert(t, s.Next(nil) != nil)
+}
+
+func TestClient_Stream_timeout(t *testing.T) {
+	r := NewRouter()
+	r.HandleStreamFunc(http.MethodGet, "/slow", func(ctx context.Context, _ Decoder, send Sender) error {
+		for i := 1; i <= 5; i++ {
+			time.Sleep(20 * time.Millisecond)
+			if err := send(&streamMessage{N: i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	c := newClient(50 * time.Millisecond)
+
+	// The stream outlives the request timeout
+	s, err := c.Stream(context.Background(), &RPC{Method: http.MethodGet, URL: srv.URL + "/slow"})
+	assert.NilError(t, err)
+	defer func() { _ = s.Close() }()
+
+	var got []int
+	for {
+		var msg streamMessage
+		err := s.Next(&msg)
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		got = append(got, msg.N)
+	}
+	assert.DeepEqual(t, []int{1, 2, 3, 4, 5}, got)
+}
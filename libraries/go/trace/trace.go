@@ -0,0 +1,54 @@
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header is the HTTP header used to propagate trace IDs between services
+const Header = "X-Trace-ID"
+
+// MetadataKey is the key used when a trace ID
+// is added to log metadata or Firehose events
+const MetadataKey = "trace_id"
+
+type contextKey struct{}
+
+// NewID returns a new random trace ID
+func NewID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand should never fail on the platforms we support
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// WithID returns a copy of ctx that carries the trace ID
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// ID returns the trace ID carried by ctx
+// or the empty string if there isn't one
+func ID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Ensure returns a context that carries a trace ID. If the given
+// id is empty, a new one is generated. This should be used at the
+// edge, i.e. wherever a request or event enters the system.
+func Ensure(ctx context.Context, id string) (context.Context, string) {
+	if id == "" {
+		id = NewID()
+	}
+
+	return WithID(ctx, id), id
+}
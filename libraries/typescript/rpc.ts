@@ -0,0 +1,178 @@
+// Shared code for the TypeScript clients that jrpc generates in each
+// service's def/client.ts. It is kept here so that every client
+// throws the same RPCError class.
+
+// RPCError is thrown when an RPC fails. The code and metadata
+// are those of the oops.Error returned by the service.
+export class RPCError extends Error {
+  constructor(
+    readonly status: number,
+    readonly code: string,
+    message: string,
+    readonly metadata: { [key: string]: string } = {}
+  ) {
+    super(message);
+    this.name = "RPCError";
+  }
+}
+
+// Fetch has the signature of window.fetch
+export type Fetch = (input: string, init?: RequestInit) => Promise<Response>;
+
+// RPCClient makes requests to a service. The generated
+// clients extend it with a method for each RPC.
+export abstract class RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(
+    private readonly baseURL: string,
+    private readonly fetch: Fetch = (input, init) => window.fetch(input, init)
+  ) {}
+
+  protected async do<T>(
+    method: string,
+    path: string,
+    body: object,
+    query: boolean
+  ): Promise<T> {
+    const rsp = await this.request(method, path, body, query, "application/json");
+    const payload = await decodeResponse(rsp);
+    return payload.data as T;
+  }
+
+  protected async stream<T>(
+    method: string,
+    path: string,
+    body: object,
+    query: boolean,
+    onMessage: (msg: T) => void,
+    signal?: AbortSignal
+  ): Promise<void> {
+    const rsp = await this.request(method, path, body, query, "text/event-stream", signal);
+
+    // If the handler fails before sending any messages,
+    // the error is returned as a normal response.
+    const contentType = rsp.headers.get("Content-Type") || "";
+    if (!contentType.startsWith("text/event-stream") || !rsp.body) {
+      await decodeResponse(rsp);
+      throw new RPCError(rsp.status, "", "response is not an event stream");
+    }
+
+    const reader = rsp.body.getReader();
+    const decoder = new TextDecoder();
+    let buf = "";
+
+    for (;;) {
+      const { done, value } = await reader.read();
+      if (done) {
+        throw new RPCError(rsp.status, "internal_service", "stream ended unexpectedly");
+      }
+
+      buf += decoder.decode(value, { stream: true });
+
+      let i;
+      while ((i = buf.indexOf("\n\n")) >= 0) {
+        const event = parseEvent(buf.slice(0, i));
+        buf = buf.slice(i + 2);
+
+        switch (event.name) {
+          case "end":
+            await reader.cancel();
+            return;
+          case "error": {
+            const payload = JSON.parse(event.data);
+            throw new RPCError(500, payload.code || "", payload.error, payload.metadata);
+          }
+          default:
+            onMessage(JSON.parse(event.data) as T);
+        }
+      }
+    }
+  }
+
+  private request(
+    method: string,
+    path: string,
+    body: object,
+    query: boolean,
+    accept: string,
+    signal?: AbortSignal
+  ): Promise<Response> {
+    let url = this.baseURL.replace(/\/+$/, "") + path;
+    const headers: { [key: string]: string } = { Accept: accept };
+    const init: RequestInit = { method, headers, signal };
+
+    if (query) {
+      url += toQuery(body);
+    } else {
+      headers["Content-Type"] = "application/json";
+      init.body = JSON.stringify(body);
+    }
+
+    return this.fetch(url, init);
+  }
+}
+
+// decodeResponse parses the response envelope
+// and throws an RPCError if the request failed.
+async function decodeResponse(rsp: Response): Promise<any> {
+  let payload: any;
+  try {
+    payload = await rsp.json();
+  } catch (err) {
+    throw new RPCError(rsp.status, "", `failed to decode response: ${rsp.statusText}`);
+  }
+
+  if (!rsp.ok || payload.error) {
+    throw new RPCError(
+      rsp.status,
+      payload.code || "",
+      payload.error || rsp.statusText,
+      payload.metadata
+    );
+  }
+
+  return payload;
+}
+
+// toQuery encodes the scalar and array values of the
+// body as a query string. Objects cannot be encoded.
+function toQuery(body: object): string {
+  const params = new URLSearchParams();
+
+  for (const [key, value] of Object.entries(body)) {
+    if (value === undefined || value === null) {
+      continue;
+    }
+
+    if (Array.isArray(value)) {
+      value.forEach(v => params.append(key, String(v)));
+    } else if (typeof value !== "object") {
+      params.append(key, String(value));
+    }
+  }
+
+  const s = params.toString();
+  return s ? "?" + s : "";
+}
+
+// parseEvent returns the name and data of a server-sent event
+function parseEvent(s: string): { name: string; data: string } {
+  let name = "";
+  const data: string[] = [];
+
+  for (const line of s.split("\n")) {
+    const i = line.indexOf(":");
+    const field = i < 0 ? line : line.slice(0, i);
+    const value = i < 0 ? "" : line.slice(i + 1).replace(/^ /, "");
+
+    if (field === "event") {
+      name = value;
+    } else if (field === "data") {
+      data.push(value);
+    }
+  }
+
+  return { name, data: data.join("\n") };
+}
@@ -17,7 +17,7 @@ var r, g, b byte
 
 func main() {
 	var err error
-	client, err = dmx.NewOLAClient("http://ola.local", 9090, 1)
+	client, err = dmx.NewOLAClient(nil, "http://ola.local", 9090, 1)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,45 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import { Fetch, RPCClient } from "../../../libraries/typescript/rpc";
+import {
+  GetDeviceRequest,
+  GetDeviceResponse,
+  GetRoomRequest,
+  GetRoomResponse,
+  ListDevicesRequest,
+  ListDevicesResponse,
+  ListRoomsRequest,
+  ListRoomsResponse,
+} from "./types";
+
+export { RPCError } from "../../../libraries/typescript/rpc";
+
+// DeviceRegistryClient makes requests to the DeviceRegistry service
+export class DeviceRegistryClient extends RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(baseURL: string, fetch?: Fetch) {
+    super(baseURL, fetch);
+  }
+
+  // getDevice makes a GET request to /device
+  getDevice(body: GetDeviceRequest): Promise<GetDeviceResponse> {
+    return this.do("GET", "/device", body, true);
+  }
+
+  // listDevices makes a GET request to /devices
+  listDevices(body: ListDevicesRequest): Promise<ListDevicesResponse> {
+    return this.do("GET", "/devices", body, true);
+  }
+
+  // getRoom makes a GET request to /room
+  getRoom(body: GetRoomRequest): Promise<GetRoomResponse> {
+    return this.do("GET", "/room", body, true);
+  }
+
+  // listRooms makes a GET request to /rooms
+  listRooms(body: ListRoomsRequest): Promise<ListRoomsResponse> {
+    return this.do("GET", "/rooms", body, true);
+  }
+}
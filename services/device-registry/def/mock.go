@@ -0,0 +1,382 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package deviceregistrydef
+
+import (
+	context "context"
+	sync "sync"
+	testing "testing"
+
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// MockService is an in-memory implementation of DeviceRegistryService
+// that can be used in tests. Responses are programmed, and requests
+// inspected, via the mock of each RPC, e.g. m.OnGetDevice().
+type MockService struct {
+	getDevice   *GetDeviceMock
+	listDevices *ListDevicesMock
+	getRoom     *GetRoomMock
+	listRooms   *ListRoomsMock
+}
+
+// Compile-time assertion that the mock implements the interface
+var _ DeviceRegistryService = (*MockService)(nil)
+
+// NewMockService returns a new mock with no responses programmed
+func NewMockService() *MockService {
+	return &MockService{
+		getDevice:   &GetDeviceMock{},
+		listDevices: &ListDevicesMock{},
+		getRoom:     &GetRoomMock{},
+		listRooms:   &ListRoomsMock{},
+	}
+}
+
+// OnGetDevice returns the mock of the GetDevice RPC
+func (m *MockService) OnGetDevice() *GetDeviceMock {
+	return m.getDevice
+}
+
+// GetDevice records the request and returns the programmed response
+func (m *MockService) GetDevice(ctx context.Context, body *GetDeviceRequest) *GetDeviceFuture {
+	handler := m.getDevice.record(body)
+
+	done := make(chan struct{})
+	ftr := &GetDeviceFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// GetDeviceMock programs and records calls to the GetDevice RPC
+type GetDeviceMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
+	requests []*GetDeviceRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *GetDeviceMock) Returns(rsp *GetDeviceResponse, err error) *GetDeviceMock {
+	return m.Handle(func(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *GetDeviceMock) Handle(fn func(ctx context.Context, body *GetDeviceRequest) (*GetDeviceResponse, error)) *GetDeviceMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *GetDeviceMock) record(body *GetDeviceRequest) func(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error) {
+			return nil, oops.InternalService("no response programmed for GetDevice")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *GetDeviceMock) Requests() []*GetDeviceRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*GetDeviceRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *GetDeviceMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected GetDevice to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *GetDeviceMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected GetDevice not to be called but it was called %d times", n)
+	}
+}
+
+// OnListDevices returns the mock of the ListDevices RPC
+func (m *MockService) OnListDevices() *ListDevicesMock {
+	return m.listDevices
+}
+
+// ListDevices records the request and returns the programmed response
+func (m *MockService) ListDevices(ctx context.Context, body *ListDevicesRequest) *ListDevicesFuture {
+	handler := m.listDevices.record(body)
+
+	done := make(chan struct{})
+	ftr := &ListDevicesFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// ListDevicesMock programs and records calls to the ListDevices RPC
+type ListDevicesMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	requests []*ListDevicesRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *ListDevicesMock) Returns(rsp *ListDevicesResponse, err error) *ListDevicesMock {
+	return m.Handle(func(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *ListDevicesMock) Handle(fn func(ctx context.Context, body *ListDevicesRequest) (*ListDevicesResponse, error)) *ListDevicesMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *ListDevicesMock) record(body *ListDevicesRequest) func(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+			return nil, oops.InternalService("no response programmed for ListDevices")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *ListDevicesMock) Requests() []*ListDevicesRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*ListDevicesRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *ListDevicesMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected ListDevices to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *ListDevicesMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected ListDevices not to be called but it was called %d times", n)
+	}
+}
+
+// OnGetRoom returns the mock of the GetRoom RPC
+func (m *MockService) OnGetRoom() *GetRoomMock {
+	return m.getRoom
+}
+
+// GetRoom records the request and returns the programmed response
+func (m *MockService) GetRoom(ctx context.Context, body *GetRoomRequest) *GetRoomFuture {
+	handler := m.getRoom.record(body)
+
+	done := make(chan struct{})
+	ftr := &GetRoomFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// GetRoomMock programs and records calls to the GetRoom RPC
+type GetRoomMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *GetRoomRequest) (*GetRoomResponse, error)
+	requests []*GetRoomRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *GetRoomMock) Returns(rsp *GetRoomResponse, err error) *GetRoomMock {
+	return m.Handle(func(context.Context, *GetRoomRequest) (*GetRoomResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *GetRoomMock) Handle(fn func(ctx context.Context, body *GetRoomRequest) (*GetRoomResponse, error)) *GetRoomMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *GetRoomMock) record(body *GetRoomRequest) func(context.Context, *GetRoomRequest) (*GetRoomResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *GetRoomRequest) (*GetRoomResponse, error) {
+			return nil, oops.InternalService("no response programmed for GetRoom")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *GetRoomMock) Requests() []*GetRoomRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*GetRoomRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *GetRoomMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected GetRoom to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *GetRoomMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected GetRoom not to be called but it was called %d times", n)
+	}
+}
+
+// OnListRooms returns the mock of the ListRooms RPC
+func (m *MockService) OnListRooms() *ListRoomsMock {
+	return m.listRooms
+}
+
+// ListRooms records the request and returns the programmed response
+func (m *MockService) ListRooms(ctx context.Context, body *ListRoomsRequest) *ListRoomsFuture {
+	handler := m.listRooms.record(body)
+
+	done := make(chan struct{})
+	ftr := &ListRoomsFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// ListRoomsMock programs and records calls to the ListRooms RPC
+type ListRoomsMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	requests []*ListRoomsRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *ListRoomsMock) Returns(rsp *ListRoomsResponse, err error) *ListRoomsMock {
+	return m.Handle(func(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *ListRoomsMock) Handle(fn func(ctx context.Context, body *ListRoomsRequest) (*ListRoomsResponse, error)) *ListRoomsMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *ListRoomsMock) record(body *ListRoomsRequest) func(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+			return nil, oops.InternalService("no response programmed for ListRooms")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *ListRoomsMock) Requests() []*ListRoomsRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*ListRoomsRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *ListRoomsMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected ListRooms to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *ListRoomsMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected ListRooms not to be called but it was called %d times", n)
+	}
+}
@@ -3,10 +3,36 @@
 package deviceregistrydef
 
 import (
+	fmt "fmt"
+	utf8 "unicode/utf8"
+
 	def "github.com/jakewright/home-automation/libraries/go/device/def"
 	oops "github.com/jakewright/home-automation/libraries/go/oops"
 )
 
+// prefixFieldPath returns a copy of an error returned by the Validate
+// function of a nested message with the path of the nested field
+// prepended to the field path in the error's metadata
+func prefixFieldPath(err error, path string) error {
+	e, ok := err.(*oops.Error)
+	if !ok {
+		return err
+	}
+
+	metadata := map[string]string{}
+	for k, v := range e.GetMetadata() {
+		metadata[k] = v
+	}
+
+	if field, ok := metadata["field"]; ok {
+		path += "." + field
+	}
+	metadata["field"] = path
+
+	// Validate functions only return bad request errors
+	return oops.BadRequest("%s", e.GetMessage(), metadata)
+}
+
 // Room is defined in the .def file
 type Room struct {
 	Id      *string       `json:"id,omitempty"`
@@ -62,19 +88,34 @@ func (m *Room) SetDevices(v []*def.Header) *Room {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *Room) Validate() error {
 	if m.Id == nil {
-		return oops.BadRequest("field 'id' is required")
+		return oops.BadRequest("field 'id' is required", map[string]string{
+			"field": "id",
+		})
+	}
+	if m.Id != nil && utf8.RuneCountInString(*m.Id) < 1 {
+		return oops.BadRequest("field 'id' should have length ≥ 1", map[string]string{
+			"field": "id",
+		})
 	}
+
 	if m.Name == nil {
-		return oops.BadRequest("field 'name' is required")
+		return oops.BadRequest("field 'name' is required", map[string]string{
+			"field": "name",
+		})
+	}
+	if m.Name != nil && utf8.RuneCountInString(*m.Name) < 1 {
+		return oops.BadRequest("field 'name' should have length ≥ 1", map[string]string{
+			"field": "name",
+		})
 	}
-	if m.Devices != nil {
-		for _, r := range m.Devices {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+
+	for i, r := range m.Devices {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("devices[%d]", i))
 		}
 	}
 
@@ -102,11 +143,20 @@ func (m *GetDeviceRequest) SetDeviceId(v string) *GetDeviceRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *GetDeviceRequest) Validate() error {
 	if m.DeviceId == nil {
-		return oops.BadRequest("field 'device_id' is required")
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
 	}
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
+	}
+
 	return nil
 }
 
@@ -131,10 +181,13 @@ func (m *GetDeviceResponse) SetDeviceHeader(v def.Header) *GetDeviceResponse {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *GetDeviceResponse) Validate() error {
-	if err := m.DeviceHeader.Validate(); err != nil {
-		return err
+	if m.DeviceHeader != nil {
+		if err := m.DeviceHeader.Validate(); err != nil {
+			return prefixFieldPath(err, "device_header")
+		}
 	}
 
 	return nil
@@ -161,7 +214,8 @@ func (m *ListDevicesRequest) SetControllerName(v string) *ListDevicesRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ListDevicesRequest) Validate() error {
 	return nil
 }
@@ -187,13 +241,12 @@ func (m *ListDevicesResponse) SetDeviceHeaders(v []*def.Header) *ListDevicesResp
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ListDevicesResponse) Validate() error {
-	if m.DeviceHeaders != nil {
-		for _, r := range m.DeviceHeaders {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+	for i, r := range m.DeviceHeaders {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("device_headers[%d]", i))
 		}
 	}
 
@@ -221,11 +274,20 @@ func (m *GetRoomRequest) SetRoomId(v string) *GetRoomRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *GetRoomRequest) Validate() error {
 	if m.RoomId == nil {
-		return oops.BadRequest("field 'room_id' is required")
+		return oops.BadRequest("field 'room_id' is required", map[string]string{
+			"field": "room_id",
+		})
+	}
+	if m.RoomId != nil && utf8.RuneCountInString(*m.RoomId) < 1 {
+		return oops.BadRequest("field 'room_id' should have length ≥ 1", map[string]string{
+			"field": "room_id",
+		})
 	}
+
 	return nil
 }
 
@@ -250,10 +312,13 @@ func (m *GetRoomResponse) SetRoom(v Room) *GetRoomResponse {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *GetRoomResponse) Validate() error {
-	if err := m.Room.Validate(); err != nil {
-		return err
+	if m.Room != nil {
+		if err := m.Room.Validate(); err != nil {
+			return prefixFieldPath(err, "room")
+		}
 	}
 
 	return nil
@@ -263,7 +328,8 @@ func (m *GetRoomResponse) Validate() error {
 type ListRoomsRequest struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ListRoomsRequest) Validate() error {
 	return nil
 }
@@ -289,13 +355,12 @@ func (m *ListRoomsResponse) SetRooms(v []*Room) *ListRoomsResponse {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ListRoomsResponse) Validate() error {
-	if m.Rooms != nil {
-		for _, r := range m.Rooms {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+	for i, r := range m.Rooms {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("rooms[%d]", i))
 		}
 	}
 
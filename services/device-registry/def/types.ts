@@ -0,0 +1,49 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import * as device from "../../../libraries/go/device/def/types";
+
+// Room is defined in the .def file
+export interface Room {
+  id: string;
+  name: string;
+  devices?: device.Header[];
+}
+
+// GetDeviceRequest is defined in the .def file
+export interface GetDeviceRequest {
+  device_id: string;
+}
+
+// GetDeviceResponse is defined in the .def file
+export interface GetDeviceResponse {
+  device_header?: device.Header;
+}
+
+// ListDevicesRequest is defined in the .def file
+export interface ListDevicesRequest {
+  controller_name?: string;
+}
+
+// ListDevicesResponse is defined in the .def file
+export interface ListDevicesResponse {
+  device_headers?: device.Header[];
+}
+
+// GetRoomRequest is defined in the .def file
+export interface GetRoomRequest {
+  room_id: string;
+}
+
+// GetRoomResponse is defined in the .def file
+export interface GetRoomResponse {
+  room?: Room;
+}
+
+// ListRoomsRequest is defined in the .def file
+export interface ListRoomsRequest {
+}
+
+// ListRoomsResponse is defined in the .def file
+export interface ListRoomsResponse {
+  rooms?: Room[];
+}
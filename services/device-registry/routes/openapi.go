@@ -0,0 +1,414 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package routes
+
+import (
+	router "github.com/jakewright/home-automation/libraries/go/router"
+)
+
+func init() {
+	router.RegisterOpenAPI([]byte(openAPIDocument))
+}
+
+// openAPIDocument describes the service's RPCs in OpenAPI 3 format
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "DeviceRegistry",
+    "version": "1.0.0"
+  },
+  "servers": [
+    {
+      "url": "http://device-registry"
+    }
+  ],
+  "paths": {
+    "/device": {
+      "get": {
+        "operationId": "GetDevice",
+        "tags": [
+          "DeviceRegistry"
+        ],
+        "parameters": [
+          {
+            "name": "device_id",
+            "in": "query",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "minLength": 1
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/GetDeviceResponse"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/GetDeviceResponse"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/devices": {
+      "get": {
+        "operationId": "ListDevices",
+        "tags": [
+          "DeviceRegistry"
+        ],
+        "parameters": [
+          {
+            "name": "controller_name",
+            "in": "query",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/ListDevicesResponse"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/ListDevicesResponse"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/room": {
+      "get": {
+        "operationId": "GetRoom",
+        "tags": [
+          "DeviceRegistry"
+        ],
+        "parameters": [
+          {
+            "name": "room_id",
+            "in": "query",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "minLength": 1
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/GetRoomResponse"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/GetRoomResponse"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/rooms": {
+      "get": {
+        "operationId": "ListRooms",
+        "tags": [
+          "DeviceRegistry"
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/ListRoomsResponse"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/ListRoomsResponse"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "GetDeviceResponse": {
+        "type": "object",
+        "properties": {
+          "device_header": {
+            "$ref": "#/components/schemas/device.Header"
+          }
+        }
+      },
+      "GetRoomResponse": {
+        "type": "object",
+        "properties": {
+          "room": {
+            "$ref": "#/components/schemas/Room"
+          }
+        }
+      },
+      "ListDevicesResponse": {
+        "type": "object",
+        "properties": {
+          "device_headers": {
+            "type": "array",
+            "items": {
+              "$ref": "#/components/schemas/device.Header"
+            }
+          }
+        }
+      },
+      "ListRoomsResponse": {
+        "type": "object",
+        "properties": {
+          "rooms": {
+            "type": "array",
+            "items": {
+              "$ref": "#/components/schemas/Room"
+            }
+          }
+        }
+      },
+      "Room": {
+        "type": "object",
+        "properties": {
+          "devices": {
+            "type": "array",
+            "items": {
+              "$ref": "#/components/schemas/device.Header"
+            }
+          },
+          "id": {
+            "type": "string",
+            "minLength": 1
+          },
+          "name": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "required": [
+          "id",
+          "name"
+        ]
+      },
+      "device.Header": {
+        "type": "object",
+        "properties": {
+          "attributes": {
+            "type": "object",
+            "additionalProperties": {}
+          },
+          "controller_name": {
+            "type": "string"
+          },
+          "id": {
+            "type": "string",
+            "minLength": 1
+          },
+          "kind": {
+            "type": "string"
+          },
+          "name": {
+            "type": "string",
+            "minLength": 1
+          },
+          "room_id": {
+            "type": "string"
+          },
+          "state_providers": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          },
+          "type": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "id",
+          "name",
+          "type",
+          "kind",
+          "controller_name"
+        ]
+      },
+      "taxi.Error": {
+        "type": "object",
+        "properties": {
+          "cause": {
+            "$ref": "#/components/schemas/taxi.RemoteError"
+          },
+          "code": {
+            "type": "string"
+          },
+          "error": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "metadata": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            }
+          },
+          "stack": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "error"
+        ]
+      },
+      "taxi.RemoteError": {
+        "type": "object",
+        "properties": {
+          "cause": {
+            "$ref": "#/components/schemas/taxi.RemoteError"
+          },
+          "code": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "metadata": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    }
+  }
+}`
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/vrischmann/envconfig"
+
+	"github.com/jakewright/home-automation/libraries/go/bootstrap"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+	"github.com/jakewright/home-automation/services/dmx/dmx"
+	"github.com/jakewright/home-automation/services/dmx/domain"
+)
+
+// Universe outputs
+const (
+	outputOLA    = "ola"
+	outputArtNet = "artnet"
+	outputSACN   = "sacn"
+)
+
+// universeConfig is set from an element of the UNIVERSES
+// variable, which is a list of universes in one of the forms:
+//
+//	{number,ola_host,ola_port}
+//	{number,ola,ola_host,ola_port}
+//	{number,artnet,node_address[,port_address]}
+//	{number,sacn,[receiver_address][,sacn_universe]}
+//
+// The universe on the wire defaults to the universe number. If
+// the sACN receiver address is empty, packets are multicast. The
+// sACN CID is derived from the service name and the hostname.
+type universeConfig struct {
+	UniverseNumber domain.UniverseNumber
+	Output         string
+	Address        string
+	OLAPort        int
+	OutputUniverse uint16
+}
+
+var _ envconfig.Unmarshaler = (*universeConfig)(nil)
+
+// Unmarshal parses the universe's config
+func (c *universeConfig) Unmarshal(s string) error {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	fields := strings.Split(s, ",")
+	if len(fields) < 3 {
+		return oops.InternalService("invalid universe config %q", s)
+	}
+
+	n, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return oops.WithMessage(err, "invalid universe number %q", fields[0])
+	}
+	c.UniverseNumber = domain.UniverseNumber(n)
+	c.OutputUniverse = uint16(n)
+
+	// The original form of the config only supported OLA
+	if len(fields) == 3 && fields[1] != outputArtNet && fields[1] != outputSACN {
+		fields = []string{fields[0], outputOLA, fields[1], fields[2]}
+	}
+
+	c.Output = fields[1]
+	c.Address = fields[2]
+
+	switch c.Output {
+	case outputOLA:
+		if len(fields) != 4 {
+			return oops.InternalService("invalid OLA universe config %q", s)
+		}
+
+		if c.OLAPort, err = strconv.Atoi(fields[3]); err != nil {
+			return oops.WithMessage(err, "invalid OLA port %q", fields[3])
+		}
+
+	case outputArtNet, outputSACN:
+		if len(fields) > 4 {
+			return oops.InternalService("invalid %s universe config %q", c.Output, s)
+		}
+
+		if len(fields) == 4 && fields[3] != "" {
+			u, err := strconv.ParseUint(fields[3], 10, 16)
+			if err != nil {
+				return oops.WithMessage(err, "invalid output universe %q", fields[3])
+			}
+			c.OutputUniverse = uint16(u)
+		}
+
+	default:
+		return oops.InternalService("unknown output %q for universe %d", c.Output, c.UniverseNumber)
+	}
+
+	return nil
+}
+
+// getSetter returns the GetSetter for the universe's output. Art-Net and
+// sACN outputs are also returned as a process that must be run to keep
+// the receiver's values refreshed. Requests to OLA are made by the doer.
+func (c *universeConfig) getSetter(olaDoer taxi.Doer) (dmx.GetSetter, bootstrap.Process, error) {
+	switch c.Output {
+	case outputArtNet:
+		gs, err := dmx.NewArtNetClient(&dmx.ArtNetOptions{
+			Address:  c.Address,
+			Universe: c.OutputUniverse,
+		})
+		return gs, gs, err
+
+	case outputSACN:
+		gs, err := dmx.NewSACNClient(&dmx.SACNOptions{
+			Address:    c.Address,
+			Universe:   c.OutputUniverse,
+			SourceName: serviceName,
+		})
+		return gs, gs, err
+	}
+
+	gs, err := dmx.NewOLAClient(olaDoer, c.Address, c.OLAPort, c.UniverseNumber)
+	return gs, nil, err
+}
@@ -4,7 +4,7 @@ package dmxdef
 
 import (
 	context "context"
-	"testing"
+	testing "testing"
 
 	taxi "github.com/jakewright/home-automation/libraries/go/taxi"
 )
@@ -13,6 +13,12 @@ import (
 type DMXService interface {
 	GetMegaParProfile(ctx context.Context, body *GetMegaParProfileRequest) *GetMegaParProfileFuture
 	UpdateMegaParProfile(ctx context.Context, body *UpdateMegaParProfileRequest) *UpdateMegaParProfileFuture
+	GetFixture(ctx context.Context, body *GetFixtureRequest) *GetFixtureFuture
+	UpdateFixture(ctx context.Context, body *UpdateFixtureRequest) *UpdateFixtureFuture
+	GetGroup(ctx context.Context, body *GetGroupRequest) *GetGroupFuture
+	UpdateGroup(ctx context.Context, body *UpdateGroupRequest) *UpdateGroupFuture
+	GetUniverse(ctx context.Context, body *GetUniverseRequest) *GetUniverseFuture
+	UpdateUniverse(ctx context.Context, body *UpdateUniverseRequest) *UpdateUniverseFuture
 }
 
 // GetMegaParProfileFuture represents an in-flight GetMegaParProfile request
@@ -41,6 +47,84 @@ func (f *UpdateMegaParProfileFuture) Wait() (*MegaParProfileResponse, error) {
 	return f.rsp, f.err
 }
 
+// GetFixtureFuture represents an in-flight GetFixture request
+type GetFixtureFuture struct {
+	done <-chan struct{}
+	rsp  *FixtureResponse
+	err  error
+}
+
+// Wait blocks until the response is ready
+func (f *GetFixtureFuture) Wait() (*FixtureResponse, error) {
+	<-f.done
+	return f.rsp, f.err
+}
+
+// UpdateFixtureFuture represents an in-flight UpdateFixture request
+type UpdateFixtureFuture struct {
+	done <-chan struct{}
+	rsp  *FixtureResponse
+	err  error
+}
+
+// Wait blocks until the response is ready
+func (f *UpdateFixtureFuture) Wait() (*FixtureResponse, error) {
+	<-f.done
+	return f.rsp, f.err
+}
+
+// GetGroupFuture represents an in-flight GetGroup request
+type GetGroupFuture struct {
+	done <-chan struct{}
+	rsp  *GroupResponse
+	err  error
+}
+
+// Wait blocks until the response is ready
+func (f *GetGroupFuture) Wait() (*GroupResponse, error) {
+	<-f.done
+	return f.rsp, f.err
+}
+
+// UpdateGroupFuture represents an in-flight UpdateGroup request
+type UpdateGroupFuture struct {
+	done <-chan struct{}
+	rsp  *GroupResponse
+	err  error
+}
+
+// Wait blocks until the response is ready
+func (f *UpdateGroupFuture) Wait() (*GroupResponse, error) {
+	<-f.done
+	return f.rsp, f.err
+}
+
+// GetUniverseFuture represents an in-flight GetUniverse request
+type GetUniverseFuture struct {
+	done <-chan struct{}
+	rsp  *UniverseResponse
+	err  error
+}
+
+// Wait blocks until the response is ready
+func (f *GetUniverseFuture) Wait() (*UniverseResponse, error) {
+	<-f.done
+	return f.rsp, f.err
+}
+
+// UpdateUniverseFuture represents an in-flight UpdateUniverse request
+type UpdateUniverseFuture struct {
+	done <-chan struct{}
+	rsp  *UniverseResponse
+	err  error
+}
+
+// Wait blocks until the response is ready
+func (f *UpdateUniverseFuture) Wait() (*UniverseResponse, error) {
+	<-f.done
+	return f.rsp, f.err
+}
+
 // Client makes requests to this service
 type Client struct {
 	dispatcher taxi.Dispatcher
@@ -81,9 +165,10 @@ func (c *Client) GetMegaParProfile(ctx context.Context, body *GetMegaParProfileR
 // UpdateMegaParProfile dispatches an RPC to the service
 func (c *Client) UpdateMegaParProfile(ctx context.Context, body *UpdateMegaParProfileRequest) *UpdateMegaParProfileFuture {
 	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
-		Method: "PATCH",
-		URL:    "http://dmx/mega-par-profile",
-		Body:   body,
+		Method:     "PATCH",
+		URL:        "http://dmx/mega-par-profile",
+		Body:       body,
+		Idempotent: true,
 	})
 
 	done := make(chan struct{})
@@ -100,6 +185,141 @@ func (c *Client) UpdateMegaParProfile(ctx context.Context, body *UpdateMegaParPr
 	return ftr
 }
 
+// GetFixture dispatches an RPC to the service
+func (c *Client) GetFixture(ctx context.Context, body *GetFixtureRequest) *GetFixtureFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: "GET",
+		URL:    "http://dmx/fixture",
+		Body:   body,
+	})
+
+	done := make(chan struct{})
+	ftr := &GetFixtureFuture{
+		done: done,
+		rsp:  &FixtureResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// UpdateFixture dispatches an RPC to the service
+func (c *Client) UpdateFixture(ctx context.Context, body *UpdateFixtureRequest) *UpdateFixtureFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method:     "PATCH",
+		URL:        "http://dmx/fixture",
+		Body:       body,
+		Idempotent: true,
+	})
+
+	done := make(chan struct{})
+	ftr := &UpdateFixtureFuture{
+		done: done,
+		rsp:  &FixtureResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// GetGroup dispatches an RPC to the service
+func (c *Client) GetGroup(ctx context.Context, body *GetGroupRequest) *GetGroupFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: "GET",
+		URL:    "http://dmx/group",
+		Body:   body,
+	})
+
+	done := make(chan struct{})
+	ftr := &GetGroupFuture{
+		done: done,
+		rsp:  &GroupResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// UpdateGroup dispatches an RPC to the service
+func (c *Client) UpdateGroup(ctx context.Context, body *UpdateGroupRequest) *UpdateGroupFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method:     "PATCH",
+		URL:        "http://dmx/group",
+		Body:       body,
+		Idempotent: true,
+	})
+
+	done := make(chan struct{})
+	ftr := &UpdateGroupFuture{
+		done: done,
+		rsp:  &GroupResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// GetUniverse dispatches an RPC to the service
+func (c *Client) GetUniverse(ctx context.Context, body *GetUniverseRequest) *GetUniverseFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: "GET",
+		URL:    "http://dmx/universe",
+		Body:   body,
+	})
+
+	done := make(chan struct{})
+	ftr := &GetUniverseFuture{
+		done: done,
+		rsp:  &UniverseResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// UpdateUniverse dispatches an RPC to the service
+func (c *Client) UpdateUniverse(ctx context.Context, body *UpdateUniverseRequest) *UpdateUniverseFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method:     "PATCH",
+		URL:        "http://dmx/universe",
+		Body:       body,
+		Idempotent: true,
+	})
+
+	done := make(chan struct{})
+	ftr := &UpdateUniverseFuture{
+		done: done,
+		rsp:  &UniverseResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(ftr.rsp)
+	}()
+
+	return ftr
+}
+
 // MockClient can be used in tests
 type MockClient struct {
 	dispatcher *taxi.MockClient
@@ -142,9 +362,10 @@ func (c *MockClient) GetMegaParProfile(ctx context.Context, body *GetMegaParProf
 // UpdateMegaParProfile dispatches an RPC to the mock client
 func (c *MockClient) UpdateMegaParProfile(ctx context.Context, body *UpdateMegaParProfileRequest) *UpdateMegaParProfileFuture {
 	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
-		Method: "PATCH",
-		URL:    "http://dmx/mega-par-profile",
-		Body:   body,
+		Method:     "PATCH",
+		URL:        "http://dmx/mega-par-profile",
+		Body:       body,
+		Idempotent: true,
 	})
 
 	done := make(chan struct{})
@@ -160,3 +381,138 @@ func (c *MockClient) UpdateMegaParProfile(ctx context.Context, body *UpdateMegaP
 
 	return ftr
 }
+
+// GetFixture dispatches an RPC to the mock client
+func (c *MockClient) GetFixture(ctx context.Context, body *GetFixtureRequest) *GetFixtureFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: "GET",
+		URL:    "http://dmx/fixture",
+		Body:   body,
+	})
+
+	done := make(chan struct{})
+	ftr := &GetFixtureFuture{
+		done: done,
+		rsp:  &FixtureResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(&ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// UpdateFixture dispatches an RPC to the mock client
+func (c *MockClient) UpdateFixture(ctx context.Context, body *UpdateFixtureRequest) *UpdateFixtureFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method:     "PATCH",
+		URL:        "http://dmx/fixture",
+		Body:       body,
+		Idempotent: true,
+	})
+
+	done := make(chan struct{})
+	ftr := &UpdateFixtureFuture{
+		done: done,
+		rsp:  &FixtureResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(&ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// GetGroup dispatches an RPC to the mock client
+func (c *MockClient) GetGroup(ctx context.Context, body *GetGroupRequest) *GetGroupFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: "GET",
+		URL:    "http://dmx/group",
+		Body:   body,
+	})
+
+	done := make(chan struct{})
+	ftr := &GetGroupFuture{
+		done: done,
+		rsp:  &GroupResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(&ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// UpdateGroup dispatches an RPC to the mock client
+func (c *MockClient) UpdateGroup(ctx context.Context, body *UpdateGroupRequest) *UpdateGroupFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method:     "PATCH",
+		URL:        "http://dmx/group",
+		Body:       body,
+		Idempotent: true,
+	})
+
+	done := make(chan struct{})
+	ftr := &UpdateGroupFuture{
+		done: done,
+		rsp:  &GroupResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(&ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// GetUniverse dispatches an RPC to the mock client
+func (c *MockClient) GetUniverse(ctx context.Context, body *GetUniverseRequest) *GetUniverseFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: "GET",
+		URL:    "http://dmx/universe",
+		Body:   body,
+	})
+
+	done := make(chan struct{})
+	ftr := &GetUniverseFuture{
+		done: done,
+		rsp:  &UniverseResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(&ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// UpdateUniverse dispatches an RPC to the mock client
+func (c *MockClient) UpdateUniverse(ctx context.Context, body *UpdateUniverseRequest) *UpdateUniverseFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method:     "PATCH",
+		URL:        "http://dmx/universe",
+		Body:       body,
+		Idempotent: true,
+	})
+
+	done := make(chan struct{})
+	ftr := &UpdateUniverseFuture{
+		done: done,
+		rsp:  &UniverseResponse{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(&ftr.rsp)
+	}()
+
+	return ftr
+}
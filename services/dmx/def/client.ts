@@ -0,0 +1,69 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import { Fetch, RPCClient } from "../../../libraries/typescript/rpc";
+import {
+  FixtureResponse,
+  GetFixtureRequest,
+  GetGroupRequest,
+  GetMegaParProfileRequest,
+  GetUniverseRequest,
+  GroupResponse,
+  MegaParProfileResponse,
+  UniverseResponse,
+  UpdateFixtureRequest,
+  UpdateGroupRequest,
+  UpdateMegaParProfileRequest,
+  UpdateUniverseRequest,
+} from "./types";
+
+export { RPCError } from "../../../libraries/typescript/rpc";
+
+// DMXClient makes requests to the DMX service
+export class DMXClient extends RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(baseURL: string, fetch?: Fetch) {
+    super(baseURL, fetch);
+  }
+
+  // getMegaParProfile makes a GET request to /mega-par-profile
+  getMegaParProfile(body: GetMegaParProfileRequest): Promise<MegaParProfileResponse> {
+    return this.do("GET", "/mega-par-profile", body, true);
+  }
+
+  // updateMegaParProfile makes a PATCH request to /mega-par-profile
+  updateMegaParProfile(body: UpdateMegaParProfileRequest): Promise<MegaParProfileResponse> {
+    return this.do("PATCH", "/mega-par-profile", body, false);
+  }
+
+  // getFixture makes a GET request to /fixture
+  getFixture(body: GetFixtureRequest): Promise<FixtureResponse> {
+    return this.do("GET", "/fixture", body, true);
+  }
+
+  // updateFixture makes a PATCH request to /fixture
+  updateFixture(body: UpdateFixtureRequest): Promise<FixtureResponse> {
+    return this.do("PATCH", "/fixture", body, false);
+  }
+
+  // getGroup makes a GET request to /group
+  getGroup(body: GetGroupRequest): Promise<GroupResponse> {
+    return this.do("GET", "/group", body, true);
+  }
+
+  // updateGroup makes a PATCH request to /group
+  updateGroup(body: UpdateGroupRequest): Promise<GroupResponse> {
+    return this.do("PATCH", "/group", body, false);
+  }
+
+  // getUniverse makes a GET request to /universe
+  getUniverse(body: GetUniverseRequest): Promise<UniverseResponse> {
+    return this.do("GET", "/universe", body, true);
+  }
+
+  // updateUniverse makes a PATCH request to /universe
+  updateUniverse(body: UpdateUniverseRequest): Promise<UniverseResponse> {
+    return this.do("PATCH", "/universe", body, false);
+  }
+}
@@ -0,0 +1,738 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package dmxdef
+
+import (
+	context "context"
+	sync "sync"
+	testing "testing"
+
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// MockService is an in-memory implementation of DMXService
+// that can be used in tests. Responses are programmed, and requests
+// inspected, via the mock of each RPC, e.g. m.OnGetMegaParProfile().
+type MockService struct {
+	getMegaParProfile    *GetMegaParProfileMock
+	updateMegaParProfile *UpdateMegaParProfileMock
+	getFixture           *GetFixtureMock
+	updateFixture        *UpdateFixtureMock
+	getGroup             *GetGroupMock
+	updateGroup          *UpdateGroupMock
+	getUniverse          *GetUniverseMock
+	updateUniverse       *UpdateUniverseMock
+}
+
+// Compile-time assertion that the mock implements the interface
+var _ DMXService = (*MockService)(nil)
+
+// NewMockService returns a new mock with no responses programmed
+func NewMockService() *MockService {
+	return &MockService{
+		getMegaParProfile:    &GetMegaParProfileMock{},
+		updateMegaParProfile: &UpdateMegaParProfileMock{},
+		getFixture:           &GetFixtureMock{},
+		updateFixture:        &UpdateFixtureMock{},
+		getGroup:             &GetGroupMock{},
+		updateGroup:          &UpdateGroupMock{},
+		getUniverse:          &GetUniverseMock{},
+		updateUniverse:       &UpdateUniverseMock{},
+	}
+}
+
+// OnGetMegaParProfile returns the mock of the GetMegaParProfile RPC
+func (m *MockService) OnGetMegaParProfile() *GetMegaParProfileMock {
+	return m.getMegaParProfile
+}
+
+// GetMegaParProfile records the request and returns the programmed response
+func (m *MockService) GetMegaParProfile(ctx context.Context, body *GetMegaParProfileRequest) *GetMegaParProfileFuture {
+	handler := m.getMegaParProfile.record(body)
+
+	done := make(chan struct{})
+	ftr := &GetMegaParProfileFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// GetMegaParProfileMock programs and records calls to the GetMegaParProfile RPC
+type GetMegaParProfileMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *GetMegaParProfileRequest) (*MegaParProfileResponse, error)
+	requests []*GetMegaParProfileRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *GetMegaParProfileMock) Returns(rsp *MegaParProfileResponse, err error) *GetMegaParProfileMock {
+	return m.Handle(func(context.Context, *GetMegaParProfileRequest) (*MegaParProfileResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *GetMegaParProfileMock) Handle(fn func(ctx context.Context, body *GetMegaParProfileRequest) (*MegaParProfileResponse, error)) *GetMegaParProfileMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *GetMegaParProfileMock) record(body *GetMegaParProfileRequest) func(context.Context, *GetMegaParProfileRequest) (*MegaParProfileResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *GetMegaParProfileRequest) (*MegaParProfileResponse, error) {
+			return nil, oops.InternalService("no response programmed for GetMegaParProfile")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *GetMegaParProfileMock) Requests() []*GetMegaParProfileRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*GetMegaParProfileRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *GetMegaParProfileMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected GetMegaParProfile to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *GetMegaParProfileMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected GetMegaParProfile not to be called but it was called %d times", n)
+	}
+}
+
+// OnUpdateMegaParProfile returns the mock of the UpdateMegaParProfile RPC
+func (m *MockService) OnUpdateMegaParProfile() *UpdateMegaParProfileMock {
+	return m.updateMegaParProfile
+}
+
+// UpdateMegaParProfile records the request and returns the programmed response
+func (m *MockService) UpdateMegaParProfile(ctx context.Context, body *UpdateMegaParProfileRequest) *UpdateMegaParProfileFuture {
+	handler := m.updateMegaParProfile.record(body)
+
+	done := make(chan struct{})
+	ftr := &UpdateMegaParProfileFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// UpdateMegaParProfileMock programs and records calls to the UpdateMegaParProfile RPC
+type UpdateMegaParProfileMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *UpdateMegaParProfileRequest) (*MegaParProfileResponse, error)
+	requests []*UpdateMegaParProfileRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *UpdateMegaParProfileMock) Returns(rsp *MegaParProfileResponse, err error) *UpdateMegaParProfileMock {
+	return m.Handle(func(context.Context, *UpdateMegaParProfileRequest) (*MegaParProfileResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *UpdateMegaParProfileMock) Handle(fn func(ctx context.Context, body *UpdateMegaParProfileRequest) (*MegaParProfileResponse, error)) *UpdateMegaParProfileMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *UpdateMegaParProfileMock) record(body *UpdateMegaParProfileRequest) func(context.Context, *UpdateMegaParProfileRequest) (*MegaParProfileResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *UpdateMegaParProfileRequest) (*MegaParProfileResponse, error) {
+			return nil, oops.InternalService("no response programmed for UpdateMegaParProfile")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *UpdateMegaParProfileMock) Requests() []*UpdateMegaParProfileRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*UpdateMegaParProfileRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *UpdateMegaParProfileMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected UpdateMegaParProfile to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *UpdateMegaParProfileMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected UpdateMegaParProfile not to be called but it was called %d times", n)
+	}
+}
+
+// OnGetFixture returns the mock of the GetFixture RPC
+func (m *MockService) OnGetFixture() *GetFixtureMock {
+	return m.getFixture
+}
+
+// GetFixture records the request and returns the programmed response
+func (m *MockService) GetFixture(ctx context.Context, body *GetFixtureRequest) *GetFixtureFuture {
+	handler := m.getFixture.record(body)
+
+	done := make(chan struct{})
+	ftr := &GetFixtureFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// GetFixtureMock programs and records calls to the GetFixture RPC
+type GetFixtureMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *GetFixtureRequest) (*FixtureResponse, error)
+	requests []*GetFixtureRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *GetFixtureMock) Returns(rsp *FixtureResponse, err error) *GetFixtureMock {
+	return m.Handle(func(context.Context, *GetFixtureRequest) (*FixtureResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *GetFixtureMock) Handle(fn func(ctx context.Context, body *GetFixtureRequest) (*FixtureResponse, error)) *GetFixtureMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *GetFixtureMock) record(body *GetFixtureRequest) func(context.Context, *GetFixtureRequest) (*FixtureResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *GetFixtureRequest) (*FixtureResponse, error) {
+			return nil, oops.InternalService("no response programmed for GetFixture")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *GetFixtureMock) Requests() []*GetFixtureRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*GetFixtureRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *GetFixtureMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected GetFixture to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *GetFixtureMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected GetFixture not to be called but it was called %d times", n)
+	}
+}
+
+// OnUpdateFixture returns the mock of the UpdateFixture RPC
+func (m *MockService) OnUpdateFixture() *UpdateFixtureMock {
+	return m.updateFixture
+}
+
+// UpdateFixture records the request and returns the programmed response
+func (m *MockService) UpdateFixture(ctx context.Context, body *UpdateFixtureRequest) *UpdateFixtureFuture {
+	handler := m.updateFixture.record(body)
+
+	done := make(chan struct{})
+	ftr := &UpdateFixtureFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// UpdateFixtureMock programs and records calls to the UpdateFixture RPC
+type UpdateFixtureMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *UpdateFixtureRequest) (*FixtureResponse, error)
+	requests []*UpdateFixtureRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *UpdateFixtureMock) Returns(rsp *FixtureResponse, err error) *UpdateFixtureMock {
+	return m.Handle(func(context.Context, *UpdateFixtureRequest) (*FixtureResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *UpdateFixtureMock) Handle(fn func(ctx context.Context, body *UpdateFixtureRequest) (*FixtureResponse, error)) *UpdateFixtureMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *UpdateFixtureMock) record(body *UpdateFixtureRequest) func(context.Context, *UpdateFixtureRequest) (*FixtureResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *UpdateFixtureRequest) (*FixtureResponse, error) {
+			return nil, oops.InternalService("no response programmed for UpdateFixture")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *UpdateFixtureMock) Requests() []*UpdateFixtureRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*UpdateFixtureRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *UpdateFixtureMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected UpdateFixture to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *UpdateFixtureMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected UpdateFixture not to be called but it was called %d times", n)
+	}
+}
+
+// OnGetGroup returns the mock of the GetGroup RPC
+func (m *MockService) OnGetGroup() *GetGroupMock {
+	return m.getGroup
+}
+
+// GetGroup records the request and returns the programmed response
+func (m *MockService) GetGroup(ctx context.Context, body *GetGroupRequest) *GetGroupFuture {
+	handler := m.getGroup.record(body)
+
+	done := make(chan struct{})
+	ftr := &GetGroupFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// GetGroupMock programs and records calls to the GetGroup RPC
+type GetGroupMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *GetGroupRequest) (*GroupResponse, error)
+	requests []*GetGroupRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *GetGroupMock) Returns(rsp *GroupResponse, err error) *GetGroupMock {
+	return m.Handle(func(context.Context, *GetGroupRequest) (*GroupResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *GetGroupMock) Handle(fn func(ctx context.Context, body *GetGroupRequest) (*GroupResponse, error)) *GetGroupMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *GetGroupMock) record(body *GetGroupRequest) func(context.Context, *GetGroupRequest) (*GroupResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *GetGroupRequest) (*GroupResponse, error) {
+			return nil, oops.InternalService("no response programmed for GetGroup")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *GetGroupMock) Requests() []*GetGroupRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*GetGroupRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *GetGroupMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected GetGroup to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *GetGroupMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected GetGroup not to be called but it was called %d times", n)
+	}
+}
+
+// OnUpdateGroup returns the mock of the UpdateGroup RPC
+func (m *MockService) OnUpdateGroup() *UpdateGroupMock {
+	return m.updateGroup
+}
+
+// UpdateGroup records the request and returns the programmed response
+func (m *MockService) UpdateGroup(ctx context.Context, body *UpdateGroupRequest) *UpdateGroupFuture {
+	handler := m.updateGroup.record(body)
+
+	done := make(chan struct{})
+	ftr := &UpdateGroupFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// UpdateGroupMock programs and records calls to the UpdateGroup RPC
+type UpdateGroupMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *UpdateGroupRequest) (*GroupResponse, error)
+	requests []*UpdateGroupRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *UpdateGroupMock) Returns(rsp *GroupResponse, err error) *UpdateGroupMock {
+	return m.Handle(func(context.Context, *UpdateGroupRequest) (*GroupResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *UpdateGroupMock) Handle(fn func(ctx context.Context, body *UpdateGroupRequest) (*GroupResponse, error)) *UpdateGroupMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *UpdateGroupMock) record(body *UpdateGroupRequest) func(context.Context, *UpdateGroupRequest) (*GroupResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *UpdateGroupRequest) (*GroupResponse, error) {
+			return nil, oops.InternalService("no response programmed for UpdateGroup")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *UpdateGroupMock) Requests() []*UpdateGroupRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*UpdateGroupRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *UpdateGroupMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected UpdateGroup to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *UpdateGroupMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected UpdateGroup not to be called but it was called %d times", n)
+	}
+}
+
+// OnGetUniverse returns the mock of the GetUniverse RPC
+func (m *MockService) OnGetUniverse() *GetUniverseMock {
+	return m.getUniverse
+}
+
+// GetUniverse records the request and returns the programmed response
+func (m *MockService) GetUniverse(ctx context.Context, body *GetUniverseRequest) *GetUniverseFuture {
+	handler := m.getUniverse.record(body)
+
+	done := make(chan struct{})
+	ftr := &GetUniverseFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// GetUniverseMock programs and records calls to the GetUniverse RPC
+type GetUniverseMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *GetUniverseRequest) (*UniverseResponse, error)
+	requests []*GetUniverseRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *GetUniverseMock) Returns(rsp *UniverseResponse, err error) *GetUniverseMock {
+	return m.Handle(func(context.Context, *GetUniverseRequest) (*UniverseResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *GetUniverseMock) Handle(fn func(ctx context.Context, body *GetUniverseRequest) (*UniverseResponse, error)) *GetUniverseMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *GetUniverseMock) record(body *GetUniverseRequest) func(context.Context, *GetUniverseRequest) (*UniverseResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *GetUniverseRequest) (*UniverseResponse, error) {
+			return nil, oops.InternalService("no response programmed for GetUniverse")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *GetUniverseMock) Requests() []*GetUniverseRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*GetUniverseRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *GetUniverseMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected GetUniverse to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *GetUniverseMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected GetUniverse not to be called but it was called %d times", n)
+	}
+}
+
+// OnUpdateUniverse returns the mock of the UpdateUniverse RPC
+func (m *MockService) OnUpdateUniverse() *UpdateUniverseMock {
+	return m.updateUniverse
+}
+
+// UpdateUniverse records the request and returns the programmed response
+func (m *MockService) UpdateUniverse(ctx context.Context, body *UpdateUniverseRequest) *UpdateUniverseFuture {
+	handler := m.updateUniverse.record(body)
+
+	done := make(chan struct{})
+	ftr := &UpdateUniverseFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// UpdateUniverseMock programs and records calls to the UpdateUniverse RPC
+type UpdateUniverseMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *UpdateUniverseRequest) (*UniverseResponse, error)
+	requests []*UpdateUniverseRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *UpdateUniverseMock) Returns(rsp *UniverseResponse, err error) *UpdateUniverseMock {
+	return m.Handle(func(context.Context, *UpdateUniverseRequest) (*UniverseResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *UpdateUniverseMock) Handle(fn func(ctx context.Context, body *UpdateUniverseRequest) (*UniverseResponse, error)) *UpdateUniverseMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *UpdateUniverseMock) record(body *UpdateUniverseRequest) func(context.Context, *UpdateUniverseRequest) (*UniverseResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *UpdateUniverseRequest) (*UniverseResponse, error) {
+			return nil, oops.InternalService("no response programmed for UpdateUniverse")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *UpdateUniverseMock) Requests() []*UpdateUniverseRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*UpdateUniverseRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *UpdateUniverseMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected UpdateUniverse to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *UpdateUniverseMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected UpdateUniverse not to be called but it was called %d times", n)
+	}
+}
@@ -3,11 +3,139 @@
 package dmxdef
 
 import (
+	json "encoding/json"
+	utf8 "unicode/utf8"
+
 	def "github.com/jakewright/home-automation/libraries/go/device/def"
 	oops "github.com/jakewright/home-automation/libraries/go/oops"
 	util "github.com/jakewright/home-automation/libraries/go/util"
 )
 
+// Easing is defined in the .def file
+type Easing string
+
+// Values of Easing
+const (
+	Easing_LINEAR      Easing = "LINEAR"
+	Easing_EASE_IN     Easing = "EASE_IN"
+	Easing_EASE_OUT    Easing = "EASE_OUT"
+	Easing_EASE_IN_OUT Easing = "EASE_IN_OUT"
+)
+
+// Validate returns an error if the value is not one of the values of Easing
+func (e Easing) Validate() error {
+	switch e {
+	case Easing_LINEAR, Easing_EASE_IN, Easing_EASE_OUT, Easing_EASE_IN_OUT:
+		return nil
+	}
+
+	return oops.BadRequest("%q is not a valid Easing", string(e))
+}
+
+// MarshalJSON returns an error if the value is not one of the values of Easing
+func (e Easing) MarshalJSON() ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON returns an error if the value is not one of the values of Easing
+func (e *Easing) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if err := Easing(s).Validate(); err != nil {
+		return err
+	}
+
+	*e = Easing(s)
+	return nil
+}
+
+// prefixFieldPath returns a copy of an error returned by the Validate
+// function of a nested message with the path of the nested field
+// prepended to the field path in the error's metadata
+func prefixFieldPath(err error, path string) error {
+	e, ok := err.(*oops.Error)
+	if !ok {
+		return err
+	}
+
+	metadata := map[string]string{}
+	for k, v := range e.GetMetadata() {
+		metadata[k] = v
+	}
+
+	if field, ok := metadata["field"]; ok {
+		path += "." + field
+	}
+	metadata["field"] = path
+
+	// Validate functions only return bad request errors
+	return oops.BadRequest("%s", e.GetMessage(), metadata)
+}
+
+// Transition is defined in the .def file
+type Transition struct {
+	DurationMs *uint32 `json:"duration_ms,omitempty"`
+	Easing     *Easing `json:"easing,omitempty"`
+}
+
+// GetDurationMs returns the de-referenced value of DurationMs.
+// The second return value states whether the field was set.
+func (m *Transition) GetDurationMs() (val uint32, set bool) {
+	if m.DurationMs == nil {
+		return
+	}
+
+	return *m.DurationMs, true
+}
+
+// SetDurationMs sets the value of DurationMs
+func (m *Transition) SetDurationMs(v uint32) *Transition {
+	m.DurationMs = &v
+	return m
+}
+
+// GetEasing returns the de-referenced value of Easing.
+// The second return value states whether the field was set.
+func (m *Transition) GetEasing() (val Easing, set bool) {
+	if m.Easing == nil {
+		return
+	}
+
+	return *m.Easing, true
+}
+
+// SetEasing sets the value of Easing
+func (m *Transition) SetEasing(v Easing) *Transition {
+	m.Easing = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *Transition) Validate() error {
+	if m.DurationMs != nil && *m.DurationMs > 600000 {
+		return oops.BadRequest("field 'duration_ms' should be ≤ 600000", map[string]string{
+			"field": "duration_ms",
+		})
+	}
+	if m.Easing != nil {
+		if err := m.Easing.Validate(); err != nil {
+			return oops.WithMessage(err, "invalid value in field 'easing'", map[string]string{
+				"field": "easing",
+			})
+		}
+	}
+
+	return nil
+}
+
 // MegaParProfileState is defined in the .def file
 type MegaParProfileState struct {
 	Power      *bool     `json:"power,omitempty"`
@@ -80,7 +208,8 @@ func (m *MegaParProfileState) SetStrobe(v byte) *MegaParProfileState {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *MegaParProfileState) Validate() error {
 	return nil
 }
@@ -106,18 +235,28 @@ func (m *GetMegaParProfileRequest) SetDeviceId(v string) *GetMegaParProfileReque
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *GetMegaParProfileRequest) Validate() error {
 	if m.DeviceId == nil {
-		return oops.BadRequest("field 'device_id' is required")
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
+	}
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
 	}
+
 	return nil
 }
 
 // UpdateMegaParProfileRequest is defined in the .def file
 type UpdateMegaParProfileRequest struct {
-	DeviceId *string              `json:"device_id,omitempty"`
-	State    *MegaParProfileState `json:"state,omitempty"`
+	DeviceId   *string              `json:"device_id,omitempty"`
+	State      *MegaParProfileState `json:"state,omitempty"`
+	Transition *Transition          `json:"transition,omitempty"`
 }
 
 // GetDeviceId returns the de-referenced value of DeviceId.
@@ -152,13 +291,46 @@ func (m *UpdateMegaParProfileRequest) SetState(v MegaParProfileState) *UpdateMeg
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// GetTransition returns the de-referenced value of Transition.
+// The second return value states whether the field was set.
+func (m *UpdateMegaParProfileRequest) GetTransition() (val Transition, set bool) {
+	if m.Transition == nil {
+		return
+	}
+
+	return *m.Transition, true
+}
+
+// SetTransition sets the value of Transition
+func (m *UpdateMegaParProfileRequest) SetTransition(v Transition) *UpdateMegaParProfileRequest {
+	m.Transition = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *UpdateMegaParProfileRequest) Validate() error {
 	if m.DeviceId == nil {
-		return oops.BadRequest("field 'device_id' is required")
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
 	}
-	if err := m.State.Validate(); err != nil {
-		return err
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
+	}
+
+	if m.State != nil {
+		if err := m.State.Validate(); err != nil {
+			return prefixFieldPath(err, "state")
+		}
+	}
+
+	if m.Transition != nil {
+		if err := m.Transition.Validate(); err != nil {
+			return prefixFieldPath(err, "transition")
+		}
 	}
 
 	return nil
@@ -219,15 +391,575 @@ func (m *MegaParProfileResponse) SetState(v MegaParProfileState) *MegaParProfile
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *MegaParProfileResponse) Validate() error {
-	if err := m.Header.Validate(); err != nil {
-		return err
+	if m.Header != nil {
+		if err := m.Header.Validate(); err != nil {
+			return prefixFieldPath(err, "header")
+		}
 	}
 
-	if err := m.State.Validate(); err != nil {
-		return err
+	if m.State != nil {
+		if err := m.State.Validate(); err != nil {
+			return prefixFieldPath(err, "state")
+		}
+	}
+
+	return nil
+}
+
+// GetFixtureRequest is defined in the .def file
+type GetFixtureRequest struct {
+	DeviceId *string `json:"device_id,omitempty"`
+}
+
+// GetDeviceId returns the de-referenced value of DeviceId.
+// If the field is nil, the function panics because device_id is marked as required.
+func (m *GetFixtureRequest) GetDeviceId() (val string) {
+	if m.DeviceId == nil {
+		panic("device_id marked as required but was not set. This should have been caught by the validate function.")
+	}
+
+	return *m.DeviceId
+}
+
+// SetDeviceId sets the value of DeviceId
+func (m *GetFixtureRequest) SetDeviceId(v string) *GetFixtureRequest {
+	m.DeviceId = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *GetFixtureRequest) Validate() error {
+	if m.DeviceId == nil {
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
+	}
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
+	}
+
+	return nil
+}
+
+// UpdateFixtureRequest is defined in the .def file
+type UpdateFixtureRequest struct {
+	DeviceId   *string                `json:"device_id,omitempty"`
+	State      map[string]interface{} `json:"state,omitempty"`
+	Transition *Transition            `json:"transition,omitempty"`
+}
+
+// GetDeviceId returns the de-referenced value of DeviceId.
+// If the field is nil, the function panics because device_id is marked as required.
+func (m *UpdateFixtureRequest) GetDeviceId() (val string) {
+	if m.DeviceId == nil {
+		panic("device_id marked as required but was not set. This should have been caught by the validate function.")
+	}
+
+	return *m.DeviceId
+}
+
+// SetDeviceId sets the value of DeviceId
+func (m *UpdateFixtureRequest) SetDeviceId(v string) *UpdateFixtureRequest {
+	m.DeviceId = &v
+	return m
+}
+
+// GetState returns the de-referenced value of State.
+// The second return value states whether the field was set.
+func (m *UpdateFixtureRequest) GetState() (val map[string]interface{}, set bool) {
+	if m.State == nil {
+		return
+	}
+
+	return m.State, true
+}
+
+// SetState sets the value of State
+func (m *UpdateFixtureRequest) SetState(v map[string]interface{}) *UpdateFixtureRequest {
+	m.State = v
+	return m
+}
+
+// GetTransition returns the de-referenced value of Transition.
+// The second return value states whether the field was set.
+func (m *UpdateFixtureRequest) GetTransition() (val Transition, set bool) {
+	if m.Transition == nil {
+		return
+	}
+
+	return *m.Transition, true
+}
+
+// SetTransition sets the value of Transition
+func (m *UpdateFixtureRequest) SetTransition(v Transition) *UpdateFixtureRequest {
+	m.Transition = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *UpdateFixtureRequest) Validate() error {
+	if m.DeviceId == nil {
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
+	}
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
+	}
+
+	if m.Transition != nil {
+		if err := m.Transition.Validate(); err != nil {
+			return prefixFieldPath(err, "transition")
+		}
+	}
+
+	return nil
+}
+
+// FixtureResponse is defined in the .def file
+type FixtureResponse struct {
+	Header     *def.Header              `json:"header,omitempty"`
+	Properties map[string]*def.Property `json:"properties,omitempty"`
+	State      map[string]interface{}   `json:"state,omitempty"`
+}
+
+// GetHeader returns the de-referenced value of Header.
+// The second return value states whether the field was set.
+func (m *FixtureResponse) GetHeader() (val def.Header, set bool) {
+	if m.Header == nil {
+		return
+	}
+
+	return *m.Header, true
+}
+
+// SetHeader sets the value of Header
+func (m *FixtureResponse) SetHeader(v def.Header) *FixtureResponse {
+	m.Header = &v
+	return m
+}
+
+// GetProperties returns the de-referenced value of Properties.
+// The second return value states whether the field was set.
+func (m *FixtureResponse) GetProperties() (val map[string]*def.Property, set bool) {
+	if m.Properties == nil {
+		return
+	}
+
+	return m.Properties, true
+}
+
+// SetProperties sets the value of Properties
+func (m *FixtureResponse) SetProperties(v map[string]*def.Property) *FixtureResponse {
+	m.Properties = v
+	return m
+}
+
+// GetState returns the de-referenced value of State.
+// The second return value states whether the field was set.
+func (m *FixtureResponse) GetState() (val map[string]interface{}, set bool) {
+	if m.State == nil {
+		return
+	}
+
+	return m.State, true
+}
+
+// SetState sets the value of State
+func (m *FixtureResponse) SetState(v map[string]interface{}) *FixtureResponse {
+	m.State = v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *FixtureResponse) Validate() error {
+	if m.Header != nil {
+		if err := m.Header.Validate(); err != nil {
+			return prefixFieldPath(err, "header")
+		}
+	}
+
+	return nil
+}
+
+// GetGroupRequest is defined in the .def file
+type GetGroupRequest struct {
+	GroupId *string `json:"group_id,omitempty"`
+}
+
+// GetGroupId returns the de-referenced value of GroupId.
+// If the field is nil, the function panics because group_id is marked as required.
+func (m *GetGroupRequest) GetGroupId() (val string) {
+	if m.GroupId == nil {
+		panic("group_id marked as required but was not set. This should have been caught by the validate function.")
+	}
+
+	return *m.GroupId
+}
+
+// SetGroupId sets the value of GroupId
+func (m *GetGroupRequest) SetGroupId(v string) *GetGroupRequest {
+	m.GroupId = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *GetGroupRequest) Validate() error {
+	if m.GroupId == nil {
+		return oops.BadRequest("field 'group_id' is required", map[string]string{
+			"field": "group_id",
+		})
+	}
+	if m.GroupId != nil && utf8.RuneCountInString(*m.GroupId) < 1 {
+		return oops.BadRequest("field 'group_id' should have length ≥ 1", map[string]string{
+			"field": "group_id",
+		})
+	}
+
+	return nil
+}
+
+// UpdateGroupRequest is defined in the .def file
+type UpdateGroupRequest struct {
+	GroupId    *string                `json:"group_id,omitempty"`
+	State      map[string]interface{} `json:"state,omitempty"`
+	Master     *uint32                `json:"master,omitempty"`
+	Transition *Transition            `json:"transition,omitempty"`
+}
+
+// GetGroupId returns the de-referenced value of GroupId.
+// If the field is nil, the function panics because group_id is marked as required.
+func (m *UpdateGroupRequest) GetGroupId() (val string) {
+	if m.GroupId == nil {
+		panic("group_id marked as required but was not set. This should have been caught by the validate function.")
 	}
 
+	return *m.GroupId
+}
+
+// SetGroupId sets the value of GroupId
+func (m *UpdateGroupRequest) SetGroupId(v string) *UpdateGroupRequest {
+	m.GroupId = &v
+	return m
+}
+
+// GetState returns the de-referenced value of State.
+// The second return value states whether the field was set.
+func (m *UpdateGroupRequest) GetState() (val map[string]interface{}, set bool) {
+	if m.State == nil {
+		return
+	}
+
+	return m.State, true
+}
+
+// SetState sets the value of State
+func (m *UpdateGroupRequest) SetState(v map[string]interface{}) *UpdateGroupRequest {
+	m.State = v
+	return m
+}
+
+// GetMaster returns the de-referenced value of Master.
+// The second return value states whether the field was set.
+func (m *UpdateGroupRequest) GetMaster() (val uint32, set bool) {
+	if m.Master == nil {
+		return
+	}
+
+	return *m.Master, true
+}
+
+// SetMaster sets the value of Master
+func (m *UpdateGroupRequest) SetMaster(v uint32) *UpdateGroupRequest {
+	m.Master = &v
+	return m
+}
+
+// GetTransition returns the de-referenced value of Transition.
+// The second return value states whether the field was set.
+func (m *UpdateGroupRequest) GetTransition() (val Transition, set bool) {
+	if m.Transition == nil {
+		return
+	}
+
+	return *m.Transition, true
+}
+
+// SetTransition sets the value of Transition
+func (m *UpdateGroupRequest) SetTransition(v Transition) *UpdateGroupRequest {
+	m.Transition = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *UpdateGroupRequest) Validate() error {
+	if m.GroupId == nil {
+		return oops.BadRequest("field 'group_id' is required", map[string]string{
+			"field": "group_id",
+		})
+	}
+	if m.GroupId != nil && utf8.RuneCountInString(*m.GroupId) < 1 {
+		return oops.BadRequest("field 'group_id' should have length ≥ 1", map[string]string{
+			"field": "group_id",
+		})
+	}
+
+	if m.Master != nil && *m.Master > 100 {
+		return oops.BadRequest("field 'master' should be ≤ 100", map[string]string{
+			"field": "master",
+		})
+	}
+	if m.Transition != nil {
+		if err := m.Transition.Validate(); err != nil {
+			return prefixFieldPath(err, "transition")
+		}
+	}
+
+	return nil
+}
+
+// GroupResponse is defined in the .def file
+type GroupResponse struct {
+	Id        *string  `json:"id,omitempty"`
+	DeviceIds []string `json:"device_ids,omitempty"`
+	Master    *uint32  `json:"master,omitempty"`
+}
+
+// GetId returns the de-referenced value of Id.
+// The second return value states whether the field was set.
+func (m *GroupResponse) GetId() (val string, set bool) {
+	if m.Id == nil {
+		return
+	}
+
+	return *m.Id, true
+}
+
+// SetId sets the value of Id
+func (m *GroupResponse) SetId(v string) *GroupResponse {
+	m.Id = &v
+	return m
+}
+
+// GetDeviceIds returns the de-referenced value of DeviceIds.
+// The second return value states whether the field was set.
+func (m *GroupResponse) GetDeviceIds() (val []string, set bool) {
+	if m.DeviceIds == nil {
+		return
+	}
+
+	return m.DeviceIds, true
+}
+
+// SetDeviceIds sets the value of DeviceIds
+func (m *GroupResponse) SetDeviceIds(v []string) *GroupResponse {
+	m.DeviceIds = v
+	return m
+}
+
+// GetMaster returns the de-referenced value of Master.
+// The second return value states whether the field was set.
+func (m *GroupResponse) GetMaster() (val uint32, set bool) {
+	if m.Master == nil {
+		return
+	}
+
+	return *m.Master, true
+}
+
+// SetMaster sets the value of Master
+func (m *GroupResponse) SetMaster(v uint32) *GroupResponse {
+	m.Master = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *GroupResponse) Validate() error {
+	return nil
+}
+
+// GetUniverseRequest is defined in the .def file
+type GetUniverseRequest struct {
+	UniverseNumber *uint32 `json:"universe_number,omitempty"`
+}
+
+// GetUniverseNumber returns the de-referenced value of UniverseNumber.
+// If the field is nil, the function panics because universe_number is marked as required.
+func (m *GetUniverseRequest) GetUniverseNumber() (val uint32) {
+	if m.UniverseNumber == nil {
+		panic("universe_number marked as required but was not set. This should have been caught by the validate function.")
+	}
+
+	return *m.UniverseNumber
+}
+
+// SetUniverseNumber sets the value of UniverseNumber
+func (m *GetUniverseRequest) SetUniverseNumber(v uint32) *GetUniverseRequest {
+	m.UniverseNumber = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *GetUniverseRequest) Validate() error {
+	if m.UniverseNumber == nil {
+		return oops.BadRequest("field 'universe_number' is required", map[string]string{
+			"field": "universe_number",
+		})
+	}
+	if m.UniverseNumber != nil && *m.UniverseNumber < 1 {
+		return oops.BadRequest("field 'universe_number' should be ≥ 1", map[string]string{
+			"field": "universe_number",
+		})
+	}
+	if m.UniverseNumber != nil && *m.UniverseNumber > 65535 {
+		return oops.BadRequest("field 'universe_number' should be ≤ 65535", map[string]string{
+			"field": "universe_number",
+		})
+	}
+	return nil
+}
+
+// UpdateUniverseRequest is defined in the .def file
+type UpdateUniverseRequest struct {
+	UniverseNumber *uint32     `json:"universe_number,omitempty"`
+	Master         *uint32     `json:"master,omitempty"`
+	Transition     *Transition `json:"transition,omitempty"`
+}
+
+// GetUniverseNumber returns the de-referenced value of UniverseNumber.
+// If the field is nil, the function panics because universe_number is marked as required.
+func (m *UpdateUniverseRequest) GetUniverseNumber() (val uint32) {
+	if m.UniverseNumber == nil {
+		panic("universe_number marked as required but was not set. This should have been caught by the validate function.")
+	}
+
+	return *m.UniverseNumber
+}
+
+// SetUniverseNumber sets the value of UniverseNumber
+func (m *UpdateUniverseRequest) SetUniverseNumber(v uint32) *UpdateUniverseRequest {
+	m.UniverseNumber = &v
+	return m
+}
+
+// GetMaster returns the de-referenced value of Master.
+// The second return value states whether the field was set.
+func (m *UpdateUniverseRequest) GetMaster() (val uint32, set bool) {
+	if m.Master == nil {
+		return
+	}
+
+	return *m.Master, true
+}
+
+// SetMaster sets the value of Master
+func (m *UpdateUniverseRequest) SetMaster(v uint32) *UpdateUniverseRequest {
+	m.Master = &v
+	return m
+}
+
+// GetTransition returns the de-referenced value of Transition.
+// The second return value states whether the field was set.
+func (m *UpdateUniverseRequest) GetTransition() (val Transition, set bool) {
+	if m.Transition == nil {
+		return
+	}
+
+	return *m.Transition, true
+}
+
+// SetTransition sets the value of Transition
+func (m *UpdateUniverseRequest) SetTransition(v Transition) *UpdateUniverseRequest {
+	m.Transition = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *UpdateUniverseRequest) Validate() error {
+	if m.UniverseNumber == nil {
+		return oops.BadRequest("field 'universe_number' is required", map[string]string{
+			"field": "universe_number",
+		})
+	}
+	if m.UniverseNumber != nil && *m.UniverseNumber < 1 {
+		return oops.BadRequest("field 'universe_number' should be ≥ 1", map[string]string{
+			"field": "universe_number",
+		})
+	}
+	if m.UniverseNumber != nil && *m.UniverseNumber > 65535 {
+		return oops.BadRequest("field 'universe_number' should be ≤ 65535", map[string]string{
+			"field": "universe_number",
+		})
+	}
+	if m.Master != nil && *m.Master > 100 {
+		return oops.BadRequest("field 'master' should be ≤ 100", map[string]string{
+			"field": "master",
+		})
+	}
+	if m.Transition != nil {
+		if err := m.Transition.Validate(); err != nil {
+			return prefixFieldPath(err, "transition")
+		}
+	}
+
+	return nil
+}
+
+// UniverseResponse is defined in the .def file
+type UniverseResponse struct {
+	UniverseNumber *uint32 `json:"universe_number,omitempty"`
+	Master         *uint32 `json:"master,omitempty"`
+}
+
+// GetUniverseNumber returns the de-referenced value of UniverseNumber.
+// The second return value states whether the field was set.
+func (m *UniverseResponse) GetUniverseNumber() (val uint32, set bool) {
+	if m.UniverseNumber == nil {
+		return
+	}
+
+	return *m.UniverseNumber, true
+}
+
+// SetUniverseNumber sets the value of UniverseNumber
+func (m *UniverseResponse) SetUniverseNumber(v uint32) *UniverseResponse {
+	m.UniverseNumber = &v
+	return m
+}
+
+// GetMaster returns the de-referenced value of Master.
+// The second return value states whether the field was set.
+func (m *UniverseResponse) GetMaster() (val uint32, set bool) {
+	if m.Master == nil {
+		return
+	}
+
+	return *m.Master, true
+}
+
+// SetMaster sets the value of Master
+func (m *UniverseResponse) SetMaster(v uint32) *UniverseResponse {
+	m.Master = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *UniverseResponse) Validate() error {
 	return nil
 }
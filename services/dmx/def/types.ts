@@ -0,0 +1,100 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import * as device from "../../../libraries/go/device/def/types";
+
+// Easing is defined in the .def file
+export type Easing =
+  | "LINEAR"
+  | "EASE_IN"
+  | "EASE_OUT"
+  | "EASE_IN_OUT";
+
+// Transition is defined in the .def file
+export interface Transition {
+  duration_ms?: number;
+  easing?: Easing;
+}
+
+// MegaParProfileState is defined in the .def file
+export interface MegaParProfileState {
+  power?: boolean;
+  brightness?: number;
+  color?: string;
+  strobe?: number;
+}
+
+// GetMegaParProfileRequest is defined in the .def file
+export interface GetMegaParProfileRequest {
+  device_id: string;
+}
+
+// UpdateMegaParProfileRequest is defined in the .def file
+export interface UpdateMegaParProfileRequest {
+  device_id: string;
+  state?: MegaParProfileState;
+  transition?: Transition;
+}
+
+// MegaParProfileResponse is defined in the .def file
+export interface MegaParProfileResponse {
+  header?: device.Header;
+  properties?: { [key: string]: device.Property };
+  state?: MegaParProfileState;
+}
+
+// GetFixtureRequest is defined in the .def file
+export interface GetFixtureRequest {
+  device_id: string;
+}
+
+// UpdateFixtureRequest is defined in the .def file
+export interface UpdateFixtureRequest {
+  device_id: string;
+  state?: { [key: string]: any };
+  transition?: Transition;
+}
+
+// FixtureResponse is defined in the .def file
+export interface FixtureResponse {
+  header?: device.Header;
+  properties?: { [key: string]: device.Property };
+  state?: { [key: string]: any };
+}
+
+// GetGroupRequest is defined in the .def file
+export interface GetGroupRequest {
+  group_id: string;
+}
+
+// UpdateGroupRequest is defined in the .def file
+export interface UpdateGroupRequest {
+  group_id: string;
+  state?: { [key: string]: any };
+  master?: number;
+  transition?: Transition;
+}
+
+// GroupResponse is defined in the .def file
+export interface GroupResponse {
+  id?: string;
+  device_ids?: string[];
+  master?: number;
+}
+
+// GetUniverseRequest is defined in the .def file
+export interface GetUniverseRequest {
+  universe_number: number;
+}
+
+// UpdateUniverseRequest is defined in the .def file
+export interface UpdateUniverseRequest {
+  universe_number: number;
+  master?: number;
+  transition?: Transition;
+}
+
+// UniverseResponse is defined in the .def file
+export interface UniverseResponse {
+  universe_number?: number;
+  master?: number;
+}
@@ -0,0 +1,87 @@
+package dmx
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+const (
+	artNetPort         = "6454"
+	artNetOpDMX        = 0x5000
+	artNetProtocolVer  = 14
+	artNetHeaderLength = 18
+)
+
+var artNetID = []byte("Art-Net\x00")
+
+// ArtNetOptions configure an ArtNetClient
+type ArtNetOptions struct {
+	// Address is the host of the Art-Net node, with
+	// an optional port. The default port is 6454.
+	Address string
+
+	// Universe is the 15 bit Port-Address of the universe on
+	// the node, made up of the net, sub-net and universe.
+	Universe uint16
+
+	// RefreshInterval is how often the values are resent. If
+	// zero, DefaultRefreshInterval is used.
+	RefreshInterval time.Duration
+}
+
+// ArtNetClient sends DMX values to an Art-Net node using ArtDmx packets.
+// It must be started as a process so that the values are refreshed.
+// https://art-net.org.uk/resources/art-net-specification/
+type ArtNetClient struct {
+	*udpSender
+	universe uint16
+	sequence byte
+}
+
+// Compile-time assertion that ArtNetClient implements the GetSetter interface
+var _ GetSetter = (*ArtNetClient)(nil)
+
+// NewArtNetClient returns a client that sends ArtDmx packets to the node
+func NewArtNetClient(opts *ArtNetOptions) (*ArtNetClient, error) {
+	if opts.Address == "" {
+		return nil, oops.InternalService("Art-Net node address not set")
+	}
+
+	if opts.Universe > 0x7FFF {
+		return nil, oops.InternalService("Art-Net Port-Address %d is greater than 32767", opts.Universe)
+	}
+
+	c := &ArtNetClient{universe: opts.Universe}
+
+	s, err := newUDPSender("Art-Net", withDefaultPort(opts.Address, artNetPort), opts.RefreshInterval, c.packet)
+	if err != nil {
+		return nil, err
+	}
+
+	c.udpSender = s
+	return c, nil
+}
+
+// packet returns an ArtDmx packet. The sequence number
+// goes from 1 to 255 because 0 disables sequencing.
+func (c *ArtNetClient) packet(values [512]byte) []byte {
+	c.sequence++
+	if c.sequence == 0 {
+		c.sequence = 1
+	}
+
+	p := make([]byte, artNetHeaderLength+len(values))
+	copy(p, artNetID)
+	binary.LittleEndian.PutUint16(p[8:], artNetOpDMX)
+	binary.BigEndian.PutUint16(p[10:], artNetProtocolVer)
+	p[12] = c.sequence
+	p[13] = 0                     // Physical input port, informational only
+	p[14] = byte(c.universe)      // SubUni: sub-net and universe
+	p[15] = byte(c.universe >> 8) // Net
+	binary.BigEndian.PutUint16(p[16:], uint16(len(values)))
+	copy(p[artNetHeaderLength:], values[:])
+
+	return p
+}
@@ -2,28 +2,71 @@ package dmx
 
 import (
 	"context"
+	"math"
 	"sync"
+	"time"
 
+	"github.com/jakewright/home-automation/libraries/go/device"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/trace"
 	"github.com/jakewright/home-automation/services/dmx/domain"
 )
 
+// frameTimeout is the maximum time that sending a frame can take
+const frameTimeout = 10 * time.Second
+
 // GetSetter is an interface for interacting with a DMX universe
 type GetSetter interface {
 	GetValues(ctx context.Context) ([512]byte, error)
 	SetValues(ctx context.Context, values [512]byte) error
 }
 
-// Client can get and set DMX values for all universes
+// fader is implemented by GetSetters that support transitions
+type fader interface {
+	Fade(ctx context.Context, values [512]byte, interpolations [512]string, t *Transition) error
+}
+
+// Client can get and set DMX values for all universes. The values of
+// each universe are kept in memory so that they are only read from the
+// GetSetter once. Writes to a universe are serialised, and writes that
+// are made while a frame is being sent are coalesced into the next frame.
 type Client struct {
-	getSetters map[domain.UniverseNumber]GetSetter
-	mu         *sync.Mutex
+	universes map[domain.UniverseNumber]*universe
+	mu        *sync.Mutex
+}
+
+// universe holds the authoritative values of a universe
+type universe struct {
+	gs     GetSetter
+	loaded bool
+	values [512]byte
+
+	// scale is the factor by which each
+	// channel is scaled when it is sent
+	scale [512]float64
+
+	// next is the frame that new writes are added
+	// to. It is only set while a frame is being sent.
+	next    *frame
+	sending bool
+
+	mu sync.Mutex
+}
+
+// frame is a send of the universe's values. The
+// writes that are included in it wait until it is done.
+type frame struct {
+	interpolations [512]string
+	transition     *Transition
+	done           chan struct{}
+	err            error
 }
 
 // NewClient returns a new client
 func NewClient() *Client {
 	return &Client{
-		getSetters: make(map[domain.UniverseNumber]GetSetter),
-		mu:         &sync.Mutex{},
+		universes: make(map[domain.UniverseNumber]*universe),
+		mu:        &sync.Mutex{},
 	}
 }
 
@@ -31,21 +74,205 @@ func NewClient() *Client {
 func (c *Client) AddGetSetter(un domain.UniverseNumber, gs GetSetter) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.getSetters[un] = gs
+
+	u := &universe{gs: gs}
+	for i := range u.scale {
+		u.scale[i] = 1
+	}
+
+	c.universes[un] = u
 }
 
-// GetValues returns the DMX values for the specified universe
-func (c *Client) GetValues(ctx context.Context, un domain.UniverseNumber) ([512]byte, error) {
+func (c *Client) universe(un domain.UniverseNumber) (*universe, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	return c.getSetters[un].GetValues(ctx)
+	u, ok := c.universes[un]
+	if !ok {
+		return nil, oops.InternalService("universe %d has not been configured", un)
+	}
+
+	return u, nil
 }
 
-// SetValues sets the DMX values for the specified universe
+// GetValues returns the DMX values for the specified universe
+func (c *Client) GetValues(ctx context.Context, un domain.UniverseNumber) ([512]byte, error) {
+	u, err := c.universe(un)
+	if err != nil {
+		return [512]byte{}, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err := u.load(ctx); err != nil {
+		return [512]byte{}, err
+	}
+
+	return u.values, nil
+}
+
+// SetValues sets all of the DMX values for the specified universe
 func (c *Client) SetValues(ctx context.Context, un domain.UniverseNumber, values [512]byte) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	var interpolations [512]string
+	for i := range interpolations {
+		interpolations[i] = device.InterpolationDiscrete
+	}
+
+	return c.Fade(ctx, un, values, interpolations, nil)
+}
 
-	return c.getSetters[un].SetValues(ctx, values)
+// Fade changes the values of the channels that have an interpolation
+// using the transition. Other channels keep their current values. If
+// the universe's GetSetter doesn't support transitions, the values
+// are set immediately.
+func (c *Client) Fade(ctx context.Context, un domain.UniverseNumber, values [512]byte, interpolations [512]string, t *Transition) error {
+	u, err := c.universe(un)
+	if err != nil {
+		return err
+	}
+
+	return u.write(ctx, t, func(f *frame) {
+		for i, interpolation := range interpolations {
+			if interpolation == "" {
+				continue
+			}
+
+			u.values[i] = values[i]
+			f.interpolations[i] = interpolation
+		}
+	})
+}
+
+// SetScale sets the factor, from 0 to 1, by which the value of each
+// channel is scaled when it is sent. GetValues returns the values
+// before they are scaled. Channels whose scale changes are faded
+// using the transition.
+func (c *Client) SetScale(ctx context.Context, un domain.UniverseNumber, scale [512]float64, t *Transition) error {
+	u, err := c.universe(un)
+	if err != nil {
+		return err
+	}
+
+	return u.write(ctx, t, func(f *frame) {
+		for i := range scale {
+			if scale[i] == u.scale[i] {
+				continue
+			}
+
+			u.scale[i] = scale[i]
+			if f.interpolations[i] == "" {
+				f.interpolations[i] = device.InterpolationContinuous
+			}
+		}
+	})
+}
+
+// load reads the initial values from the
+// GetSetter. The lock must be held.
+func (u *universe) load(ctx context.Context) error {
+	if u.loaded {
+		return nil
+	}
+
+	values, err := u.gs.GetValues(ctx)
+	if err != nil {
+		return oops.WithMessage(err, "failed to get DMX values")
+	}
+
+	u.values, u.loaded = values, true
+	return nil
+}
+
+// write waits until the frame that includes the changes made by apply
+// has been sent. If no frame is being sent, the write sends frames until
+// there are no more writes waiting. Otherwise, the changes are added to
+// the next frame. The lock is held while apply is called. Once the
+// changes have been applied, the write waits for the frame even if
+// ctx is cancelled, so that the error reflects whether they were sent.
+func (u *universe) write(ctx context.Context, t *Transition, apply func(f *frame)) error {
+	u.mu.Lock()
+
+	if err := u.load(ctx); err != nil {
+		u.mu.Unlock()
+		return err
+	}
+
+	// A frame can only include writes with the same transition
+	for u.next != nil && !u.next.transition.equal(t) {
+		f := u.next
+		u.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		u.mu.Lock()
+	}
+
+	if u.next == nil {
+		u.next = &frame{
+			transition: t,
+			done:       make(chan struct{}),
+		}
+	}
+
+	f := u.next
+	apply(f)
+
+	send := !u.sending
+	u.sending = true
+	u.mu.Unlock()
+
+	if send {
+		u.send(trace.ID(ctx))
+	}
+
+	<-f.done
+	return f.err
+}
+
+// output returns the values scaled by the
+// channels' scales. The lock must be held.
+func (u *universe) output() [512]byte {
+	output := u.values
+	for i, scale := range u.scale {
+		if scale != 1 {
+			output[i] = byte(math.Round(float64(output[i]) * scale))
+		}
+	}
+	return output
+}
+
+// send sends frames until there are no more writes waiting. The
+// frames include other writes, so they are not sent with the
+// context of the write that started sending. Instead, each frame
+// has its own timeout and carries the write's trace ID. The lock
+// must not be held.
+func (u *universe) send(traceID string) {
+	for {
+		u.mu.Lock()
+		f := u.next
+		if f == nil {
+			u.sending = false
+			u.mu.Unlock()
+			return
+		}
+
+		u.next = nil
+		values := u.output()
+		u.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(trace.WithID(context.Background(), traceID), frameTimeout)
+		if fd, ok := u.gs.(fader); ok {
+			f.err = fd.Fade(ctx, values, f.interpolations, f.transition)
+		} else {
+			f.err = u.gs.SetValues(ctx, values)
+		}
+		cancel()
+
+		close(f.done)
+	}
 }
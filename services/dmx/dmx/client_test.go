@@ -0,0 +1,168 @@
+package dmx
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jakewright/home-automation/libraries/go/device"
+)
+
+// blockingGetSetter counts requests and blocks
+// SetValues until a value is sent on release
+type blockingGetSetter struct {
+	MockGetSetter
+	gets, sets int
+	ctxErr     error
+	setting    chan struct{}
+	release    chan struct{}
+	mu         sync.Mutex
+}
+
+func (b *blockingGetSetter) GetValues(ctx context.Context) ([512]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gets++
+	return b.MockGetSetter.GetValues(ctx)
+}
+
+func (b *blockingGetSetter) SetValues(ctx context.Context, values [512]byte) error {
+	b.setting <- struct{}{}
+	<-b.release
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sets++
+	b.ctxErr = ctx.Err()
+	return b.MockGetSetter.SetValues(ctx, values)
+}
+
+// channel returns interpolations that only include the channel
+func channel(i int) [512]string {
+	var interpolations [512]string
+	interpolations[i] = device.InterpolationDiscrete
+	return interpolations
+}
+
+func TestClient_GetValues(t *testing.T) {
+	gs := &blockingGetSetter{MockGetSetter: MockGetSetter{Values: [512]byte{0: 10}}}
+	c := NewClient()
+	c.AddGetSetter(1, gs)
+
+	for i := 0; i < 3; i++ {
+		values, err := c.GetValues(context.Background(), 1)
+		require.NoError(t, err)
+		require.Equal(t, byte(10), values[0])
+	}
+
+	// The values are only read once
+	require.Equal(t, 1, gs.gets)
+
+	_, err := c.GetValues(context.Background(), 2)
+	require.Error(t, err)
+}
+
+func TestClient_Fade_coalesce(t *testing.T) {
+	ctx := context.Background()
+	gs := &blockingGetSetter{
+		setting: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	c := NewClient()
+	c.AddGetSetter(1, gs)
+
+	// The first write starts sending a frame
+	errs := make(chan error, 3)
+	go func() { errs <- c.Fade(ctx, 1, [512]byte{0: 1}, channel(0), nil) }()
+	<-gs.setting
+
+	// Writes made while the frame is being sent are coalesced.
+	// Each write only changes the channels it interpolates.
+	go func() { errs <- c.Fade(ctx, 1, [512]byte{1: 2}, channel(1), nil) }()
+	go func() { errs <- c.Fade(ctx, 1, [512]byte{2: 3}, channel(2), nil) }()
+
+	require.Eventually(t, func() bool {
+		values, err := c.GetValues(ctx, 1)
+		return err == nil && values[1] == 2 && values[2] == 3
+	}, time.Second, time.Millisecond)
+
+	gs.release <- struct{}{}
+	<-gs.setting
+	gs.release <- struct{}{}
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	require.Equal(t, 2, gs.sets)
+	require.Equal(t, [3]byte{1, 2, 3}, [3]byte{gs.Values[0], gs.Values[1], gs.Values[2]})
+}
+
+func TestClient_Fade_cancelled(t *testing.T) {
+	gs := &blockingGetSetter{
+		setting: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	c := NewClient()
+	c.AddGetSetter(1, gs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- c.Fade(ctx, 1, [512]byte{0: 1}, channel(0), nil) }()
+	<-gs.setting
+
+	// Cancelling the write doesn't cancel the frame, which
+	// might include other writes, and the write still
+	// waits for the result because its value has been set
+	cancel()
+	gs.release <- struct{}{}
+
+	require.NoError(t, <-errs)
+	require.NoError(t, gs.ctxErr)
+	require.Equal(t, byte(1), gs.Values[0])
+}
+
+func TestClient_SetValues(t *testing.T) {
+	gs := &MockGetSetter{Values: [512]byte{0: 10, 1: 20}}
+	c := NewClient()
+	c.AddGetSetter(1, NewFader(gs, 0))
+
+	require.NoError(t, c.SetValues(context.Background(), 1, [512]byte{1: 30}))
+	require.Equal(t, [2]byte{0, 30}, [2]byte{gs.Values[0], gs.Values[1]})
+}
+
+func TestTransition_equal(t *testing.T) {
+	var nilTransition *Transition
+	require.True(t, nilTransition.equal(nil))
+	require.False(t, nilTransition.equal(&Transition{}))
+	require.True(t, (&Transition{Duration: time.Second}).equal(&Transition{Duration: time.Second, Easing: EaseLinear}))
+	require.False(t, (&Transition{Duration: time.Second}).equal(&Transition{Duration: time.Second, Easing: EaseIn}))
+	require.False(t, (&Transition{Duration: time.Second}).equal(&Transition{Duration: time.Minute}))
+}
+
+func TestClient_SetScale(t *testing.T) {
+	ctx := context.Background()
+	gs := &MockGetSetter{Values: [512]byte{0: 200, 1: 200}}
+	c := NewClient()
+	c.AddGetSetter(1, gs)
+
+	var scale [512]float64
+	for i := range scale {
+		scale[i] = 1
+	}
+	scale[0] = 0.5
+
+	require.NoError(t, c.SetScale(ctx, 1, scale, nil))
+	require.Equal(t, [2]byte{100, 200}, [2]byte{gs.Values[0], gs.Values[1]})
+
+	// The values are returned before they are scaled
+	values, err := c.GetValues(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, byte(200), values[0])
+
+	require.NoError(t, c.Fade(ctx, 1, [512]byte{0: 100}, channel(0), nil))
+	require.Equal(t, byte(50), gs.Values[0])
+}
@@ -0,0 +1,251 @@
+package dmx
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/jakewright/home-automation/libraries/go/device"
+	"github.com/jakewright/home-automation/libraries/go/slog"
+)
+
+// DefaultFrameRate is the number of frames per second that are sent while
+// channels are fading. DMX512 can refresh a full universe at up to 44 Hz.
+const DefaultFrameRate = 40
+
+// Easing maps the fraction of a transition's
+// duration that has elapsed to the fraction
+// of the change in value that should be made
+type Easing func(t float64) float64
+
+// Easing functions
+var (
+	EaseLinear Easing = func(t float64) float64 { return t }
+	EaseIn     Easing = func(t float64) float64 { return t * t }
+	EaseOut    Easing = func(t float64) float64 { return 1 - (1-t)*(1-t) }
+	EaseInOut  Easing = func(t float64) float64 {
+		if t < 0.5 {
+			return 2 * t * t
+		}
+		return 1 - (2-2*t)*(2-2*t)/2
+	}
+)
+
+// Transition describes how channels change to their new values
+type Transition struct {
+	Duration time.Duration
+
+	// Easing defaults to EaseLinear
+	Easing Easing
+}
+
+// equal returns whether the transitions have the same duration and easing
+func (t *Transition) equal(o *Transition) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+
+	return t.Duration == o.Duration && t.easing() == o.easing()
+}
+
+// easing returns a pointer to the easing function
+// so that transitions' easings can be compared
+func (t *Transition) easing() uintptr {
+	if t.Easing == nil {
+		return reflect.ValueOf(EaseLinear).Pointer()
+	}
+	return reflect.ValueOf(t.Easing).Pointer()
+}
+
+type fade struct {
+	from, to byte
+	start    time.Time
+	duration time.Duration
+	easing   Easing
+}
+
+// value returns the value of the channel at the time and
+// whether the fade has finished
+func (f *fade) value(now time.Time) (byte, bool) {
+	t := float64(now.Sub(f.start)) / float64(f.duration)
+	if t >= 1 {
+		return f.to, true
+	}
+	if t < 0 {
+		t = 0
+	}
+
+	v := float64(f.from) + (float64(f.to)-float64(f.from))*f.easing(t)
+	return byte(math.Round(v)), false
+}
+
+// Fader wraps a GetSetter and fades channels to new values. It must be
+// started as a process to send the frames of the fades. GetValues
+// returns the values that the channels are fading to, so that fixtures
+// are hydrated with their target state. Each channel fades separately,
+// so a fade can be retargeted without affecting the other channels.
+type Fader struct {
+	gs       GetSetter
+	interval time.Duration
+	now      func() time.Time
+
+	loaded  bool
+	current [512]byte
+	targets [512]byte
+	fades   map[int]*fade
+	mu      sync.Mutex
+}
+
+// Compile-time assertion that Fader implements the GetSetter interface
+var _ GetSetter = (*Fader)(nil)
+
+// NewFader returns a Fader that sends frames at the frame rate.
+// If the frame rate is zero, DefaultFrameRate is used.
+func NewFader(gs GetSetter, frameRate int) *Fader {
+	if frameRate == 0 {
+		frameRate = DefaultFrameRate
+	}
+
+	return &Fader{
+		gs:       gs,
+		interval: time.Second / time.Duration(frameRate),
+		now:      time.Now,
+		fades:    make(map[int]*fade),
+	}
+}
+
+// load gets the initial values from the
+// GetSetter. The lock must be held.
+func (f *Fader) load(ctx context.Context) error {
+	if f.loaded {
+		return nil
+	}
+
+	values, err := f.gs.GetValues(ctx)
+	if err != nil {
+		return err
+	}
+
+	f.current, f.targets, f.loaded = values, values, true
+	return nil
+}
+
+// GetValues returns the target values of the channels
+func (f *Fader) GetValues(ctx context.Context) ([512]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(ctx); err != nil {
+		return [512]byte{}, err
+	}
+
+	return f.targets, nil
+}
+
+// SetValues immediately sets all channels
+// and cancels any fades that are in progress
+func (f *Fader) SetValues(ctx context.Context, values [512]byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.current, f.targets, f.loaded = values, values, true
+	f.fades = make(map[int]*fade)
+	return f.gs.SetValues(ctx, f.current)
+}
+
+// Fade changes the channels that have an interpolation to their new
+// values. Continuous channels fade from their current value over the
+// transition. Discrete channels change immediately, as do all channels
+// if the transition has no duration. Channels without an interpolation
+// keep their target, and any fade that is in progress.
+func (f *Fader) Fade(ctx context.Context, values [512]byte, interpolations [512]string, t *Transition) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(ctx); err != nil {
+		return err
+	}
+
+	now := f.now()
+	f.step(now)
+
+	easing := EaseLinear
+	if t != nil && t.Easing != nil {
+		easing = t.Easing
+	}
+
+	for i, interpolation := range interpolations {
+		switch {
+		case interpolation == "":
+			continue
+
+		case interpolation == device.InterpolationContinuous && t != nil && t.Duration > 0:
+			// Start from the current value so that
+			// an in-progress fade is retargeted
+			f.fades[i] = &fade{
+				from:     f.current[i],
+				to:       values[i],
+				start:    now,
+				duration: t.Duration,
+				easing:   easing,
+			}
+
+		default:
+			delete(f.fades, i)
+			f.current[i] = values[i]
+		}
+
+		f.targets[i] = values[i]
+	}
+
+	return f.gs.SetValues(ctx, f.current)
+}
+
+// step updates the current values of fading
+// channels to the time. The lock must be held.
+func (f *Fader) step(now time.Time) {
+	for i, fd := range f.fades {
+		v, done := fd.value(now)
+		f.current[i] = v
+		if done {
+			delete(f.fades, i)
+		}
+	}
+}
+
+// GetName returns a friendly name for the process
+func (f *Fader) GetName() string {
+	return "DMX fader"
+}
+
+// Start sends a frame at the frame rate while any
+// channels are fading, until the context is cancelled
+func (f *Fader) Start(ctx context.Context) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := f.frame(ctx); err != nil {
+				slog.Errorf("Failed to send DMX frame: %v", err)
+			}
+		}
+	}
+}
+
+func (f *Fader) frame(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.fades) == 0 {
+		return nil
+	}
+
+	f.step(f.now())
+	return f.gs.SetValues(ctx, f.current)
+}
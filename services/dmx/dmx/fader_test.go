@@ -0,0 +1,93 @@
+package dmx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jakewright/home-automation/libraries/go/device"
+)
+
+// newTestFader returns a fader with a clock that can be moved forward
+func newTestFader(gs GetSetter) (*Fader, func(d time.Duration)) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFader(gs, 0)
+	f.now = func() time.Time { return now }
+	return f, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestFader_Fade(t *testing.T) {
+	ctx := context.Background()
+	gs := &MockGetSetter{Values: [512]byte{0: 100, 1: 100, 2: 100}}
+	f, advance := newTestFader(gs)
+
+	var interpolations [512]string
+	interpolations[0] = device.InterpolationContinuous
+	interpolations[1] = device.InterpolationDiscrete
+
+	values := [512]byte{0: 200, 1: 200, 2: 200}
+	require.NoError(t, f.Fade(ctx, values, interpolations, &Transition{Duration: time.Second}))
+
+	// Discrete channels change immediately and channels
+	// without an interpolation are left unchanged
+	require.Equal(t, [3]byte{100, 200, 100}, [3]byte{gs.Values[0], gs.Values[1], gs.Values[2]})
+
+	// The targets are returned so that fixtures are hydrated with their new state
+	targets, err := f.GetValues(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [3]byte{200, 200, 100}, [3]byte{targets[0], targets[1], targets[2]})
+
+	advance(250 * time.Millisecond)
+	require.NoError(t, f.frame(ctx))
+	require.Equal(t, byte(125), gs.Values[0])
+
+	advance(time.Second)
+	require.NoError(t, f.frame(ctx))
+	require.Equal(t, byte(200), gs.Values[0])
+	require.Empty(t, f.fades)
+}
+
+func TestFader_Fade_retarget(t *testing.T) {
+	ctx := context.Background()
+	gs := &MockGetSetter{}
+	f, advance := newTestFader(gs)
+
+	var interpolations [512]string
+	interpolations[0] = device.InterpolationContinuous
+
+	require.NoError(t, f.Fade(ctx, [512]byte{0: 200}, interpolations, &Transition{Duration: time.Second}))
+	advance(500 * time.Millisecond)
+
+	// The new fade starts from the value part way through the first
+	require.NoError(t, f.Fade(ctx, [512]byte{0: 0}, interpolations, &Transition{Duration: time.Second}))
+	require.Equal(t, byte(100), gs.Values[0])
+
+	advance(500 * time.Millisecond)
+	require.NoError(t, f.frame(ctx))
+	require.Equal(t, byte(50), gs.Values[0])
+
+	// Without a transition the channel jumps and the fade is cancelled
+	require.NoError(t, f.Fade(ctx, [512]byte{0: 30}, interpolations, nil))
+	require.Equal(t, byte(30), gs.Values[0])
+	require.Empty(t, f.fades)
+}
+
+func TestEasing(t *testing.T) {
+	for name, easing := range map[string]Easing{
+		"linear":      EaseLinear,
+		"ease in":     EaseIn,
+		"ease out":    EaseOut,
+		"ease in out": EaseInOut,
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, 0.0, easing(0))
+			require.Equal(t, 1.0, easing(1))
+		})
+	}
+
+	require.Less(t, EaseIn(0.5), 0.5)
+	require.Greater(t, EaseOut(0.5), 0.5)
+	require.Equal(t, 0.5, EaseInOut(0.5))
+}
@@ -3,6 +3,7 @@ package dmx
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"time"
@@ -10,9 +11,13 @@ import (
 	"github.com/jakewright/patch"
 
 	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
 	"github.com/jakewright/home-automation/services/dmx/domain"
 )
 
+// OLATimeout is the timeout for requests to OLA
+const OLATimeout = 10 * time.Second
+
 // OLAClient makes requests to an OLA server to get and set DMX values
 // https://wiki.openlighting.org/index.php/OLA_JSON_API
 type OLAClient struct {
@@ -23,17 +28,23 @@ type OLAClient struct {
 // Compile-time assertion that OLAClient implements the GetSetter interface
 var _ GetSetter = (*OLAClient)(nil)
 
-// NewOLAClient returns a new OLA client for the given host:port
-func NewOLAClient(host string, port int, un domain.UniverseNumber) (*OLAClient, error) {
+// NewOLAClient returns a new OLA client for the given host:port. Requests
+// are made using the doer, which should be wrapped in a circuit breaker
+// e.g. by taxi.NewBreakerDoer. If the doer is nil, an http.Client is used.
+func NewOLAClient(doer taxi.Doer, host string, port int, un domain.UniverseNumber) (*OLAClient, error) {
 	u, err := url.Parse(fmt.Sprintf("%s:%d", host, port))
 	if err != nil {
 		return nil, oops.WithMessage(err, "failed to parse OLA URL")
 	}
 
+	if doer == nil {
+		doer = &http.Client{Timeout: OLATimeout}
+	}
+
 	return &OLAClient{
 		un: un,
-		client: patch.New(
-			patch.WithTimeout(time.Second*10),
+		client: patch.NewFromBaseClient(
+			doer,
 			patch.WithStatusValidator(func(status int) bool {
 				return status == 200
 			}),
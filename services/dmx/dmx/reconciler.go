@@ -0,0 +1,100 @@
+package dmx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jakewright/home-automation/libraries/go/slog"
+)
+
+// Reconciler wraps a GetSetter and periodically checks that the receiver
+// still has the values that were last set. If it doesn't, e.g. because
+// OLA was restarted, the values are set again. It must be started as a
+// process for the reconciliation to happen.
+type Reconciler struct {
+	gs       GetSetter
+	interval time.Duration
+
+	set    bool
+	values [512]byte
+	mu     sync.Mutex
+}
+
+// Compile-time assertion that Reconciler implements the GetSetter interface
+var _ GetSetter = (*Reconciler)(nil)
+
+// NewReconciler returns a Reconciler that checks the values at the interval
+func NewReconciler(gs GetSetter, interval time.Duration) *Reconciler {
+	return &Reconciler{
+		gs:       gs,
+		interval: interval,
+	}
+}
+
+// GetValues returns the values from the wrapped GetSetter
+func (r *Reconciler) GetValues(ctx context.Context) ([512]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.gs.GetValues(ctx)
+}
+
+// SetValues sets the values and remembers them for reconciliation
+func (r *Reconciler) SetValues(ctx context.Context, values [512]byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.gs.SetValues(ctx, values); err != nil {
+		return err
+	}
+
+	r.set, r.values = true, values
+	return nil
+}
+
+// GetName returns a friendly name for the process
+func (r *Reconciler) GetName() string {
+	return "DMX reconciler"
+}
+
+// Start reconciles the values at the interval until the context is cancelled
+func (r *Reconciler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.reconcile(ctx); err != nil {
+				slog.Errorf("Failed to reconcile DMX values: %v", err)
+			}
+		}
+	}
+}
+
+// reconcile sets the values again if they have changed
+// since they were set. Nothing is done until the first
+// time the values are set.
+func (r *Reconciler) reconcile(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.set {
+		return nil
+	}
+
+	values, err := r.gs.GetValues(ctx)
+	if err != nil {
+		return err
+	}
+
+	if values == r.values {
+		return nil
+	}
+
+	slog.Warnf("DMX values have changed since they were set; setting them again")
+	return r.gs.SetValues(ctx, r.values)
+}
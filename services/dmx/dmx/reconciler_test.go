@@ -0,0 +1,26 @@
+package dmx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+	gs := &MockGetSetter{}
+	r := NewReconciler(gs, 0)
+
+	// Nothing is reconciled before the values have been set
+	gs.Values[0] = 10
+	require.NoError(t, r.reconcile(ctx))
+	require.Equal(t, byte(10), gs.Values[0])
+
+	require.NoError(t, r.SetValues(ctx, [512]byte{0: 20}))
+
+	// The values are set again if they change
+	gs.Values[0] = 0
+	require.NoError(t, r.reconcile(ctx))
+	require.Equal(t, byte(20), gs.Values[0])
+}
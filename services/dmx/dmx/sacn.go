@@ -0,0 +1,165 @@
+package dmx
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+const (
+	sacnPort            = "5568"
+	sacnDefaultPriority = 100
+	sacnHeaderLength    = 126
+
+	sacnVectorRootData    = 0x00000004
+	sacnVectorFramingData = 0x00000002
+	sacnVectorDMPSetProp  = 0x02
+)
+
+var sacnPacketID = []byte("ASC-E1.17\x00\x00\x00")
+
+// sacnCIDNamespace is the RFC 4122 DNS namespace
+// that name-based CIDs are generated in
+var sacnCIDNamespace = [16]byte{
+	0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1,
+	0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8,
+}
+
+// SACNOptions configure a SACNClient
+type SACNOptions struct {
+	// Address is the host of the receiver, with an optional port.
+	// If not set, packets are sent to the universe's multicast
+	// address. The default port is 5568.
+	Address string
+
+	// Universe is the sACN universe, from 1 to 63999
+	Universe uint16
+
+	// SourceName is a friendly name for the sender
+	SourceName string
+
+	// CID uniquely identifies the sender. If not set, a CID is
+	// derived from the SourceName and the host's name so that
+	// it stays the same when the service is restarted.
+	CID [16]byte
+
+	// Priority is from 0 to 200. If zero, the default of 100 is used.
+	Priority byte
+
+	// RefreshInterval is how often the values are resent. If
+	// zero, DefaultRefreshInterval is used.
+	RefreshInterval time.Duration
+}
+
+// SACNClient sends DMX values using sACN (ANSI E1.31) data packets.
+// It must be started as a process so that the values are refreshed.
+type SACNClient struct {
+	*udpSender
+	opts     SACNOptions
+	sequence byte
+}
+
+// Compile-time assertion that SACNClient implements the GetSetter interface
+var _ GetSetter = (*SACNClient)(nil)
+
+// NewSACNClient returns a client that sends sACN data packets
+func NewSACNClient(opts *SACNOptions) (*SACNClient, error) {
+	if opts.Universe < 1 || opts.Universe > 63999 {
+		return nil, oops.InternalService("sACN universe %d is not between 1 and 63999", opts.Universe)
+	}
+
+	if opts.Priority > 200 {
+		return nil, oops.InternalService("sACN priority %d is greater than 200", opts.Priority)
+	}
+
+	if len(opts.SourceName) > 63 {
+		return nil, oops.InternalService("sACN source name is longer than 63 bytes")
+	}
+
+	c := &SACNClient{opts: *opts}
+	if c.opts.Priority == 0 {
+		c.opts.Priority = sacnDefaultPriority
+	}
+
+	if c.opts.CID == [16]byte{} {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, oops.WithMessage(err, "failed to get hostname for sACN CID")
+		}
+		c.opts.CID = NameCID(c.opts.SourceName + "." + hostname)
+	}
+
+	address := opts.Address
+	if address == "" {
+		// The multicast address for a universe is 239.255.<high byte>.<low byte>
+		address = fmt.Sprintf("239.255.%d.%d", opts.Universe>>8, opts.Universe&0xFF)
+	}
+
+	s, err := newUDPSender("sACN", withDefaultPort(address, sacnPort), opts.RefreshInterval, c.packet)
+	if err != nil {
+		return nil, err
+	}
+
+	c.udpSender = s
+	return c, nil
+}
+
+// NameCID returns a name-based (version 5) UUID for use as a
+// CID. The same name always results in the same CID.
+func NameCID(name string) [16]byte {
+	h := sha1.New()
+	h.Write(sacnCIDNamespace[:])
+	h.Write([]byte(name))
+
+	var cid [16]byte
+	copy(cid[:], h.Sum(nil))
+	cid[6] = (cid[6] & 0x0F) | 0x50 // Version 5
+	cid[8] = (cid[8] & 0x3F) | 0x80 // RFC 4122 variant
+	return cid
+}
+
+// packet returns an E1.31 data packet
+func (c *SACNClient) packet(values [512]byte) []byte {
+	c.sequence++
+
+	p := make([]byte, sacnHeaderLength+len(values))
+
+	// Root layer
+	binary.BigEndian.PutUint16(p[0:], 0x0010) // Preamble size
+	binary.BigEndian.PutUint16(p[2:], 0x0000) // Post-amble size
+	copy(p[4:], sacnPacketID)
+	putFlagsAndLength(p[16:], len(p)-16)
+	binary.BigEndian.PutUint32(p[18:], sacnVectorRootData)
+	copy(p[22:], c.opts.CID[:])
+
+	// Framing layer
+	putFlagsAndLength(p[38:], len(p)-38)
+	binary.BigEndian.PutUint32(p[40:], sacnVectorFramingData)
+	copy(p[44:108], c.opts.SourceName)
+	p[108] = c.opts.Priority
+	binary.BigEndian.PutUint16(p[109:], 0) // Synchronization address
+	p[111] = c.sequence
+	p[112] = 0 // Options
+	binary.BigEndian.PutUint16(p[113:], c.opts.Universe)
+
+	// DMP layer
+	putFlagsAndLength(p[115:], len(p)-115)
+	p[117] = sacnVectorDMPSetProp
+	p[118] = 0xA1                                              // Address type and data type
+	binary.BigEndian.PutUint16(p[119:], 0)                     // First property address
+	binary.BigEndian.PutUint16(p[121:], 1)                     // Address increment
+	binary.BigEndian.PutUint16(p[123:], uint16(len(values)+1)) // Property value count including the start code
+	p[125] = 0                                                 // DMX start code
+	copy(p[sacnHeaderLength:], values[:])
+
+	return p
+}
+
+// putFlagsAndLength writes the length of a PDU with the flags set to 0x7
+func putFlagsAndLength(b []byte, length int) {
+	binary.BigEndian.PutUint16(b, 0x7000|uint16(length))
+}
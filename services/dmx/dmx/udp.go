@@ -0,0 +1,123 @@
+package dmx
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/slog"
+)
+
+// DefaultRefreshInterval is how often Art-Net and sACN clients resend
+// the last values. Receivers stop outputting if they don't receive
+// a packet for a few seconds, so the values must be sent regularly
+// even if they haven't changed.
+const DefaultRefreshInterval = time.Second
+
+// udpSender sends the values of a universe as UDP packets. The values
+// are write-only so the last values sent are kept in memory.
+type udpSender struct {
+	name     string
+	conn     net.Conn
+	interval time.Duration
+
+	// packet returns the packet for the values. It is only
+	// called with the lock held so that it can keep state
+	// such as sequence numbers.
+	packet func(values [512]byte) []byte
+
+	values [512]byte
+	sent   bool
+	mu     sync.Mutex
+}
+
+func newUDPSender(name, address string, interval time.Duration, packet func([512]byte) []byte) (*udpSender, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, oops.WithMessage(err, "failed to dial %s", address)
+	}
+
+	if interval == 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	return &udpSender{
+		name:     name,
+		conn:     conn,
+		interval: interval,
+		packet:   packet,
+	}, nil
+}
+
+// GetValues returns the last values that were sent
+func (s *udpSender) GetValues(context.Context) ([512]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.values, nil
+}
+
+// SetValues sends the values
+func (s *udpSender) SetValues(_ context.Context, values [512]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values = values
+	s.sent = true
+	return s.send()
+}
+
+func (s *udpSender) send() error {
+	if _, err := s.conn.Write(s.packet(s.values)); err != nil {
+		return oops.WithMessage(err, "failed to send %s packet", s.name)
+	}
+	return nil
+}
+
+// GetName returns a friendly name for the process
+func (s *udpSender) GetName() string {
+	return s.name + " " + s.conn.RemoteAddr().String()
+}
+
+// Start resends the last values at the refresh interval
+// until the context is cancelled. Nothing is sent until
+// the values have been set for the first time so that
+// the universe isn't blacked out on startup.
+func (s *udpSender) Start(ctx context.Context) error {
+	defer func() { _ = s.conn.Close() }()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.refresh()
+		}
+	}
+}
+
+func (s *udpSender) refresh() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.sent {
+		return
+	}
+
+	if err := s.send(); err != nil {
+		slog.Errorf("Failed to refresh DMX values: %v", err)
+	}
+}
+
+// withDefaultPort adds the port to the address if it doesn't have one
+func withDefaultPort(address string, port string) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+	return net.JoinHostPort(address, port)
+}
@@ -0,0 +1,179 @@
+package dmx
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// listen returns a UDP connection on a random loopback port
+func listen(t *testing.T) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// receive returns the next packet received by the connection
+func receive(t *testing.T, conn net.PacketConn) []byte {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+	b := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(b)
+	require.NoError(t, err)
+	return b[:n]
+}
+
+func TestArtNetClient(t *testing.T) {
+	receiver := listen(t)
+
+	c, err := NewArtNetClient(&ArtNetOptions{
+		Address:         receiver.LocalAddr().String(),
+		Universe:        0x0123,
+		RefreshInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	values := [512]byte{0: 10, 1: 20, 511: 255}
+	require.NoError(t, c.SetValues(context.Background(), values))
+
+	p := receive(t, receiver)
+	require.Len(t, p, 530)
+	require.Equal(t, []byte("Art-Net\x00"), p[0:8])
+	require.Equal(t, uint16(0x5000), binary.LittleEndian.Uint16(p[8:]))
+	require.Equal(t, uint16(14), binary.BigEndian.Uint16(p[10:]))
+	require.Equal(t, byte(1), p[12])
+	require.Equal(t, byte(0x23), p[14])
+	require.Equal(t, byte(0x01), p[15])
+	require.Equal(t, uint16(512), binary.BigEndian.Uint16(p[16:]))
+	require.Equal(t, values[:], p[18:])
+
+	got, err := c.GetValues(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, values, got)
+
+	// The values are refreshed with the next sequence number
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = c.Start(ctx) }()
+
+	p = receive(t, receiver)
+	require.Equal(t, byte(2), p[12])
+	require.Equal(t, values[:], p[18:])
+
+	_, err = NewArtNetClient(&ArtNetOptions{Address: "127.0.0.1", Universe: 0x8000})
+	require.Error(t, err)
+}
+
+func TestArtNetClient_sequence(t *testing.T) {
+	c := &ArtNetClient{sequence: 254}
+
+	require.Equal(t, byte(255), c.packet([512]byte{})[12])
+
+	// Zero disables sequencing so it is skipped
+	require.Equal(t, byte(1), c.packet([512]byte{})[12])
+}
+
+func TestSACNClient(t *testing.T) {
+	receiver := listen(t)
+
+	cid := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	c, err := NewSACNClient(&SACNOptions{
+		Address:         receiver.LocalAddr().String(),
+		Universe:        7,
+		SourceName:      "home-automation",
+		CID:             cid,
+		RefreshInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	values := [512]byte{0: 10, 1: 20, 511: 255}
+	require.NoError(t, c.SetValues(context.Background(), values))
+
+	p := receive(t, receiver)
+	require.Len(t, p, 638)
+
+	// Root layer
+	require.Equal(t, uint16(0x0010), binary.BigEndian.Uint16(p[0:]))
+	require.Equal(t, []byte("ASC-E1.17\x00\x00\x00"), p[4:16])
+	require.Equal(t, uint16(0x7000|622), binary.BigEndian.Uint16(p[16:]))
+	require.Equal(t, uint32(4), binary.BigEndian.Uint32(p[18:]))
+	require.Equal(t, cid[:], p[22:38])
+
+	// Framing layer
+	require.Equal(t, uint16(0x7000|600), binary.BigEndian.Uint16(p[38:]))
+	require.Equal(t, uint32(2), binary.BigEndian.Uint32(p[40:]))
+	require.Equal(t, "home-automation", string(p[44:59]))
+	require.Equal(t, byte(0), p[59])
+	require.Equal(t, byte(100), p[108])
+	require.Equal(t, byte(1), p[111])
+	require.Equal(t, uint16(7), binary.BigEndian.Uint16(p[113:]))
+
+	// DMP layer
+	require.Equal(t, uint16(0x7000|523), binary.BigEndian.Uint16(p[115:]))
+	require.Equal(t, byte(0x02), p[117])
+	require.Equal(t, byte(0xA1), p[118])
+	require.Equal(t, uint16(1), binary.BigEndian.Uint16(p[121:]))
+	require.Equal(t, uint16(513), binary.BigEndian.Uint16(p[123:]))
+	require.Equal(t, byte(0), p[125])
+	require.Equal(t, values[:], p[126:])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = c.Start(ctx) }()
+
+	p = receive(t, receiver)
+	require.Equal(t, byte(2), p[111])
+	require.Equal(t, values[:], p[126:])
+}
+
+func TestSACNClient_defaultCID(t *testing.T) {
+	c, err := NewSACNClient(&SACNOptions{Universe: 1, SourceName: "service.dmx"})
+	require.NoError(t, err)
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	require.Equal(t, NameCID("service.dmx."+hostname), c.opts.CID)
+}
+
+func TestNameCID(t *testing.T) {
+	// The UUID v5 of www.example.com in the DNS namespace
+	want := [16]byte{
+		0x2e, 0xd6, 0x65, 0x7d, 0xe9, 0x27, 0x56, 0x8b,
+		0x95, 0xe1, 0x26, 0x65, 0xa8, 0xae, 0xa6, 0xa2,
+	}
+	require.Equal(t, want, NameCID("www.example.com"))
+	require.NotEqual(t, want, NameCID("www.example.org"))
+}
+
+func TestSACNClient_multicast(t *testing.T) {
+	c, err := NewSACNClient(&SACNOptions{Universe: 0x0102})
+	require.NoError(t, err)
+	require.Equal(t, "239.255.1.2:5568", c.conn.RemoteAddr().String())
+
+	_, err = NewSACNClient(&SACNOptions{Universe: 64000})
+	require.Error(t, err)
+}
+
+func TestUDPSender_noRefreshBeforeSet(t *testing.T) {
+	receiver := listen(t)
+
+	c, err := NewArtNetClient(&ArtNetOptions{
+		Address:         receiver.LocalAddr().String(),
+		RefreshInterval: 5 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = c.Start(ctx) }()
+
+	require.NoError(t, receiver.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, _, err = receiver.ReadFrom(make([]byte, 1024))
+	require.Error(t, err)
+}
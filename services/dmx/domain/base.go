@@ -9,6 +9,7 @@ type baseFixture struct {
 	*devicedef.Header
 	universeNumber UniverseNumber
 	offsetValue    int
+	groups         []string
 }
 
 // setHeader sets the fixture's header and pulls the offset out of the attributes
@@ -23,17 +24,41 @@ func (f *baseFixture) setHeader(h *devicedef.Header) error {
 		return oops.PreconditionFailed("offset not found in %s device header", h.Id)
 	}
 
+	// Groups are optional
+	var groups []string
+	if v, ok := h.Attributes["groups"]; ok {
+		list, ok := v.([]interface{})
+		if !ok {
+			return oops.PreconditionFailed("groups in %s device header is not a list", h.Id)
+		}
+
+		for _, g := range list {
+			id, ok := g.(string)
+			if !ok || id == "" {
+				return oops.PreconditionFailed("invalid group %v in %s device header", g, h.Id)
+			}
+			groups = append(groups, id)
+		}
+	}
+
 	f.Header = h
 	f.universeNumber = UniverseNumber(universeNumber)
 	f.offsetValue = int(offset)
+	f.groups = groups
 	return nil
 }
 
 // ID returns the device ID
 func (f *baseFixture) ID() string { return f.Header.GetId() }
 
+// DeviceHeader returns the device's header
+func (f *baseFixture) DeviceHeader() *devicedef.Header { return f.Header }
+
 // UniverseNumber returns the fixture's universe number
 func (f *baseFixture) UniverseNumber() UniverseNumber { return f.universeNumber }
 
+// Groups returns the IDs of the groups that the fixture is in
+func (f *baseFixture) Groups() []string { return f.groups }
+
 // offset returns the fixture's offset into the channel space
 func (f *baseFixture) offset() int { return f.offsetValue }
@@ -0,0 +1,56 @@
+// Code generated by devicegen. DO NOT EDIT.
+
+package domain
+
+import (
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	ptr "github.com/jakewright/home-automation/libraries/go/ptr"
+	def "github.com/jakewright/home-automation/services/dmx/def"
+)
+
+// Device is implemented by every type of device that the service controls
+type Device interface {
+	// ID returns the device's ID
+	ID() string
+
+	// DeviceHeader returns the device's header from the registry
+	DeviceHeader() *devicedef.Header
+}
+
+// MegaParProfileDevice is implemented by MegaParProfile devices
+type MegaParProfileDevice interface {
+	Device
+
+	// State returns the current state of the device
+	State() *def.MegaParProfileState
+
+	// ApplyState updates the device with the
+	// properties that are set in the state
+	ApplyState(state *def.MegaParProfileState) error
+}
+
+// MegaParProfileProperties returns metadata
+// about the properties of MegaParProfile devices
+func MegaParProfileProperties() map[string]*devicedef.Property {
+	return map[string]*devicedef.Property{
+		"brightness": {
+			Type:          ptr.String("int"),
+			Min:           ptr.Float64(0),
+			Max:           ptr.Float64(255),
+			Interpolation: ptr.String("continuous"),
+		},
+		"color": {
+			Type:          ptr.String("rgb"),
+			Interpolation: ptr.String("continuous"),
+		},
+		"power": {
+			Type: ptr.String("bool"),
+		},
+		"strobe": {
+			Type:          ptr.String("int"),
+			Min:           ptr.Float64(0),
+			Max:           ptr.Float64(255),
+			Interpolation: ptr.String("discrete"),
+		},
+	}
+}
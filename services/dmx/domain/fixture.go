@@ -15,13 +15,15 @@ const (
 // Fixture is the interface that all DMX
 // fixture structs must implement
 type Fixture interface {
-	// ID returns the device ID of the fixture
-	ID() string
+	Device
 
 	// UniverseNumber returns the number of the
 	// universe of which the fixture is a part
 	UniverseNumber() UniverseNumber
 
+	// Groups returns the IDs of the groups that the fixture is in
+	Groups() []string
+
 	// offset returns the device's offset
 	// into the universe's channel space
 	offset() int
@@ -38,13 +40,23 @@ type Fixture interface {
 	// representing the current state of the fixture
 	dmxValues() []byte
 
+	// interpolations returns a slice of size Length() of the
+	// interpolation of each channel, either discrete or continuous
+	interpolations() []string
+
+	// dimmerChannels returns the indices of the
+	// channels that are scaled by master dimmers
+	dimmerChannels() []int
+
 	// setHeader is used by newFromDeviceHeader to set
 	// properties common to all fixtures
 	setHeader(header *devicedef.Header) error
 }
 
-// NewFixture returns a Fixture based on the device's fixture type attribute
-func NewFixture(h *devicedef.Header) (Fixture, error) {
+// NewFixture returns a Fixture based on the device's fixture type
+// attribute. Fixture types that aren't built in are looked up in the
+// profiles, and a ProfileFixture is returned.
+func NewFixture(h *devicedef.Header, profiles map[string]*Profile) (Fixture, error) {
 	fixtureType, ok := h.Attributes["fixture_type"].(string)
 	if !ok {
 		return nil, oops.PreconditionFailed("fixture_type not found in %s device header", h.Id)
@@ -56,7 +68,11 @@ func NewFixture(h *devicedef.Header) (Fixture, error) {
 	case FixtureTypeMegaParProfile:
 		f = &MegaParProfile{}
 	default:
-		return nil, oops.InternalService("device %s has invalid fixture type '%s'", h.Id, fixtureType)
+		p, ok := profiles[fixtureType]
+		if !ok {
+			return nil, oops.InternalService("device %s has invalid fixture type '%s'", h.Id, fixtureType)
+		}
+		f = newProfileFixture(p)
 	}
 
 	if err := f.setHeader(h); err != nil {
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/jakewright/home-automation/libraries/go/device"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
+)
+
+// Group is a set of fixtures that are controlled together. Fixtures are
+// added to groups by the groups attribute of their device headers.
+type Group struct {
+	ID string
+
+	// Fixtures are sorted by ID
+	Fixtures []Fixture
+}
+
+// NewGroups returns the groups that the fixtures are in
+func NewGroups(fixtures []Fixture) map[string]*Group {
+	groups := make(map[string]*Group)
+	for _, f := range fixtures {
+		for _, id := range f.Groups() {
+			if _, ok := groups[id]; !ok {
+				groups[id] = &Group{ID: id}
+			}
+			groups[id].Fixtures = append(groups[id].Fixtures, f)
+		}
+	}
+
+	for _, g := range groups {
+		fs := g.Fixtures
+		sort.Slice(fs, func(i, j int) bool {
+			return fs[i].ID() < fs[j].ID()
+		})
+	}
+
+	return groups
+}
+
+// ApplyState applies the state to a fixture of any type. The
+// state is validated against the fixture's properties first.
+func ApplyState(f Fixture, state map[string]interface{}) error {
+	switch f := f.(type) {
+	case *ProfileFixture:
+		return f.ApplyState(state)
+
+	case MegaParProfileDevice:
+		if err := device.ValidateState(state, MegaParProfileProperties()); err != nil {
+			return err
+		}
+
+		// The state has been validated so it can
+		// be converted to the typed state via JSON
+		b, err := json.Marshal(state)
+		if err != nil {
+			return oops.WithMessage(err, "failed to marshal state")
+		}
+
+		s := &dmxdef.MegaParProfileState{}
+		if err := json.Unmarshal(b, s); err != nil {
+			return oops.WithMessage(err, "failed to unmarshal state")
+		}
+
+		return f.ApplyState(s)
+	}
+
+	return oops.InternalService("cannot apply state to fixture %s", f.ID())
+}
+
+// Masters holds the levels of the master dimmers of groups and universes
+// as percentages. A fixture's dimmer channels are scaled by the master
+// of its universe and the masters of its groups, so the fixture's own
+// values are kept. Masters must be locked while they are used.
+type Masters struct {
+	sync.Mutex
+	groups    map[string]uint8
+	universes map[UniverseNumber]uint8
+}
+
+// NewMasters returns masters that are all at full level
+func NewMasters() *Masters {
+	return &Masters{
+		groups:    make(map[string]uint8),
+		universes: make(map[UniverseNumber]uint8),
+	}
+}
+
+// Group returns the level of the group's master
+func (m *Masters) Group(id string) uint8 {
+	if level, ok := m.groups[id]; ok {
+		return level
+	}
+	return 100
+}
+
+// SetGroup sets the level of the group's master
+func (m *Masters) SetGroup(id string, level uint8) {
+	m.groups[id] = level
+}
+
+// Universe returns the level of the universe's master
+func (m *Masters) Universe(un UniverseNumber) uint8 {
+	if level, ok := m.universes[un]; ok {
+		return level
+	}
+	return 100
+}
+
+// SetUniverse sets the level of the universe's master
+func (m *Masters) SetUniverse(un UniverseNumber, level uint8) {
+	m.universes[un] = level
+}
+
+// Scale returns the factor by which each channel of the universe
+// should be scaled. Fixtures in other universes are ignored.
+func (m *Masters) Scale(un UniverseNumber, fixtures []Fixture) [512]float64 {
+	var scale [512]float64
+	for i := range scale {
+		scale[i] = 1
+	}
+
+	universe := float64(m.Universe(un)) / 100
+
+	for _, f := range fixtures {
+		if f.UniverseNumber() != un {
+			continue
+		}
+
+		level := universe
+		for _, id := range f.Groups() {
+			level *= float64(m.Group(id)) / 100
+		}
+
+		for _, c := range f.dimmerChannels() {
+			scale[f.offset()+c] = level
+		}
+	}
+
+	return scale
+}
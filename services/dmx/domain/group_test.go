@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/util"
+)
+
+func TestNewGroups(t *testing.T) {
+	t.Parallel()
+
+	newFixture := func(id string, groups interface{}) (Fixture, error) {
+		attributes := map[string]interface{}{
+			"fixture_type": FixtureTypeMegaParProfile,
+			"universe":     float64(1),
+			"offset":       float64(0),
+		}
+		if groups != nil {
+			attributes["groups"] = groups
+		}
+
+		return NewFixture((&devicedef.Header{}).
+			SetId(id).
+			SetAttributes(attributes), nil)
+	}
+
+	f1, err := newFixture("b", []interface{}{"stage", "front"})
+	require.NoError(t, err)
+	f2, err := newFixture("a", []interface{}{"stage"})
+	require.NoError(t, err)
+	f3, err := newFixture("c", nil)
+	require.NoError(t, err)
+
+	groups := NewGroups([]Fixture{f1, f2, f3})
+	require.Len(t, groups, 2)
+	require.Equal(t, []Fixture{f2, f1}, groups["stage"].Fixtures)
+	require.Equal(t, []Fixture{f1}, groups["front"].Fixtures)
+
+	_, err = newFixture("d", "stage")
+	require.Error(t, err)
+
+	_, err = newFixture("e", []interface{}{float64(1)})
+	require.Error(t, err)
+}
+
+func TestApplyState(t *testing.T) {
+	t.Parallel()
+
+	f := &MegaParProfile{}
+	require.NoError(t, ApplyState(f, map[string]interface{}{
+		"brightness": float64(100),
+		"color":      "#00ff00",
+	}))
+	require.Equal(t, byte(100), f.brightness)
+	require.Equal(t, util.RGB{G: 255, A: 255}, f.color)
+
+	err := ApplyState(f, map[string]interface{}{"brightness": float64(300)})
+	require.Error(t, err)
+	require.Equal(t, byte(100), f.brightness)
+}
+
+func TestMasters_Scale(t *testing.T) {
+	t.Parallel()
+
+	fixtures := []Fixture{
+		&MockFixture{UN: 1, Ofs: 0, Len: 7, Dimmers: []int{6}, Grps: []string{"stage"}},
+		&MockFixture{UN: 1, Ofs: 7, Len: 7, Dimmers: []int{6}, Grps: []string{"stage", "front"}},
+		&MockFixture{UN: 1, Ofs: 14, Len: 1, Dimmers: []int{0}},
+		&MockFixture{UN: 2, Ofs: 0, Len: 1, Dimmers: []int{0}, Grps: []string{"stage"}},
+	}
+
+	m := NewMasters()
+	m.SetUniverse(1, 50)
+	m.SetGroup("stage", 50)
+	m.SetGroup("front", 40)
+
+	scale := m.Scale(1, fixtures)
+	require.Equal(t, 1.0, scale[0])
+	require.Equal(t, 0.25, scale[6])
+	require.Equal(t, 0.1, scale[13])
+	require.Equal(t, 0.5, scale[14])
+	require.Equal(t, uint8(100), m.Group("back"))
+	require.Equal(t, uint8(100), m.Universe(2))
+}
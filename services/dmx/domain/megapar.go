@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"github.com/jakewright/home-automation/libraries/go/device"
 	"github.com/jakewright/home-automation/libraries/go/oops"
 	"github.com/jakewright/home-automation/libraries/go/util"
 	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
@@ -18,7 +19,10 @@ type MegaParProfile struct {
 	brightness byte
 }
 
-var _ Fixture = (*MegaParProfile)(nil)
+var (
+	_ Fixture              = (*MegaParProfile)(nil)
+	_ MegaParProfileDevice = (*MegaParProfile)(nil)
+)
 
 // length returns the number of DMX values that this fixture occupies
 func (f *MegaParProfile) length() int { return 7 }
@@ -59,10 +63,30 @@ func (f *MegaParProfile) dmxValues() []byte {
 	return []byte{f.color.R, f.color.G, f.color.B, f.colorMacro, f.strobe, f.program, b}
 }
 
+// interpolations returns the interpolation of each
+// channel based on the properties that they belong to
+func (f *MegaParProfile) interpolations() []string {
+	properties := MegaParProfileProperties()
+	color, _ := properties["color"].GetInterpolation()
+	strobe, _ := properties["strobe"].GetInterpolation()
+	brightness, _ := properties["brightness"].GetInterpolation()
+
+	return []string{
+		color, color, color,
+		device.InterpolationDiscrete, // Color macro
+		strobe,
+		device.InterpolationDiscrete, // Program
+		brightness,
+	}
+}
+
+// dimmerChannels returns the index of the brightness channel
+func (f *MegaParProfile) dimmerChannels() []int { return []int{6} }
+
 // ApplyState sets any properties that exist in the state map
-func (f *MegaParProfile) ApplyState(p *dmxdef.MegaParProfileState) {
+func (f *MegaParProfile) ApplyState(p *dmxdef.MegaParProfileState) error {
 	if p == nil {
-		return
+		return nil
 	}
 
 	if power, ok := p.GetPower(); ok {
@@ -81,6 +105,8 @@ func (f *MegaParProfile) ApplyState(p *dmxdef.MegaParProfileState) {
 		f.brightness = brightness
 		f.power = brightness > 0
 	}
+
+	return nil
 }
 
 // State returns the current state of the device's properties
@@ -10,6 +10,8 @@ type MockFixture struct {
 	UN      UniverseNumber
 	Ofs     int
 	Len     int
+	Grps    []string
+	Dimmers []int
 }
 
 var _ Fixture = (*MockFixture)(nil)
@@ -19,11 +21,21 @@ func (f *MockFixture) ID() string {
 	return f.IDValue
 }
 
+// DeviceHeader returns a header containing only the ID
+func (f *MockFixture) DeviceHeader() *devicedef.Header {
+	return (&devicedef.Header{}).SetId(f.IDValue)
+}
+
 // UniverseNumber returns the universe number
 func (f *MockFixture) UniverseNumber() UniverseNumber {
 	return f.UN
 }
 
+// Groups returns the groups
+func (f *MockFixture) Groups() []string {
+	return f.Grps
+}
+
 // SetProperties sets the properties
 func (f *MockFixture) SetProperties(m map[string]interface{}) error {
 	panic("implement me")
@@ -49,6 +61,16 @@ func (f *MockFixture) dmxValues() []byte {
 	panic("implement me")
 }
 
+// interpolations is not implemented
+func (f *MockFixture) interpolations() []string {
+	panic("implement me")
+}
+
+// dimmerChannels returns the dimmer channels
+func (f *MockFixture) dimmerChannels() []int {
+	return f.Dimmers
+}
+
 // setHeader is not implemented
 func (f *MockFixture) setHeader(header *devicedef.Header) error {
 	panic("implement me")
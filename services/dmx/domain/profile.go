@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/jakewright/home-automation/libraries/go/device"
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/ptr"
+)
+
+// Channel roles
+const (
+	RoleRed    = "red"
+	RoleGreen  = "green"
+	RoleBlue   = "blue"
+	RoleDimmer = "dimmer"
+	RoleStrobe = "strobe"
+	RolePan    = "pan"
+	RoleTilt   = "tilt"
+	RoleMacro  = "macro"
+)
+
+var continuousRoles = map[string]bool{
+	RoleRed:    true,
+	RoleGreen:  true,
+	RoleBlue:   true,
+	RoleDimmer: true,
+	RolePan:    true,
+	RoleTilt:   true,
+}
+
+var discreteRoles = map[string]bool{
+	RoleStrobe: true,
+	RoleMacro:  true,
+}
+
+// Profile describes the channel layout of a type of
+// fixture and how its channels map to device properties
+type Profile struct {
+	// Channels are in the order that they appear in
+	// the fixture's channel space, starting at its offset
+	Channels []*Channel `json:"channels"`
+
+	// Properties is keyed by the name of the property
+	Properties map[string]*ProfileProperty `json:"properties"`
+}
+
+// Channel is a single DMX channel. Channels without a role are not
+// mapped to a property, and keep whatever value they already have.
+type Channel struct {
+	Role string `json:"role"`
+
+	// Min and Max are the range of values that the channel
+	// can be set to. If not set, the full range 0 - 255 is used.
+	Min *int `json:"min"`
+	Max *int `json:"max"`
+}
+
+// ProfileProperty maps a device property onto the channel with
+// the given role. The type is one of bool, int or rgb. An rgb
+// property uses the red, green and blue channels and has no role.
+type ProfileProperty struct {
+	Type string `json:"type"`
+	Role string `json:"role"`
+
+	// Min and Max are the range of an int property. The range is
+	// scaled to the channel's range. If not set, the property has
+	// the same range as the channel.
+	Min *int `json:"min"`
+	Max *int `json:"max"`
+}
+
+// LoadProfiles reads and validates the profiles in the JSON file
+func LoadProfiles(filename string) (map[string]*Profile, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, oops.WithMessage(err, "failed to open profiles file")
+	}
+	defer func() { _ = f.Close() }()
+
+	return ParseProfiles(f)
+}
+
+// ParseProfiles decodes and validates a JSON object
+// of profiles keyed by the name of the fixture type
+func ParseProfiles(r io.Reader) (map[string]*Profile, error) {
+	var profiles map[string]*Profile
+	if err := json.NewDecoder(r).Decode(&profiles); err != nil {
+		return nil, oops.WithMessage(err, "failed to decode profiles")
+	}
+
+	for name, p := range profiles {
+		if name == FixtureTypeMegaParProfile {
+			return nil, oops.InternalService("profile %q has the same name as a built-in fixture type", name)
+		}
+
+		if err := p.validate(); err != nil {
+			return nil, oops.WithMessage(err, "invalid profile %q", name)
+		}
+	}
+
+	return profiles, nil
+}
+
+func (p *Profile) validate() error {
+	if p == nil || len(p.Channels) == 0 {
+		return oops.InternalService("profile has no channels")
+	}
+
+	if len(p.Channels) > 512 {
+		return oops.InternalService("profile has more than 512 channels")
+	}
+
+	roles := map[string]int{}
+	for i, c := range p.Channels {
+		if c == nil {
+			return oops.InternalService("channel %d is empty", i)
+		}
+
+		if c.Role != "" && !continuousRoles[c.Role] && !discreteRoles[c.Role] {
+			return oops.InternalService("channel %d has unknown role %q", i, c.Role)
+		}
+
+		if !inByteRange(c.Min) || !inByteRange(c.Max) || c.min() > c.max() {
+			return oops.InternalService("channel %d has invalid range %d - %d", i, c.min(), c.max())
+		}
+
+		roles[c.Role]++
+	}
+
+	for name, prop := range p.Properties {
+		if prop == nil {
+			return oops.InternalService("property %q is empty", name)
+		}
+
+		switch prop.Type {
+		case device.TypeRGB:
+			if prop.Role != "" {
+				return oops.InternalService("rgb property %q cannot have a role", name)
+			}
+
+			for _, role := range []string{RoleRed, RoleGreen, RoleBlue} {
+				if roles[role] != 1 {
+					return oops.InternalService("rgb property %q needs exactly one %s channel", name, role)
+				}
+			}
+
+		case device.TypeBool, device.TypeInt:
+			if prop.Role == "" {
+				return oops.InternalService("property %q has no role", name)
+			}
+
+			if roles[prop.Role] != 1 {
+				return oops.InternalService("property %q needs exactly one %s channel", name, prop.Role)
+			}
+
+			if prop.Type == device.TypeBool && (prop.Min != nil || prop.Max != nil) {
+				return oops.InternalService("bool property %q cannot have a range", name)
+			}
+
+			c := p.channel(prop.Role)
+			if prop.min(c) >= prop.max(c) && c.min() != c.max() {
+				return oops.InternalService("property %q has invalid range %d - %d", name, prop.min(c), prop.max(c))
+			}
+
+		default:
+			return oops.InternalService("property %q has unsupported type %q", name, prop.Type)
+		}
+	}
+
+	return nil
+}
+
+// channel returns the channel with the role
+func (p *Profile) channel(role string) *Channel {
+	if i := p.channelIndex(role); i >= 0 {
+		return p.Channels[i]
+	}
+	return nil
+}
+
+// channelIndex returns the index of the channel with the role
+func (p *Profile) channelIndex(role string) int {
+	for i, c := range p.Channels {
+		if c.Role == role {
+			return i
+		}
+	}
+	return -1
+}
+
+// deviceProperties returns the device properties described by the profile
+func (p *Profile) deviceProperties() map[string]*devicedef.Property {
+	properties := make(map[string]*devicedef.Property, len(p.Properties))
+
+	for name, prop := range p.Properties {
+		property := &devicedef.Property{
+			Type:          ptr.String(prop.Type),
+			Interpolation: ptr.String(device.InterpolationDiscrete),
+		}
+
+		switch prop.Type {
+		case device.TypeInt:
+			c := p.channel(prop.Role)
+			property.Min = ptr.Float64(float64(prop.min(c)))
+			property.Max = ptr.Float64(float64(prop.max(c)))
+			if continuousRoles[prop.Role] {
+				property.Interpolation = ptr.String(device.InterpolationContinuous)
+			}
+		case device.TypeRGB:
+			property.Interpolation = ptr.String(device.InterpolationContinuous)
+		}
+
+		properties[name] = property
+	}
+
+	return properties
+}
+
+func (c *Channel) min() int {
+	if c.Min == nil {
+		return 0
+	}
+	return *c.Min
+}
+
+func (c *Channel) max() int {
+	if c.Max == nil {
+		return 255
+	}
+	return *c.Max
+}
+
+func (p *ProfileProperty) min(c *Channel) int {
+	if p.Min == nil {
+		return c.min()
+	}
+	return *p.Min
+}
+
+func (p *ProfileProperty) max(c *Channel) int {
+	if p.Max == nil {
+		return c.max()
+	}
+	return *p.Max
+}
+
+// toChannel scales a value of the property to the channel's range
+func (p *ProfileProperty) toChannel(c *Channel, v int) byte {
+	return byte(scale(v, p.min(c), p.max(c), c.min(), c.max()))
+}
+
+// fromChannel scales a channel value to the property's range
+func (p *ProfileProperty) fromChannel(c *Channel, b byte) int {
+	v := int(b)
+	if v < c.min() {
+		v = c.min()
+	} else if v > c.max() {
+		v = c.max()
+	}
+	return scale(v, c.min(), c.max(), p.min(c), p.max(c))
+}
+
+// scale maps v from the range [fromMin, fromMax]
+// to the range [toMin, toMax], rounding to nearest
+func scale(v, fromMin, fromMax, toMin, toMax int) int {
+	if fromMax == fromMin {
+		return toMin
+	}
+
+	n := (v-fromMin)*(toMax-toMin)*2 + (fromMax - fromMin)
+	return toMin + n/((fromMax-fromMin)*2)
+}
+
+func inByteRange(v *int) bool {
+	return v == nil || (*v >= 0 && *v <= 255)
+}
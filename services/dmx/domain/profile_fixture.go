@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/jakewright/home-automation/libraries/go/device"
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/util"
+)
+
+// ProfileFixture is a fixture whose channels and
+// properties are described by a Profile
+type ProfileFixture struct {
+	baseFixture
+	profile *Profile
+
+	// values holds the value of each of the fixture's channels
+	values []byte
+
+	// off is true for channels that have been turned off
+	// by a bool property. The channel's value is kept so
+	// that it can be restored when it is turned back on.
+	off []bool
+}
+
+var _ Fixture = (*ProfileFixture)(nil)
+
+func newProfileFixture(p *Profile) *ProfileFixture {
+	return &ProfileFixture{
+		profile: p,
+		values:  make([]byte, len(p.Channels)),
+		off:     make([]bool, len(p.Channels)),
+	}
+}
+
+// length returns the number of channels in the profile
+func (f *ProfileFixture) length() int { return len(f.profile.Channels) }
+
+// hydrate sets the value of each channel
+func (f *ProfileFixture) hydrate(values []byte) error {
+	if len(values) != f.length() {
+		return oops.InternalService(
+			"expected %d values to hydrate fixture but received %d",
+			f.length(), len(values),
+		)
+	}
+
+	copy(f.values, values)
+	for i := range f.off {
+		f.off[i] = false
+	}
+
+	return nil
+}
+
+// dmxValues returns the DMX values for this fixture only
+func (f *ProfileFixture) dmxValues() []byte {
+	values := make([]byte, f.length())
+	for i, c := range f.profile.Channels {
+		values[i] = f.values[i]
+		if f.off[i] {
+			values[i] = byte(c.min())
+		}
+	}
+	return values
+}
+
+// interpolations returns the interpolation of each channel. Channels
+// are continuous if they belong to a continuous property.
+func (f *ProfileFixture) interpolations() []string {
+	interpolations := make([]string, f.length())
+	for i := range interpolations {
+		interpolations[i] = device.InterpolationDiscrete
+	}
+
+	properties := f.Properties()
+	for name, prop := range f.profile.Properties {
+		if v, _ := properties[name].GetInterpolation(); v != device.InterpolationContinuous {
+			continue
+		}
+
+		roles := []string{prop.Role}
+		if prop.Type == device.TypeRGB {
+			roles = []string{RoleRed, RoleGreen, RoleBlue}
+		}
+
+		for _, role := range roles {
+			interpolations[f.profile.channelIndex(role)] = device.InterpolationContinuous
+		}
+	}
+
+	return interpolations
+}
+
+// dimmerChannels returns the indices of the profile's dimmer channels
+func (f *ProfileFixture) dimmerChannels() []int {
+	var channels []int
+	for i, c := range f.profile.Channels {
+		if c.Role == RoleDimmer {
+			channels = append(channels, i)
+		}
+	}
+	return channels
+}
+
+// Properties returns the device properties described by the profile
+func (f *ProfileFixture) Properties() map[string]*devicedef.Property {
+	return f.profile.deviceProperties()
+}
+
+// State returns the current value of each property. Colors
+// are hex strings so that the state can be encoded as JSON.
+func (f *ProfileFixture) State() map[string]interface{} {
+	state := make(map[string]interface{}, len(f.profile.Properties))
+
+	for name, prop := range f.profile.Properties {
+		switch prop.Type {
+		case device.TypeBool:
+			i := f.profile.channelIndex(prop.Role)
+			state[name] = !f.off[i] && int(f.values[i]) > f.profile.Channels[i].min()
+		case device.TypeInt:
+			i := f.profile.channelIndex(prop.Role)
+			state[name] = int64(prop.fromChannel(f.profile.Channels[i], f.values[i]))
+		case device.TypeRGB:
+			state[name] = util.ColorToHex(color.RGBA{
+				R: f.values[f.profile.channelIndex(RoleRed)],
+				G: f.values[f.profile.channelIndex(RoleGreen)],
+				B: f.values[f.profile.channelIndex(RoleBlue)],
+			})
+		}
+	}
+
+	return state
+}
+
+// ApplyState validates the state against the fixture's properties
+// and then sets the channels. Bool properties are applied first so
+// that setting an int property on the same channel turns it on.
+func (f *ProfileFixture) ApplyState(state map[string]interface{}) error {
+	if err := device.ValidateState(state, f.Properties()); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(state))
+	for name := range state {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		bi := f.profile.Properties[names[i]].Type == device.TypeBool
+		bj := f.profile.Properties[names[j]].Type == device.TypeBool
+		if bi != bj {
+			return bi
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		prop := f.profile.Properties[name]
+		value := state[name]
+
+		switch prop.Type {
+		case device.TypeBool:
+			i := f.profile.channelIndex(prop.Role)
+			c := f.profile.Channels[i]
+			f.off[i] = !value.(bool)
+
+			// Turning on a channel that is at its minimum
+			// wouldn't change anything so go to the maximum
+			if !f.off[i] && int(f.values[i]) <= c.min() {
+				f.values[i] = byte(c.max())
+			}
+
+		case device.TypeInt:
+			i := f.profile.channelIndex(prop.Role)
+			f.values[i] = prop.toChannel(f.profile.Channels[i], intValue(value))
+			f.off[i] = false
+
+		case device.TypeRGB:
+			c, err := rgbValue(value)
+			if err != nil {
+				return err
+			}
+
+			f.values[f.profile.channelIndex(RoleRed)] = c.R
+			f.values[f.profile.channelIndex(RoleGreen)] = c.G
+			f.values[f.profile.channelIndex(RoleBlue)] = c.B
+		}
+	}
+
+	return nil
+}
+
+// intValue converts a value that has been validated as an int
+func intValue(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+
+	panic("value was not validated as an int")
+}
+
+// rgbValue converts a value that has been validated as an rgb
+func rgbValue(value interface{}) (color.RGBA, error) {
+	switch v := value.(type) {
+	case util.RGB:
+		return color.RGBA(v), nil
+	case *util.RGB:
+		return color.RGBA(*v), nil
+	case color.RGBA:
+		return v, nil
+	case string:
+		return util.HexToColor(v)
+	}
+
+	return color.RGBA{}, oops.BadRequest("invalid color %v", value)
+}
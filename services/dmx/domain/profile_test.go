@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jakewright/home-automation/libraries/go/device"
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	t.Parallel()
+
+	// The example profiles must always be valid
+	profiles, err := LoadProfiles("../profiles.json")
+	require.NoError(t, err)
+	require.Contains(t, profiles, "rgb_par")
+	require.Contains(t, profiles, "moving_head")
+}
+
+func TestParseProfiles_invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		profile string
+		err     string
+	}{
+		{
+			name:    "no channels",
+			profile: `{"channels": []}`,
+			err:     "profile has no channels",
+		},
+		{
+			name:    "unknown role",
+			profile: `{"channels": [{"role": "zoom"}]}`,
+			err:     `channel 0 has unknown role "zoom"`,
+		},
+		{
+			name:    "invalid channel range",
+			profile: `{"channels": [{"role": "dimmer", "min": 10, "max": 300}]}`,
+			err:     "channel 0 has invalid range 10 - 300",
+		},
+		{
+			name:    "missing color channel",
+			profile: `{"channels": [{"role": "red"}, {"role": "green"}], "properties": {"color": {"type": "rgb"}}}`,
+			err:     `rgb property "color" needs exactly one blue channel`,
+		},
+		{
+			name:    "missing channel",
+			profile: `{"channels": [{"role": "red"}], "properties": {"brightness": {"type": "int", "role": "dimmer"}}}`,
+			err:     `property "brightness" needs exactly one dimmer channel`,
+		},
+		{
+			name:    "ambiguous channel",
+			profile: `{"channels": [{"role": "macro"}, {"role": "macro"}], "properties": {"gobo": {"type": "int", "role": "macro"}}}`,
+			err:     `property "gobo" needs exactly one macro channel`,
+		},
+		{
+			name:    "unsupported type",
+			profile: `{"channels": [{"role": "macro"}], "properties": {"gobo": {"type": "string", "role": "macro"}}}`,
+			err:     `property "gobo" has unsupported type "string"`,
+		},
+		{
+			name:    "invalid property range",
+			profile: `{"channels": [{"role": "pan"}], "properties": {"pan": {"type": "int", "role": "pan", "min": 10, "max": 10}}}`,
+			err:     `property "pan" has invalid range 10 - 10`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseProfiles(strings.NewReader(`{"test": ` + tt.profile + `}`))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	_, err := ParseProfiles(strings.NewReader(`{"mega_par_profile": {"channels": [{}]}}`))
+	require.Error(t, err)
+}
+
+func testProfileFixture(t *testing.T, fixtureType string) *ProfileFixture {
+	profiles, err := LoadProfiles("../profiles.json")
+	require.NoError(t, err)
+
+	fixture, err := NewFixture((&devicedef.Header{}).
+		SetId("fixture 1").
+		SetAttributes(map[string]interface{}{
+			"fixture_type": fixtureType,
+			"universe":     float64(1),
+			"offset":       float64(10),
+		}),
+		profiles,
+	)
+	require.NoError(t, err)
+
+	pf, ok := fixture.(*ProfileFixture)
+	require.True(t, ok)
+	return pf
+}
+
+func TestProfileFixture(t *testing.T) {
+	t.Parallel()
+
+	f := testProfileFixture(t, "rgb_par")
+	require.Equal(t, 10, f.offset())
+	require.Equal(t, 6, f.length())
+
+	require.NoError(t, f.hydrate([]byte{200, 255, 0, 16, 0, 42}))
+	require.Equal(t, map[string]interface{}{
+		"power":      true,
+		"brightness": int64(200),
+		"color":      "#FF0010",
+		"strobe":     int64(0),
+	}, f.State())
+
+	// Turning the power off keeps the brightness
+	require.NoError(t, f.ApplyState(map[string]interface{}{"power": false}))
+	require.Equal(t, []byte{0, 255, 0, 16, 0, 42}, f.dmxValues())
+	require.Equal(t, int64(200), f.State()["brightness"])
+	require.Equal(t, false, f.State()["power"])
+
+	require.NoError(t, f.ApplyState(map[string]interface{}{"power": true}))
+	require.Equal(t, []byte{200, 255, 0, 16, 0, 42}, f.dmxValues())
+
+	// Setting the brightness of a channel that is off turns it on
+	require.NoError(t, f.ApplyState(map[string]interface{}{
+		"power":      false,
+		"brightness": float64(100),
+		"color":      "#00FF00",
+		"strobe":     float64(250),
+	}))
+	require.Equal(t, []byte{100, 0, 255, 0, 250, 42}, f.dmxValues())
+	require.Equal(t, true, f.State()["power"])
+
+	// Channels that are turned on from zero go to full
+	require.NoError(t, f.hydrate([]byte{0, 0, 0, 0, 0, 0}))
+	require.Equal(t, false, f.State()["power"])
+	require.NoError(t, f.ApplyState(map[string]interface{}{"power": true}))
+	require.Equal(t, byte(255), f.dmxValues()[0])
+}
+
+func TestProfileFixture_scaling(t *testing.T) {
+	t.Parallel()
+
+	f := testProfileFixture(t, "moving_head")
+
+	properties := f.Properties()
+	require.Equal(t, device.TypeInt, properties["pan"].GetType())
+	max, _ := properties["pan"].GetMax()
+	require.Equal(t, float64(540), max)
+	interpolation, _ := properties["gobo"].GetInterpolation()
+	require.Equal(t, device.InterpolationDiscrete, interpolation)
+
+	require.NoError(t, f.ApplyState(map[string]interface{}{
+		"pan":        270,
+		"tilt":       270,
+		"brightness": 50,
+		"gobo":       127,
+	}))
+	require.Equal(t, []byte{128, 255, 127, 128}, f.dmxValues())
+
+	// The pan range is wider than the channel's so precision is lost
+	require.Equal(t, int64(271), f.State()["pan"])
+	require.Equal(t, int64(50), f.State()["brightness"])
+
+	err := f.ApplyState(map[string]interface{}{
+		"pan":  600,
+		"gobo": 200,
+		"zoom": 1,
+	})
+	require.True(t, oops.Is(err, oops.ErrBadRequest))
+	require.Equal(t, map[string]string{
+		"pan":  "should be ≤ 540",
+		"gobo": "should be ≤ 127",
+		"zoom": "unknown property",
+	}, err.(*oops.Error).GetMetadata())
+}
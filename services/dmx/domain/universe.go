@@ -42,3 +42,14 @@ func (u *Universe) DMXValues() [512]byte {
 	}
 	return u.values
 }
+
+// Interpolations returns the interpolation of each channel that
+// belongs to one of the universe's fixtures. The interpolation of
+// all other channels is empty.
+func (u *Universe) Interpolations() [512]string {
+	var interpolations [512]string
+	for _, f := range u.fixtures {
+		copy(interpolations[f.offset():], f.interpolations())
+	}
+	return interpolations
+}
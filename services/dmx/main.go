@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/jakewright/home-automation/libraries/go/bootstrap"
+	"github.com/jakewright/home-automation/libraries/go/healthz"
 	"github.com/jakewright/home-automation/libraries/go/oops"
 	"github.com/jakewright/home-automation/libraries/go/slog"
 	"github.com/jakewright/home-automation/libraries/go/taxi"
@@ -15,17 +18,21 @@ import (
 )
 
 //go:generate jrpc dmx.def
+//go:generate devicegen devices.json
 
 const serviceName = "dmx"
 
-type universeConfig struct {
-	UniverseNumber domain.UniverseNumber `envconfig:"UNIVERSE_NUMBER"`
-	OLAHost        string                `envconfig:"OLA_HOST"`
-	OLAPort        int                   `envconfig:"OLA_PORT"`
-}
-
 type config struct {
 	Universes []universeConfig `envconfig:"UNIVERSES"`
+
+	// ProfilesFile is the path to a JSON file
+	// of fixture profiles e.g. profiles.json
+	ProfilesFile string `envconfig:"optional,PROFILES_FILE"`
+
+	// ReconcileInterval is how often the values of OLA universes
+	// are checked against the service's values. If zero, the
+	// values are not checked.
+	ReconcileInterval time.Duration `envconfig:"optional,RECONCILE_INTERVAL"`
 }
 
 func main() {
@@ -44,19 +51,54 @@ func main() {
 func run(svc *bootstrap.Service, conf *config) error {
 	client := dmx.NewClient()
 
+	// Requests to OLA fail fast while it is unavailable
+	olaDoer := taxi.NewBreakerDoer(&http.Client{Timeout: dmx.OLATimeout}, taxi.DefaultBreakerPolicy)
+	healthz.RegisterCheck("ola", olaDoer.HealthCheck)
+
+	var processes []bootstrap.Process
 	for _, uc := range conf.Universes {
-		getSetter, err := dmx.NewOLAClient(uc.OLAHost, uc.OLAPort, uc.UniverseNumber)
+		getSetter, process, err := uc.getSetter(olaDoer)
+		if err != nil {
+			return oops.WithMessage(err, "failed to create %s client for universe %d", uc.Output, uc.UniverseNumber)
+		}
+
+		if uc.Output == outputOLA && conf.ReconcileInterval > 0 {
+			reconciler := dmx.NewReconciler(getSetter, conf.ReconcileInterval)
+			getSetter = reconciler
+			processes = append(processes, reconciler)
+		}
+
+		// The fader sends the frames of transitions
+		fader := dmx.NewFader(getSetter, dmx.DefaultFrameRate)
+		client.AddGetSetter(uc.UniverseNumber, fader)
+
+		processes = append(processes, fader)
+		if process != nil {
+			processes = append(processes, process)
+		}
+	}
+
+	// Retry requests to the device registry so that a
+	// blip during a deploy doesn't stop the service starting
+	dispatcher := taxi.NewClient().
+		WithRetryPolicy(taxi.DefaultRetryPolicy).
+		WithCircuitBreaker(taxi.DefaultBreakerPolicy)
+	healthz.RegisterCheck("upstreams", dispatcher.HealthCheck)
+
+	var profiles map[string]*domain.Profile
+	if conf.ProfilesFile != "" {
+		var err error
+		profiles, err = domain.LoadProfiles(conf.ProfilesFile)
 		if err != nil {
-			return oops.WithMessage(err, "failed to create OLA client")
+			return err
 		}
-		client.AddGetSetter(uc.UniverseNumber, getSetter)
 	}
 
-	dispatcher := taxi.NewClient()
 	repo, err := repository.Init(
 		context.Background(),
 		serviceName,
 		deviceregistrydef.NewClient(dispatcher),
+		profiles,
 	)
 	if err != nil {
 		return err
@@ -66,8 +108,9 @@ func run(svc *bootstrap.Service, conf *config) error {
 		Repository: repo,
 		Client:     client,
 		Publisher:  svc.FirehosePublisher(),
+		Masters:    domain.NewMasters(),
 	})
 
-	svc.Run()
+	svc.Run(processes...)
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 // FixtureRepository holds an in-memory collection of fixtures
 type FixtureRepository struct {
 	fixtures map[string]domain.Fixture
+	groups   map[string]*domain.Group
 }
 
 // Init loads devices from the device registry and populates a new repository
@@ -18,6 +19,7 @@ func Init(
 	ctx context.Context,
 	serviceName string,
 	deviceRegistry deviceregistrydef.DeviceRegistryService,
+	profiles map[string]*domain.Profile,
 ) (*FixtureRepository, error) {
 	// Load devices from the registry
 	rsp, err := deviceRegistry.ListDevices(ctx, &deviceregistrydef.ListDevicesRequest{
@@ -38,7 +40,7 @@ func Init(
 			return nil, oops.InternalService("device %s is not for this controller", header.GetId())
 		}
 
-		fixture, err := domain.NewFixture(header)
+		fixture, err := domain.NewFixture(header, profiles)
 		if err != nil {
 			return nil, oops.WithMessage(err, "failed to create fixture")
 		}
@@ -61,6 +63,7 @@ func New(fixtures ...domain.Fixture) *FixtureRepository {
 	}
 	return &FixtureRepository{
 		fixtures: m,
+		groups:   domain.NewGroups(fixtures),
 	}
 }
 
@@ -73,6 +76,23 @@ func (r *FixtureRepository) Find(id string) domain.Fixture {
 	return nil
 }
 
+// FindGroup returns the group with the specified ID or nil if it doesn't exist
+func (r *FixtureRepository) FindGroup(id string) *domain.Group {
+	return r.groups[id]
+}
+
+// FindByUniverse returns all fixtures in the universe
+func (r *FixtureRepository) FindByUniverse(un domain.UniverseNumber) []domain.Fixture {
+	var fixtures []domain.Fixture
+	for _, f := range r.fixtures {
+		if f.UniverseNumber() == un {
+			fixtures = append(fixtures, f)
+		}
+	}
+
+	return fixtures
+}
+
 func validate(fs []domain.Fixture) error {
 	m := map[domain.UniverseNumber][]domain.Fixture{}
 
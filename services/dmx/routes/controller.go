@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"context"
+	"time"
+
+	"github.com/jakewright/home-automation/libraries/go/distsync"
+	"github.com/jakewright/home-automation/libraries/go/firehose"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
+	"github.com/jakewright/home-automation/services/dmx/dmx"
+	"github.com/jakewright/home-automation/services/dmx/domain"
+	"github.com/jakewright/home-automation/services/dmx/repository"
+)
+
+// Controller handles requests
+type Controller struct {
+	Repository *repository.FixtureRepository
+	Client     *dmx.Client
+	Publisher  firehose.Publisher
+	Masters    *domain.Masters
+}
+
+// fixtureSession holds the lock on a fixture and
+// the universe that was used to hydrate it
+type fixtureSession struct {
+	fixture  domain.Fixture
+	universe *domain.Universe
+	client   *dmx.Client
+	lock     distsync.Locker
+}
+
+// openDevice locks the fixture and hydrates it with the current DMX values
+func (c *Controller) openDevice(ctx context.Context, id string) (deviceSession, error) {
+	lock, err := distsync.Lock(ctx, "device", id)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := c.hydrate(ctx, id)
+	if err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+
+	s.lock = lock
+	return s, nil
+}
+
+// hydrate finds the fixture and hydrates it with the values of its universe
+func (c *Controller) hydrate(ctx context.Context, id string) (*fixtureSession, error) {
+	f := c.Repository.Find(id)
+	if f == nil {
+		return nil, oops.NotFound("device %q not found", id)
+	}
+
+	values, err := c.Client.GetValues(ctx, f.UniverseNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	// Instantiating a universe will hydrate the fixture
+	u, err := domain.NewUniverse(values, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fixtureSession{
+		fixture:  f,
+		universe: u,
+		client:   c.Client,
+	}, nil
+}
+
+// Device returns the hydrated fixture
+func (s *fixtureSession) Device() domain.Device {
+	return s.fixture
+}
+
+// Commit sends the universe's values, including the fixture's
+// new state, to the DMX client. If the request has a transition,
+// the fixture's continuous channels fade to their new values.
+func (s *fixtureSession) Commit(ctx context.Context, req interface{}) error {
+	var t *dmx.Transition
+	if r, ok := req.(transitionRequest); ok {
+		if def, set := r.GetTransition(); set {
+			t = transition(&def)
+		}
+	}
+
+	values := s.universe.DMXValues()
+	interpolations := s.universe.Interpolations()
+	if err := s.client.Fade(ctx, s.fixture.UniverseNumber(), values, interpolations, t); err != nil {
+		return oops.WithMessage(err, "failed to set DMX values")
+	}
+
+	return nil
+}
+
+// Close releases the lock on the fixture
+func (s *fixtureSession) Close() {
+	s.lock.Unlock()
+}
+
+// transitionRequest is implemented by update requests that have a transition
+type transitionRequest interface {
+	GetTransition() (dmxdef.Transition, bool)
+}
+
+// transition converts a transition from a request
+func transition(def *dmxdef.Transition) *dmx.Transition {
+	ms, _ := def.GetDurationMs()
+	t := &dmx.Transition{
+		Duration: time.Duration(ms) * time.Millisecond,
+	}
+
+	easing, _ := def.GetEasing()
+	switch easing {
+	case dmxdef.Easing_EASE_IN:
+		t.Easing = dmx.EaseIn
+	case dmxdef.Easing_EASE_OUT:
+		t.Easing = dmx.EaseOut
+	case dmxdef.Easing_EASE_IN_OUT:
+		t.Easing = dmx.EaseInOut
+	default:
+		t.Easing = dmx.EaseLinear
+	}
+
+	return t
+}
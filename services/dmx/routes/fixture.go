@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"context"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
+	"github.com/jakewright/home-automation/services/dmx/domain"
+)
+
+// GetFixture returns the current state of a fixture that is described by a profile
+func (c *Controller) GetFixture(ctx context.Context, body *dmxdef.GetFixtureRequest) (*dmxdef.FixtureResponse, error) {
+	s, f, err := c.openProfileFixture(ctx, body.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	return fixtureResponse(f), nil
+}
+
+// UpdateFixture applies the state in the request to a fixture that is described by a profile
+func (c *Controller) UpdateFixture(ctx context.Context, body *dmxdef.UpdateFixtureRequest) (*dmxdef.FixtureResponse, error) {
+	errParams := map[string]string{
+		"device_id": body.GetDeviceId(),
+	}
+
+	s, f, err := c.openProfileFixture(ctx, body.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	before := f.State()
+
+	if err := f.ApplyState(body.State); err != nil {
+		return nil, oops.WithMetadata(err, errParams)
+	}
+
+	if err := s.Commit(ctx, body); err != nil {
+		return nil, oops.WithMessage(err, "failed to commit device state", errParams)
+	}
+
+	c.publishStateChange(ctx, f, before, f.State())
+
+	return fixtureResponse(f), nil
+}
+
+// openProfileFixture opens the device and checks that it is described by a profile
+func (c *Controller) openProfileFixture(ctx context.Context, id string) (deviceSession, *domain.ProfileFixture, error) {
+	s, err := c.openDevice(ctx, id)
+	if err != nil {
+		return nil, nil, oops.WithMetadata(err, map[string]string{
+			"device_id": id,
+		})
+	}
+
+	f, ok := s.Device().(*domain.ProfileFixture)
+	if !ok {
+		s.Close()
+		return nil, nil, oops.BadRequest("device %q is not described by a fixture profile", id)
+	}
+
+	return s, f, nil
+}
+
+func fixtureResponse(f *domain.ProfileFixture) *dmxdef.FixtureResponse {
+	return &dmxdef.FixtureResponse{
+		Header:     f.DeviceHeader(),
+		Properties: f.Properties(),
+		State:      f.State(),
+	}
+}
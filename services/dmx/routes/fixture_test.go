@@ -0,0 +1,80 @@
+package routes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
+	"github.com/jakewright/home-automation/services/dmx/dmx"
+	"github.com/jakewright/home-automation/services/dmx/repository"
+)
+
+func TestController_UpdateFixture(t *testing.T) {
+	f := newTestFixture(t, "fixture 1", "dimmer", 3)
+
+	client := dmx.NewClient()
+	getSetter := &dmx.MockGetSetter{}
+	client.AddGetSetter(1, getSetter)
+
+	publisher := &recordingPublisher{}
+	c := &Controller{
+		Repository: repository.New(f),
+		Client:     client,
+		Publisher:  publisher,
+	}
+
+	rsp, err := c.UpdateFixture(context.Background(), (&dmxdef.UpdateFixtureRequest{
+		State: map[string]interface{}{"brightness": float64(50)},
+	}).SetDeviceId("fixture 1"))
+	require.NoError(t, err)
+
+	require.Equal(t, int64(50), rsp.State["brightness"])
+	require.Equal(t, byte(128), getSetter.Values[3])
+	require.Len(t, publisher.events, 1)
+
+	_, err = c.UpdateFixture(context.Background(), (&dmxdef.UpdateFixtureRequest{
+		State: map[string]interface{}{"brightness": float64(101)},
+	}).SetDeviceId("fixture 1"))
+	require.True(t, oops.Is(err, oops.ErrBadRequest))
+	require.Len(t, publisher.events, 1)
+
+	// Fixtures described by a profile are not available through the typed RPCs
+	_, err = c.GetMegaParProfile(context.Background(), (&dmxdef.GetMegaParProfileRequest{}).SetDeviceId("fixture 1"))
+	require.True(t, oops.Is(err, oops.ErrBadRequest))
+}
+
+func TestController_UpdateFixture_transition(t *testing.T) {
+	f := newTestFixture(t, "fixture 1", "dimmer_8bit", 0)
+
+	getSetter := &dmx.MockGetSetter{}
+	client := dmx.NewClient()
+	client.AddGetSetter(1, dmx.NewFader(getSetter, 0))
+
+	c := &Controller{
+		Repository: repository.New(f),
+		Client:     client,
+		Publisher:  &recordingPublisher{},
+	}
+
+	rsp, err := c.UpdateFixture(context.Background(), (&dmxdef.UpdateFixtureRequest{
+		State: map[string]interface{}{"brightness": float64(255)},
+	}).
+		SetDeviceId("fixture 1").
+		SetTransition(*(&dmxdef.Transition{}).
+			SetDurationMs(60000).
+			SetEasing(dmxdef.Easing_EASE_IN_OUT),
+		))
+	require.NoError(t, err)
+
+	// The new state is returned but the channel is still fading
+	require.Equal(t, int64(255), rsp.State["brightness"])
+	require.Less(t, getSetter.Values[0], byte(255))
+
+	// The fixture is hydrated with the target value
+	rsp, err = c.GetFixture(context.Background(), (&dmxdef.GetFixtureRequest{}).SetDeviceId("fixture 1"))
+	require.NoError(t, err)
+	require.Equal(t, int64(255), rsp.State["brightness"])
+}
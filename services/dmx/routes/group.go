@@ -0,0 +1,199 @@
+package routes
+
+import (
+	"context"
+	"sort"
+
+	"github.com/jakewright/home-automation/libraries/go/distsync"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/slog"
+	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
+	"github.com/jakewright/home-automation/services/dmx/dmx"
+	"github.com/jakewright/home-automation/services/dmx/domain"
+)
+
+// GetGroup returns the fixtures in a group and the level of its master
+func (c *Controller) GetGroup(ctx context.Context, body *dmxdef.GetGroupRequest) (*dmxdef.GroupResponse, error) {
+	g := c.Repository.FindGroup(body.GetGroupId())
+	if g == nil {
+		return nil, oops.NotFound("group %q not found", body.GetGroupId())
+	}
+
+	c.Masters.Lock()
+	defer c.Masters.Unlock()
+
+	return groupResponse(g, c.Masters.Group(g.ID)), nil
+}
+
+// UpdateGroup applies the state in the request to every fixture in a group
+// and sets the level of the group's master. The fixtures in each universe
+// are updated with a single write.
+func (c *Controller) UpdateGroup(ctx context.Context, body *dmxdef.UpdateGroupRequest) (*dmxdef.GroupResponse, error) {
+	errParams := map[string]string{
+		"group_id": body.GetGroupId(),
+	}
+
+	g := c.Repository.FindGroup(body.GetGroupId())
+	if g == nil {
+		return nil, oops.NotFound("group %q not found", body.GetGroupId())
+	}
+
+	var t *dmx.Transition
+	if def, set := body.GetTransition(); set {
+		t = transition(&def)
+	}
+
+	if state, _ := body.GetState(); len(state) > 0 {
+		if err := c.applyGroupState(ctx, g, state, t); err != nil {
+			return nil, oops.WithMetadata(err, errParams)
+		}
+	}
+
+	c.Masters.Lock()
+	defer c.Masters.Unlock()
+
+	if master, set := body.GetMaster(); set {
+		universes := universeNumbers(g.Fixtures)
+		previous := c.Masters.Group(g.ID)
+		c.Masters.SetGroup(g.ID, uint8(master))
+
+		if err := c.applyMasters(ctx, universes, t); err != nil {
+			c.Masters.SetGroup(g.ID, previous)
+			c.restoreMasters(ctx, universes)
+			return nil, oops.WithMetadata(err, errParams)
+		}
+	}
+
+	return groupResponse(g, c.Masters.Group(g.ID)), nil
+}
+
+// applyGroupState locks all of the group's fixtures, applies the state to
+// them and then writes each universe. Nothing is written unless the state
+// can be applied to every fixture, but the universes are written one at a
+// time so if a write fails, the universes before it keep the new state.
+func (c *Controller) applyGroupState(ctx context.Context, g *domain.Group, state map[string]interface{}, t *dmx.Transition) error {
+	// The fixtures are sorted by ID so the locks are always taken in the same order
+	var locks []distsync.Locker
+	defer func() {
+		for _, lock := range locks {
+			lock.Unlock()
+		}
+	}()
+
+	for _, f := range g.Fixtures {
+		lock, err := distsync.Lock(ctx, "device", f.ID())
+		if err != nil {
+			return err
+		}
+		locks = append(locks, lock)
+	}
+
+	universes := make(map[domain.UniverseNumber]*domain.Universe)
+	before := make(map[string]interface{}, len(g.Fixtures))
+
+	for _, un := range universeNumbers(g.Fixtures) {
+		var fixtures []domain.Fixture
+		for _, f := range g.Fixtures {
+			if f.UniverseNumber() == un {
+				fixtures = append(fixtures, f)
+			}
+		}
+
+		values, err := c.Client.GetValues(ctx, un)
+		if err != nil {
+			return err
+		}
+
+		// Instantiating a universe will hydrate the fixtures
+		u, err := domain.NewUniverse(values, fixtures...)
+		if err != nil {
+			return err
+		}
+
+		universes[un] = u
+	}
+
+	for _, f := range g.Fixtures {
+		before[f.ID()] = fixtureState(f)
+
+		if err := domain.ApplyState(f, state); err != nil {
+			return oops.WithMetadata(err, map[string]string{
+				"device_id": f.ID(),
+			})
+		}
+	}
+
+	for un, u := range universes {
+		if err := c.Client.Fade(ctx, un, u.DMXValues(), u.Interpolations(), t); err != nil {
+			return oops.WithMessage(err, "failed to set DMX values of universe %d", un)
+		}
+	}
+
+	for _, f := range g.Fixtures {
+		c.publishStateChange(ctx, f, before[f.ID()], fixtureState(f))
+	}
+
+	return nil
+}
+
+// applyMasters sets the scale of the universes' channels
+// to the levels of the masters. The masters must be locked.
+func (c *Controller) applyMasters(ctx context.Context, universes []domain.UniverseNumber, t *dmx.Transition) error {
+	for _, un := range universes {
+		scale := c.Masters.Scale(un, c.Repository.FindByUniverse(un))
+		if err := c.Client.SetScale(ctx, un, scale, t); err != nil {
+			return oops.WithMessage(err, "failed to scale universe %d", un)
+		}
+	}
+
+	return nil
+}
+
+// restoreMasters scales the universes by the masters' levels after an
+// update to a master failed and its previous level was restored. This
+// undoes the scaling of any universes that were written before the
+// failure. The masters must be locked.
+func (c *Controller) restoreMasters(ctx context.Context, universes []domain.UniverseNumber) {
+	if err := c.applyMasters(ctx, universes, nil); err != nil {
+		slog.FromContext(ctx).Errorf("Failed to restore master levels: %v", err)
+	}
+}
+
+// fixtureState returns the state of a fixture of any type
+func fixtureState(f domain.Fixture) interface{} {
+	if pf, ok := f.(*domain.ProfileFixture); ok {
+		return pf.State()
+	}
+
+	return deviceState(f)
+}
+
+// universeNumbers returns the sorted universe numbers of the fixtures
+func universeNumbers(fixtures []domain.Fixture) []domain.UniverseNumber {
+	seen := make(map[domain.UniverseNumber]bool)
+	var universes []domain.UniverseNumber
+	for _, f := range fixtures {
+		if !seen[f.UniverseNumber()] {
+			seen[f.UniverseNumber()] = true
+			universes = append(universes, f.UniverseNumber())
+		}
+	}
+
+	sort.Slice(universes, func(i, j int) bool {
+		return universes[i] < universes[j]
+	})
+
+	return universes
+}
+
+func groupResponse(g *domain.Group, master uint8) *dmxdef.GroupResponse {
+	ids := make([]string, len(g.Fixtures))
+	for i, f := range g.Fixtures {
+		ids[i] = f.ID()
+	}
+
+	return (&dmxdef.GroupResponse{}).
+		SetId(g.ID).
+		SetDeviceIds(ids).
+		SetMaster(uint32(master))
+}
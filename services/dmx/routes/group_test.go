@@ -0,0 +1,171 @@
+package routes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
+	"github.com/jakewright/home-automation/services/dmx/dmx"
+	"github.com/jakewright/home-automation/services/dmx/domain"
+	"github.com/jakewright/home-automation/services/dmx/repository"
+)
+
+// countingGetSetter counts the number of times the values
+// are set. If err is set, setting the values fails.
+type countingGetSetter struct {
+	dmx.MockGetSetter
+	sets int
+	err  error
+}
+
+func (c *countingGetSetter) SetValues(ctx context.Context, values [512]byte) error {
+	c.sets++
+	if c.err != nil {
+		return c.err
+	}
+	return c.MockGetSetter.SetValues(ctx, values)
+}
+
+func newGroupController(t *testing.T) (*Controller, *countingGetSetter, *recordingPublisher) {
+	var fixtures []domain.Fixture
+	for i, id := range []string{"fixture 1", "fixture 2", "fixture 3"} {
+		// The last fixture isn't in the group
+		var groups []string
+		if i < 2 {
+			groups = []string{"stage"}
+		}
+
+		fixtures = append(fixtures, newTestFixture(t, id, "mega_par_profile", i*7, groups...))
+	}
+
+	getSetter := &countingGetSetter{}
+	client := dmx.NewClient()
+	client.AddGetSetter(1, getSetter)
+
+	publisher := &recordingPublisher{}
+	return &Controller{
+		Repository: repository.New(fixtures...),
+		Client:     client,
+		Publisher:  publisher,
+		Masters:    domain.NewMasters(),
+	}, getSetter, publisher
+}
+
+func TestController_UpdateGroup(t *testing.T) {
+	c, getSetter, publisher := newGroupController(t)
+
+	rsp, err := c.UpdateGroup(context.Background(), (&dmxdef.UpdateGroupRequest{}).
+		SetGroupId("stage").
+		SetState(map[string]interface{}{"brightness": float64(200)}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"fixture 1", "fixture 2"}, rsp.DeviceIds)
+
+	// Both fixtures are updated with a single write
+	require.Equal(t, 1, getSetter.sets)
+	require.Equal(t, byte(200), getSetter.Values[6])
+	require.Equal(t, byte(200), getSetter.Values[13])
+	require.Equal(t, byte(0), getSetter.Values[20])
+	require.Len(t, publisher.events, 2)
+
+	// Nothing is written if the state can't be applied to every fixture
+	_, err = c.UpdateGroup(context.Background(), (&dmxdef.UpdateGroupRequest{}).
+		SetGroupId("stage").
+		SetState(map[string]interface{}{"brightness": float64(300)}))
+	require.True(t, oops.Is(err, oops.ErrBadRequest))
+	require.Equal(t, 1, getSetter.sets)
+
+	_, err = c.UpdateGroup(context.Background(), (&dmxdef.UpdateGroupRequest{}).SetGroupId("back"))
+	require.True(t, oops.Is(err, oops.ErrNotFound))
+}
+
+func TestController_UpdateGroup_master(t *testing.T) {
+	c, getSetter, _ := newGroupController(t)
+	ctx := context.Background()
+
+	_, err := c.UpdateGroup(ctx, (&dmxdef.UpdateGroupRequest{}).
+		SetGroupId("stage").
+		SetState(map[string]interface{}{"brightness": float64(200)}))
+	require.NoError(t, err)
+
+	rsp, err := c.UpdateGroup(ctx, (&dmxdef.UpdateGroupRequest{}).
+		SetGroupId("stage").
+		SetMaster(50))
+	require.NoError(t, err)
+	require.Equal(t, uint32(50), *rsp.Master)
+	require.Equal(t, byte(100), getSetter.Values[6])
+	require.Equal(t, byte(100), getSetter.Values[13])
+
+	// The universe's master is applied on top of the group's
+	_, err = c.UpdateUniverse(ctx, (&dmxdef.UpdateUniverseRequest{}).
+		SetUniverseNumber(1).
+		SetMaster(50))
+	require.NoError(t, err)
+	require.Equal(t, byte(50), getSetter.Values[6])
+
+	// The fixtures' own values are kept
+	fixture, err := c.GetMegaParProfile(ctx, (&dmxdef.GetMegaParProfileRequest{}).SetDeviceId("fixture 1"))
+	require.NoError(t, err)
+	brightness, _ := fixture.State.GetBrightness()
+	require.Equal(t, byte(200), brightness)
+
+	// Updating a fixture is still scaled by the masters
+	_, err = c.UpdateMegaParProfile(ctx, (&dmxdef.UpdateMegaParProfileRequest{
+		State: (&dmxdef.MegaParProfileState{}).SetBrightness(100),
+	}).SetDeviceId("fixture 1"))
+	require.NoError(t, err)
+	require.Equal(t, byte(25), getSetter.Values[6])
+
+	_, err = c.UpdateUniverse(ctx, (&dmxdef.UpdateUniverseRequest{}).
+		SetUniverseNumber(1).
+		SetMaster(100))
+	require.NoError(t, err)
+	require.Equal(t, byte(50), getSetter.Values[6])
+	require.Equal(t, byte(100), getSetter.Values[13])
+
+	_, err = c.GetUniverse(ctx, (&dmxdef.GetUniverseRequest{}).SetUniverseNumber(2))
+	require.True(t, oops.Is(err, oops.ErrNotFound))
+}
+
+func TestController_UpdateGroup_masterFailure(t *testing.T) {
+	c, getSetter, _ := newGroupController(t)
+	ctx := context.Background()
+
+	_, err := c.UpdateGroup(ctx, (&dmxdef.UpdateGroupRequest{}).
+		SetGroupId("stage").
+		SetState(map[string]interface{}{"brightness": float64(200)}))
+	require.NoError(t, err)
+
+	// The masters keep their levels if the universes can't be scaled
+	getSetter.err = oops.Unavailable("universe is offline")
+
+	_, err = c.UpdateGroup(ctx, (&dmxdef.UpdateGroupRequest{}).
+		SetGroupId("stage").
+		SetMaster(50))
+	require.True(t, oops.Is(err, oops.ErrUnavailable))
+
+	_, err = c.UpdateUniverse(ctx, (&dmxdef.UpdateUniverseRequest{}).
+		SetUniverseNumber(1).
+		SetMaster(50))
+	require.True(t, oops.Is(err, oops.ErrUnavailable))
+
+	group, err := c.GetGroup(ctx, (&dmxdef.GetGroupRequest{}).SetGroupId("stage"))
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), *group.Master)
+
+	universe, err := c.GetUniverse(ctx, (&dmxdef.GetUniverseRequest{}).SetUniverseNumber(1))
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), *universe.Master)
+
+	// The next write isn't scaled by the levels that failed
+	getSetter.err = nil
+
+	_, err = c.UpdateMegaParProfile(ctx, (&dmxdef.UpdateMegaParProfileRequest{
+		State: (&dmxdef.MegaParProfileState{}).SetBrightness(100),
+	}).SetDeviceId("fixture 1"))
+	require.NoError(t, err)
+	require.Equal(t, byte(100), getSetter.Values[6])
+	require.Equal(t, byte(200), getSetter.Values[13])
+}
@@ -8,7 +8,9 @@ import (
 
 	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
 	"github.com/jakewright/home-automation/libraries/go/firehose"
+	"github.com/jakewright/home-automation/libraries/go/oops"
 	"github.com/jakewright/home-automation/libraries/go/util"
+	deviceregistrydef "github.com/jakewright/home-automation/services/device-registry/def"
 	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
 	"github.com/jakewright/home-automation/services/dmx/dmx"
 	"github.com/jakewright/home-automation/services/dmx/domain"
@@ -16,21 +18,22 @@ import (
 )
 
 func TestController_Update(t *testing.T) {
-	// Create a fixture
-	f, err := domain.NewFixture((&devicedef.Header{}).
-		SetId("fixture 1").
-		SetName("Fixture 1").
-		SetType("dmx").
-		SetKind("dmx").
-		SetControllerName("service.dmx").
-		SetAttributes(map[string]interface{}{
-			"fixture_type": "mega_par_profile",
-			"universe":     float64(1),
-			"offset":       float64(0),
-		}),
-	)
+	// Load a fixture from the device registry
+	registry := deviceregistrydef.NewMockService()
+	registry.OnListDevices().Returns((&deviceregistrydef.ListDevicesResponse{}).
+		SetDeviceHeaders([]*devicedef.Header{
+			newTestHeader("fixture 1", "mega_par_profile", 0),
+		}), nil)
+
+	repo, err := repository.Init(context.Background(), "service.dmx", registry, nil)
 	require.NoError(t, err)
 
+	requests := registry.OnListDevices().Requests()
+	require.Len(t, requests, 1)
+	controllerName, _ := requests[0].GetControllerName()
+	require.Equal(t, "service.dmx", controllerName)
+
+	f := repo.Find("fixture 1")
 	megaParProfile, ok := f.(*domain.MegaParProfile)
 	require.True(t, ok)
 
@@ -42,9 +45,6 @@ func TestController_Update(t *testing.T) {
 		SetBrightness(0),
 	)
 
-	// Create a repository with the fixture
-	repo := repository.New(f)
-
 	client := dmx.NewClient()
 	getSetter := &dmx.MockGetSetter{}
 	client.AddGetSetter(1, getSetter)
@@ -73,7 +73,7 @@ func TestController_Update(t *testing.T) {
 
 	brightness, set := rsp.State.GetBrightness()
 	require.Equal(t, true, set)
-	require.Equal(t, 100, brightness)
+	require.Equal(t, uint8(100), brightness)
 
 	color, set := rsp.State.GetColor()
 	require.Equal(t, true, set)
@@ -81,8 +81,86 @@ func TestController_Update(t *testing.T) {
 
 	strobe, set := rsp.State.GetStrobe()
 	require.Equal(t, true, set)
-	require.Equal(t, 50, strobe)
+	require.Equal(t, uint8(50), strobe)
 
 	expectedValues := [512]byte{0, 255, 0, 0, 50, 0, 100}
 	require.Equal(t, expectedValues, getSetter.Values)
 }
+
+type recordingPublisher struct {
+	events []*devicedef.DeviceStateChangedEvent
+	err    error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, channel string, message interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	if channel == "device-state-changed" {
+		p.events = append(p.events, message.(*devicedef.DeviceStateChangedEvent))
+	}
+	return nil
+}
+
+func TestController_Update_publishesStateChanges(t *testing.T) {
+	f := newTestFixture(t, "fixture 1", "mega_par_profile", 0)
+
+	client := dmx.NewClient()
+	client.AddGetSetter(1, &dmx.MockGetSetter{})
+
+	publisher := &recordingPublisher{}
+	c := &Controller{
+		Repository: repository.New(f),
+		Client:     client,
+		Publisher:  publisher,
+	}
+
+	update := func(state *dmxdef.MegaParProfileState) {
+		_, err := c.UpdateMegaParProfile(context.Background(), (&dmxdef.UpdateMegaParProfileRequest{
+			State: state,
+		}).SetDeviceId("fixture 1"))
+		require.NoError(t, err)
+	}
+
+	update((&dmxdef.MegaParProfileState{}).SetBrightness(100))
+	require.Len(t, publisher.events, 1)
+	require.Equal(t, "fixture 1", publisher.events[0].Header.GetId())
+	_, set := publisher.events[0].GetChangedAt()
+	require.True(t, set)
+
+	state, ok := publisher.events[0].GetState().(*dmxdef.MegaParProfileState)
+	require.True(t, ok)
+	brightness, _ := state.GetBrightness()
+	require.Equal(t, uint8(100), brightness)
+
+	// Applying the same state again doesn't publish an event
+	update((&dmxdef.MegaParProfileState{}).SetBrightness(100))
+	require.Len(t, publisher.events, 1)
+
+	update((&dmxdef.MegaParProfileState{}).SetStrobe(50))
+	require.Len(t, publisher.events, 2)
+}
+
+func TestController_Update_publishFailure(t *testing.T) {
+	f := newTestFixture(t, "fixture 1", "mega_par_profile", 0)
+
+	getSetter := &dmx.MockGetSetter{}
+	client := dmx.NewClient()
+	client.AddGetSetter(1, getSetter)
+
+	c := &Controller{
+		Repository: repository.New(f),
+		Client:     client,
+		Publisher:  &recordingPublisher{err: oops.Unavailable("firehose is down")},
+	}
+
+	// The values have been written so the request succeeds
+	rsp, err := c.UpdateMegaParProfile(context.Background(), (&dmxdef.UpdateMegaParProfileRequest{
+		State: (&dmxdef.MegaParProfileState{}).SetBrightness(100),
+	}).SetDeviceId("fixture 1"))
+	require.NoError(t, err)
+
+	brightness, _ := rsp.State.GetBrightness()
+	require.Equal(t, uint8(100), brightness)
+	require.Equal(t, byte(100), getSetter.Values[6])
+}
@@ -17,6 +17,12 @@ type taxiRouter interface {
 type handler interface {
 	GetMegaParProfile(ctx context.Context, body *def.GetMegaParProfileRequest) (*def.MegaParProfileResponse, error)
 	UpdateMegaParProfile(ctx context.Context, body *def.UpdateMegaParProfileRequest) (*def.MegaParProfileResponse, error)
+	GetFixture(ctx context.Context, body *def.GetFixtureRequest) (*def.FixtureResponse, error)
+	UpdateFixture(ctx context.Context, body *def.UpdateFixtureRequest) (*def.FixtureResponse, error)
+	GetGroup(ctx context.Context, body *def.GetGroupRequest) (*def.GroupResponse, error)
+	UpdateGroup(ctx context.Context, body *def.UpdateGroupRequest) (*def.GroupResponse, error)
+	GetUniverse(ctx context.Context, body *def.GetUniverseRequest) (*def.UniverseResponse, error)
+	UpdateUniverse(ctx context.Context, body *def.UpdateUniverseRequest) (*def.UniverseResponse, error)
 }
 
 // Register adds the service's routes to the router
@@ -47,4 +53,82 @@ func Register(r taxiRouter, h handler) {
 		return h.UpdateMegaParProfile(ctx, body)
 	})
 
+	r.HandleFunc("GET", "/fixture", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def.GetFixtureRequest{}
+		if err := decode(body); err != nil {
+			return nil, err
+		}
+
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+
+		return h.GetFixture(ctx, body)
+	})
+
+	r.HandleFunc("PATCH", "/fixture", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def.UpdateFixtureRequest{}
+		if err := decode(body); err != nil {
+			return nil, err
+		}
+
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+
+		return h.UpdateFixture(ctx, body)
+	})
+
+	r.HandleFunc("GET", "/group", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def.GetGroupRequest{}
+		if err := decode(body); err != nil {
+			return nil, err
+		}
+
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+
+		return h.GetGroup(ctx, body)
+	})
+
+	r.HandleFunc("PATCH", "/group", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def.UpdateGroupRequest{}
+		if err := decode(body); err != nil {
+			return nil, err
+		}
+
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+
+		return h.UpdateGroup(ctx, body)
+	})
+
+	r.HandleFunc("GET", "/universe", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def.GetUniverseRequest{}
+		if err := decode(body); err != nil {
+			return nil, err
+		}
+
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+
+		return h.GetUniverse(ctx, body)
+	})
+
+	r.HandleFunc("PATCH", "/universe", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def.UpdateUniverseRequest{}
+		if err := decode(body); err != nil {
+			return nil, err
+		}
+
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+
+		return h.UpdateUniverse(ctx, body)
+	})
+
 }
@@ -2,12 +2,71 @@ package routes
 
 import (
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/jakewright/home-automation/libraries/go/bootstrap"
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/services/dmx/domain"
 )
 
 func TestMain(m *testing.M) {
 	bootstrap.SetupTest()
 	os.Exit(m.Run())
 }
+
+// testProfiles are the fixture profiles available to test fixtures
+const testProfiles = `{
+	"dimmer": {
+		"channels": [{ "role": "dimmer" }],
+		"properties": {
+			"brightness": { "type": "int", "role": "dimmer", "min": 0, "max": 100 }
+		}
+	},
+	"dimmer_8bit": {
+		"channels": [{ "role": "dimmer" }],
+		"properties": {
+			"brightness": { "type": "int", "role": "dimmer", "min": 0, "max": 255 }
+		}
+	}
+}`
+
+// newTestHeader returns the device header of a
+// fixture in universe 1 that is in the groups
+func newTestHeader(id, fixtureType string, offset int, groups ...string) *devicedef.Header {
+	attributes := map[string]interface{}{
+		"fixture_type": fixtureType,
+		"universe":     float64(1),
+		"offset":       float64(offset),
+	}
+
+	if len(groups) > 0 {
+		ids := make([]interface{}, len(groups))
+		for i, g := range groups {
+			ids[i] = g
+		}
+		attributes["groups"] = ids
+	}
+
+	return (&devicedef.Header{}).
+		SetId(id).
+		SetName(id).
+		SetType("dmx").
+		SetKind("dmx").
+		SetControllerName("service.dmx").
+		SetAttributes(attributes)
+}
+
+// newTestFixture returns a fixture in universe 1 that is in the groups. The
+// fixture type can be mega_par_profile or a profile in testProfiles.
+func newTestFixture(t *testing.T, id, fixtureType string, offset int, groups ...string) domain.Fixture {
+	profiles, err := domain.ParseProfiles(strings.NewReader(testProfiles))
+	require.NoError(t, err)
+
+	f, err := domain.NewFixture(newTestHeader(id, fixtureType, offset, groups...), profiles)
+	require.NoError(t, err)
+
+	return f
+}
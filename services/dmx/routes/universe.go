@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"context"
+
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	dmxdef "github.com/jakewright/home-automation/services/dmx/def"
+	"github.com/jakewright/home-automation/services/dmx/dmx"
+	"github.com/jakewright/home-automation/services/dmx/domain"
+)
+
+// GetUniverse returns the level of a universe's master
+func (c *Controller) GetUniverse(ctx context.Context, body *dmxdef.GetUniverseRequest) (*dmxdef.UniverseResponse, error) {
+	un, err := c.findUniverse(body.GetUniverseNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	c.Masters.Lock()
+	defer c.Masters.Unlock()
+
+	return universeResponse(un, c.Masters.Universe(un)), nil
+}
+
+// UpdateUniverse sets the level of a universe's master
+func (c *Controller) UpdateUniverse(ctx context.Context, body *dmxdef.UpdateUniverseRequest) (*dmxdef.UniverseResponse, error) {
+	un, err := c.findUniverse(body.GetUniverseNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	var t *dmx.Transition
+	if def, set := body.GetTransition(); set {
+		t = transition(&def)
+	}
+
+	c.Masters.Lock()
+	defer c.Masters.Unlock()
+
+	if master, set := body.GetMaster(); set {
+		universes := []domain.UniverseNumber{un}
+		previous := c.Masters.Universe(un)
+		c.Masters.SetUniverse(un, uint8(master))
+
+		if err := c.applyMasters(ctx, universes, t); err != nil {
+			c.Masters.SetUniverse(un, previous)
+			c.restoreMasters(ctx, universes)
+			return nil, err
+		}
+	}
+
+	return universeResponse(un, c.Masters.Universe(un)), nil
+}
+
+// findUniverse returns an error if there are no fixtures in the universe
+func (c *Controller) findUniverse(n uint32) (domain.UniverseNumber, error) {
+	un := domain.UniverseNumber(n)
+	if len(c.Repository.FindByUniverse(un)) == 0 {
+		return 0, oops.NotFound("no fixtures found in universe %d", un)
+	}
+
+	return un, nil
+}
+
+func universeResponse(un domain.UniverseNumber, master uint8) *dmxdef.UniverseResponse {
+	return (&dmxdef.UniverseResponse{}).
+		SetUniverseNumber(uint32(un)).
+		SetMaster(uint32(master))
+}
@@ -0,0 +1,31 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import { Fetch, RPCClient } from "../../../libraries/typescript/rpc";
+import {
+  LogRequest,
+  LogResponse,
+  PanicRequest,
+  PanicResponse,
+} from "./types";
+
+export { RPCError } from "../../../libraries/typescript/rpc";
+
+// DummyClient makes requests to the Dummy service
+export class DummyClient extends RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(baseURL: string, fetch?: Fetch) {
+    super(baseURL, fetch);
+  }
+
+  // log makes a POST request to /log
+  log(body: LogRequest): Promise<LogResponse> {
+    return this.do("POST", "/log", body, false);
+  }
+
+  // panic makes a POST request to /panic
+  panic(body: PanicRequest): Promise<PanicResponse> {
+    return this.do("POST", "/panic", body, false);
+  }
+}
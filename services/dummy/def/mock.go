@@ -0,0 +1,204 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package dummydef
+
+import (
+	context "context"
+	sync "sync"
+	testing "testing"
+
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// MockService is an in-memory implementation of DummyService
+// that can be used in tests. Responses are programmed, and requests
+// inspected, via the mock of each RPC, e.g. m.OnLog().
+type MockService struct {
+	log   *LogMock
+	panic *PanicMock
+}
+
+// Compile-time assertion that the mock implements the interface
+var _ DummyService = (*MockService)(nil)
+
+// NewMockService returns a new mock with no responses programmed
+func NewMockService() *MockService {
+	return &MockService{
+		log:   &LogMock{},
+		panic: &PanicMock{},
+	}
+}
+
+// OnLog returns the mock of the Log RPC
+func (m *MockService) OnLog() *LogMock {
+	return m.log
+}
+
+// Log records the request and returns the programmed response
+func (m *MockService) Log(ctx context.Context, body *LogRequest) *LogFuture {
+	handler := m.log.record(body)
+
+	done := make(chan struct{})
+	ftr := &LogFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// LogMock programs and records calls to the Log RPC
+type LogMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *LogRequest) (*LogResponse, error)
+	requests []*LogRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *LogMock) Returns(rsp *LogResponse, err error) *LogMock {
+	return m.Handle(func(context.Context, *LogRequest) (*LogResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *LogMock) Handle(fn func(ctx context.Context, body *LogRequest) (*LogResponse, error)) *LogMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *LogMock) record(body *LogRequest) func(context.Context, *LogRequest) (*LogResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *LogRequest) (*LogResponse, error) {
+			return nil, oops.InternalService("no response programmed for Log")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *LogMock) Requests() []*LogRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*LogRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *LogMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected Log to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *LogMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected Log not to be called but it was called %d times", n)
+	}
+}
+
+// OnPanic returns the mock of the Panic RPC
+func (m *MockService) OnPanic() *PanicMock {
+	return m.panic
+}
+
+// Panic records the request and returns the programmed response
+func (m *MockService) Panic(ctx context.Context, body *PanicRequest) *PanicFuture {
+	handler := m.panic.record(body)
+
+	done := make(chan struct{})
+	ftr := &PanicFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// PanicMock programs and records calls to the Panic RPC
+type PanicMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *PanicRequest) (*PanicResponse, error)
+	requests []*PanicRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *PanicMock) Returns(rsp *PanicResponse, err error) *PanicMock {
+	return m.Handle(func(context.Context, *PanicRequest) (*PanicResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *PanicMock) Handle(fn func(ctx context.Context, body *PanicRequest) (*PanicResponse, error)) *PanicMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *PanicMock) record(body *PanicRequest) func(context.Context, *PanicRequest) (*PanicResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *PanicRequest) (*PanicResponse, error) {
+			return nil, oops.InternalService("no response programmed for Panic")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *PanicMock) Requests() []*PanicRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*PanicRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *PanicMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected Panic to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *PanicMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected Panic not to be called but it was called %d times", n)
+	}
+}
@@ -6,7 +6,8 @@ package dummydef
 type LogRequest struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *LogRequest) Validate() error {
 	return nil
 }
@@ -15,7 +16,8 @@ func (m *LogRequest) Validate() error {
 type LogResponse struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *LogResponse) Validate() error {
 	return nil
 }
@@ -24,7 +26,8 @@ func (m *LogResponse) Validate() error {
 type PanicRequest struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *PanicRequest) Validate() error {
 	return nil
 }
@@ -33,7 +36,8 @@ func (m *PanicRequest) Validate() error {
 type PanicResponse struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *PanicResponse) Validate() error {
 	return nil
 }
@@ -0,0 +1,17 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+// LogRequest is defined in the .def file
+export interface LogRequest {
+}
+
+// LogResponse is defined in the .def file
+export interface LogResponse {
+}
+
+// PanicRequest is defined in the .def file
+export interface PanicRequest {
+}
+
+// PanicResponse is defined in the .def file
+export interface PanicResponse {
+}
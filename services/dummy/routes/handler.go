@@ -12,7 +12,7 @@ type Controller struct{}
 
 // Log emits a log line
 func (c Controller) Log(ctx context.Context, body *def.LogRequest) (*def.LogResponse, error) {
-	slog.Infof("This is a log line", map[string]string{
+	slog.FromContext(ctx).Infof("This is a log line", map[string]string{
 		"foo": "bar",
 	})
 	return &def.LogResponse{}, nil
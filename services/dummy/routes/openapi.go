@@ -0,0 +1,222 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package routes
+
+import (
+	router "github.com/jakewright/home-automation/libraries/go/router"
+)
+
+func init() {
+	router.RegisterOpenAPI([]byte(openAPIDocument))
+}
+
+// openAPIDocument describes the service's RPCs in OpenAPI 3 format
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Dummy",
+    "version": "1.0.0"
+  },
+  "servers": [
+    {
+      "url": "http://dummy"
+    }
+  ],
+  "paths": {
+    "/log": {
+      "post": {
+        "operationId": "Log",
+        "tags": [
+          "Dummy"
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/LogRequest"
+              }
+            },
+            "application/msgpack": {
+              "schema": {
+                "$ref": "#/components/schemas/LogRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/LogResponse"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/LogResponse"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/panic": {
+      "post": {
+        "operationId": "Panic",
+        "tags": [
+          "Dummy"
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/PanicRequest"
+              }
+            },
+            "application/msgpack": {
+              "schema": {
+                "$ref": "#/components/schemas/PanicRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/PanicResponse"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/PanicResponse"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "LogRequest": {
+        "type": "object"
+      },
+      "LogResponse": {
+        "type": "object"
+      },
+      "PanicRequest": {
+        "type": "object"
+      },
+      "PanicResponse": {
+        "type": "object"
+      },
+      "taxi.Error": {
+        "type": "object",
+        "properties": {
+          "cause": {
+            "$ref": "#/components/schemas/taxi.RemoteError"
+          },
+          "code": {
+            "type": "string"
+          },
+          "error": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "metadata": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            }
+          },
+          "stack": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "error"
+        ]
+      },
+      "taxi.RemoteError": {
+        "type": "object",
+        "properties": {
+          "cause": {
+            "$ref": "#/components/schemas/taxi.RemoteError"
+          },
+          "code": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "metadata": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    }
+  }
+}`
@@ -4,39 +4,54 @@ package infrareddef
 
 import (
 	context "context"
-	"testing"
+	testing "testing"
 
+	def "github.com/jakewright/home-automation/libraries/go/device/def"
 	taxi "github.com/jakewright/home-automation/libraries/go/taxi"
 )
 
 // InfraredService is the public interface of this service
 type InfraredService interface {
-	GetDevice(ctx context.Context, body *GetDeviceRequest) *GetDeviceFuture
-	UpdateDevice(ctx context.Context, body *UpdateDeviceRequest) *UpdateDeviceFuture
+	GetOnkyoHTR380(ctx context.Context, body *GetOnkyoHTR380Request) *GetOnkyoHTR380Future
+	UpdateOnkyoHTR380(ctx context.Context, body *UpdateOnkyoHTR380Request) *UpdateOnkyoHTR380Future
+	InvokeCommand(ctx context.Context, body *def.InvokeCommandRequest) *InvokeCommandFuture
 }
 
-// GetDeviceFuture represents an in-flight GetDevice request
-type GetDeviceFuture struct {
+// GetOnkyoHTR380Future represents an in-flight GetOnkyoHTR380 request
+type GetOnkyoHTR380Future struct {
 	done <-chan struct{}
-	rsp  *GetDeviceResponse
+	rsp  *OnkyoHTR380Response
 	err  error
 }
 
 // Wait blocks until the response is ready
-func (f *GetDeviceFuture) Wait() (*GetDeviceResponse, error) {
+func (f *GetOnkyoHTR380Future) Wait() (*OnkyoHTR380Response, error) {
 	<-f.done
 	return f.rsp, f.err
 }
 
-// UpdateDeviceFuture represents an in-flight UpdateDevice request
-type UpdateDeviceFuture struct {
+// UpdateOnkyoHTR380Future represents an in-flight UpdateOnkyoHTR380 request
+type UpdateOnkyoHTR380Future struct {
 	done <-chan struct{}
-	rsp  *UpdateDeviceResponse
+	rsp  *OnkyoHTR380Response
 	err  error
 }
 
 // Wait blocks until the response is ready
-func (f *UpdateDeviceFuture) Wait() (*UpdateDeviceResponse, error) {
+func (f *UpdateOnkyoHTR380Future) Wait() (*OnkyoHTR380Response, error) {
+	<-f.done
+	return f.rsp, f.err
+}
+
+// InvokeCommandFuture represents an in-flight InvokeCommand request
+type InvokeCommandFuture struct {
+	done <-chan struct{}
+	rsp  *def.InvokeCommandResponse
+	err  error
+}
+
+// Wait blocks until the response is ready
+func (f *InvokeCommandFuture) Wait() (*def.InvokeCommandResponse, error) {
 	<-f.done
 	return f.rsp, f.err
 }
@@ -56,18 +71,18 @@ func NewClient(dispatcher taxi.Dispatcher) *Client {
 	}
 }
 
-// GetDevice dispatches an RPC to the service
-func (c *Client) GetDevice(ctx context.Context, body *GetDeviceRequest) *GetDeviceFuture {
+// GetOnkyoHTR380 dispatches an RPC to the service
+func (c *Client) GetOnkyoHTR380(ctx context.Context, body *GetOnkyoHTR380Request) *GetOnkyoHTR380Future {
 	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
 		Method: "GET",
-		URL:    "http://infrared/device",
+		URL:    "http://infrared/onkyo-htr380",
 		Body:   body,
 	})
 
 	done := make(chan struct{})
-	ftr := &GetDeviceFuture{
+	ftr := &GetOnkyoHTR380Future{
 		done: done,
-		rsp:  &GetDeviceResponse{},
+		rsp:  &OnkyoHTR380Response{},
 	}
 
 	go func() {
@@ -78,18 +93,40 @@ func (c *Client) GetDevice(ctx context.Context, body *GetDeviceRequest) *GetDevi
 	return ftr
 }
 
-// UpdateDevice dispatches an RPC to the service
-func (c *Client) UpdateDevice(ctx context.Context, body *UpdateDeviceRequest) *UpdateDeviceFuture {
+// UpdateOnkyoHTR380 dispatches an RPC to the service
+func (c *Client) UpdateOnkyoHTR380(ctx context.Context, body *UpdateOnkyoHTR380Request) *UpdateOnkyoHTR380Future {
 	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
 		Method: "PATCH",
-		URL:    "http://infrared/device",
+		URL:    "http://infrared/onkyo-htr380",
 		Body:   body,
 	})
 
 	done := make(chan struct{})
-	ftr := &UpdateDeviceFuture{
+	ftr := &UpdateOnkyoHTR380Future{
 		done: done,
-		rsp:  &UpdateDeviceResponse{},
+		rsp:  &OnkyoHTR380Response{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// InvokeCommand dispatches an RPC to the service
+func (c *Client) InvokeCommand(ctx context.Context, body *def.InvokeCommandRequest) *InvokeCommandFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: "POST",
+		URL:    "http://infrared/command",
+		Body:   body,
+	})
+
+	done := make(chan struct{})
+	ftr := &InvokeCommandFuture{
+		done: done,
+		rsp:  &def.InvokeCommandResponse{},
 	}
 
 	go func() {
@@ -117,18 +154,18 @@ func NewMockClient(ctx context.Context, t *testing.T) *MockClient {
 	}
 }
 
-// GetDevice dispatches an RPC to the mock client
-func (c *MockClient) GetDevice(ctx context.Context, body *GetDeviceRequest) *GetDeviceFuture {
+// GetOnkyoHTR380 dispatches an RPC to the mock client
+func (c *MockClient) GetOnkyoHTR380(ctx context.Context, body *GetOnkyoHTR380Request) *GetOnkyoHTR380Future {
 	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
 		Method: "GET",
-		URL:    "http://infrared/device",
+		URL:    "http://infrared/onkyo-htr380",
 		Body:   body,
 	})
 
 	done := make(chan struct{})
-	ftr := &GetDeviceFuture{
+	ftr := &GetOnkyoHTR380Future{
 		done: done,
-		rsp:  &GetDeviceResponse{},
+		rsp:  &OnkyoHTR380Response{},
 	}
 
 	go func() {
@@ -139,18 +176,40 @@ func (c *MockClient) GetDevice(ctx context.Context, body *GetDeviceRequest) *Get
 	return ftr
 }
 
-// UpdateDevice dispatches an RPC to the mock client
-func (c *MockClient) UpdateDevice(ctx context.Context, body *UpdateDeviceRequest) *UpdateDeviceFuture {
+// UpdateOnkyoHTR380 dispatches an RPC to the mock client
+func (c *MockClient) UpdateOnkyoHTR380(ctx context.Context, body *UpdateOnkyoHTR380Request) *UpdateOnkyoHTR380Future {
 	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
 		Method: "PATCH",
-		URL:    "http://infrared/device",
+		URL:    "http://infrared/onkyo-htr380",
+		Body:   body,
+	})
+
+	done := make(chan struct{})
+	ftr := &UpdateOnkyoHTR380Future{
+		done: done,
+		rsp:  &OnkyoHTR380Response{},
+	}
+
+	go func() {
+		defer close(done)
+		ftr.err = taxiFtr.DecodeResponse(&ftr.rsp)
+	}()
+
+	return ftr
+}
+
+// InvokeCommand dispatches an RPC to the mock client
+func (c *MockClient) InvokeCommand(ctx context.Context, body *def.InvokeCommandRequest) *InvokeCommandFuture {
+	taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
+		Method: "POST",
+		URL:    "http://infrared/command",
 		Body:   body,
 	})
 
 	done := make(chan struct{})
-	ftr := &UpdateDeviceFuture{
+	ftr := &InvokeCommandFuture{
 		done: done,
-		rsp:  &UpdateDeviceResponse{},
+		rsp:  &def.InvokeCommandResponse{},
 	}
 
 	go func() {
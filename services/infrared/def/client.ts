@@ -0,0 +1,36 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import { Fetch, RPCClient } from "../../../libraries/typescript/rpc";
+import * as device from "../../../libraries/go/device/def/types";
+import {
+  GetOnkyoHTR380Request,
+  OnkyoHTR380Response,
+  UpdateOnkyoHTR380Request,
+} from "./types";
+
+export { RPCError } from "../../../libraries/typescript/rpc";
+
+// InfraredClient makes requests to the Infrared service
+export class InfraredClient extends RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(baseURL: string, fetch?: Fetch) {
+    super(baseURL, fetch);
+  }
+
+  // getOnkyoHTR380 makes a GET request to /onkyo-htr380
+  getOnkyoHTR380(body: GetOnkyoHTR380Request): Promise<OnkyoHTR380Response> {
+    return this.do("GET", "/onkyo-htr380", body, true);
+  }
+
+  // updateOnkyoHTR380 makes a PATCH request to /onkyo-htr380
+  updateOnkyoHTR380(body: UpdateOnkyoHTR380Request): Promise<OnkyoHTR380Response> {
+    return this.do("PATCH", "/onkyo-htr380", body, false);
+  }
+
+  // invokeCommand makes a POST request to /command
+  invokeCommand(body: device.InvokeCommandRequest): Promise<device.InvokeCommandResponse> {
+    return this.do("POST", "/command", body, false);
+  }
+}
@@ -0,0 +1,294 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package infrareddef
+
+import (
+	context "context"
+	sync "sync"
+	testing "testing"
+
+	def "github.com/jakewright/home-automation/libraries/go/device/def"
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// MockService is an in-memory implementation of InfraredService
+// that can be used in tests. Responses are programmed, and requests
+// inspected, via the mock of each RPC, e.g. m.OnGetOnkyoHTR380().
+type MockService struct {
+	getOnkyoHTR380    *GetOnkyoHTR380Mock
+	updateOnkyoHTR380 *UpdateOnkyoHTR380Mock
+	invokeCommand     *InvokeCommandMock
+}
+
+// Compile-time assertion that the mock implements the interface
+var _ InfraredService = (*MockService)(nil)
+
+// NewMockService returns a new mock with no responses programmed
+func NewMockService() *MockService {
+	return &MockService{
+		getOnkyoHTR380:    &GetOnkyoHTR380Mock{},
+		updateOnkyoHTR380: &UpdateOnkyoHTR380Mock{},
+		invokeCommand:     &InvokeCommandMock{},
+	}
+}
+
+// OnGetOnkyoHTR380 returns the mock of the GetOnkyoHTR380 RPC
+func (m *MockService) OnGetOnkyoHTR380() *GetOnkyoHTR380Mock {
+	return m.getOnkyoHTR380
+}
+
+// GetOnkyoHTR380 records the request and returns the programmed response
+func (m *MockService) GetOnkyoHTR380(ctx context.Context, body *GetOnkyoHTR380Request) *GetOnkyoHTR380Future {
+	handler := m.getOnkyoHTR380.record(body)
+
+	done := make(chan struct{})
+	ftr := &GetOnkyoHTR380Future{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// GetOnkyoHTR380Mock programs and records calls to the GetOnkyoHTR380 RPC
+type GetOnkyoHTR380Mock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *GetOnkyoHTR380Request) (*OnkyoHTR380Response, error)
+	requests []*GetOnkyoHTR380Request
+}
+
+// Returns programs the mock to return the response and error
+func (m *GetOnkyoHTR380Mock) Returns(rsp *OnkyoHTR380Response, err error) *GetOnkyoHTR380Mock {
+	return m.Handle(func(context.Context, *GetOnkyoHTR380Request) (*OnkyoHTR380Response, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *GetOnkyoHTR380Mock) Handle(fn func(ctx context.Context, body *GetOnkyoHTR380Request) (*OnkyoHTR380Response, error)) *GetOnkyoHTR380Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *GetOnkyoHTR380Mock) record(body *GetOnkyoHTR380Request) func(context.Context, *GetOnkyoHTR380Request) (*OnkyoHTR380Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *GetOnkyoHTR380Request) (*OnkyoHTR380Response, error) {
+			return nil, oops.InternalService("no response programmed for GetOnkyoHTR380")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *GetOnkyoHTR380Mock) Requests() []*GetOnkyoHTR380Request {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*GetOnkyoHTR380Request, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *GetOnkyoHTR380Mock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected GetOnkyoHTR380 to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *GetOnkyoHTR380Mock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected GetOnkyoHTR380 not to be called but it was called %d times", n)
+	}
+}
+
+// OnUpdateOnkyoHTR380 returns the mock of the UpdateOnkyoHTR380 RPC
+func (m *MockService) OnUpdateOnkyoHTR380() *UpdateOnkyoHTR380Mock {
+	return m.updateOnkyoHTR380
+}
+
+// UpdateOnkyoHTR380 records the request and returns the programmed response
+func (m *MockService) UpdateOnkyoHTR380(ctx context.Context, body *UpdateOnkyoHTR380Request) *UpdateOnkyoHTR380Future {
+	handler := m.updateOnkyoHTR380.record(body)
+
+	done := make(chan struct{})
+	ftr := &UpdateOnkyoHTR380Future{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// UpdateOnkyoHTR380Mock programs and records calls to the UpdateOnkyoHTR380 RPC
+type UpdateOnkyoHTR380Mock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *UpdateOnkyoHTR380Request) (*OnkyoHTR380Response, error)
+	requests []*UpdateOnkyoHTR380Request
+}
+
+// Returns programs the mock to return the response and error
+func (m *UpdateOnkyoHTR380Mock) Returns(rsp *OnkyoHTR380Response, err error) *UpdateOnkyoHTR380Mock {
+	return m.Handle(func(context.Context, *UpdateOnkyoHTR380Request) (*OnkyoHTR380Response, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *UpdateOnkyoHTR380Mock) Handle(fn func(ctx context.Context, body *UpdateOnkyoHTR380Request) (*OnkyoHTR380Response, error)) *UpdateOnkyoHTR380Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *UpdateOnkyoHTR380Mock) record(body *UpdateOnkyoHTR380Request) func(context.Context, *UpdateOnkyoHTR380Request) (*OnkyoHTR380Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *UpdateOnkyoHTR380Request) (*OnkyoHTR380Response, error) {
+			return nil, oops.InternalService("no response programmed for UpdateOnkyoHTR380")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *UpdateOnkyoHTR380Mock) Requests() []*UpdateOnkyoHTR380Request {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*UpdateOnkyoHTR380Request, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *UpdateOnkyoHTR380Mock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected UpdateOnkyoHTR380 to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *UpdateOnkyoHTR380Mock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected UpdateOnkyoHTR380 not to be called but it was called %d times", n)
+	}
+}
+
+// OnInvokeCommand returns the mock of the InvokeCommand RPC
+func (m *MockService) OnInvokeCommand() *InvokeCommandMock {
+	return m.invokeCommand
+}
+
+// InvokeCommand records the request and returns the programmed response
+func (m *MockService) InvokeCommand(ctx context.Context, body *def.InvokeCommandRequest) *InvokeCommandFuture {
+	handler := m.invokeCommand.record(body)
+
+	done := make(chan struct{})
+	ftr := &InvokeCommandFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// InvokeCommandMock programs and records calls to the InvokeCommand RPC
+type InvokeCommandMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *def.InvokeCommandRequest) (*def.InvokeCommandResponse, error)
+	requests []*def.InvokeCommandRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *InvokeCommandMock) Returns(rsp *def.InvokeCommandResponse, err error) *InvokeCommandMock {
+	return m.Handle(func(context.Context, *def.InvokeCommandRequest) (*def.InvokeCommandResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *InvokeCommandMock) Handle(fn func(ctx context.Context, body *def.InvokeCommandRequest) (*def.InvokeCommandResponse, error)) *InvokeCommandMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *InvokeCommandMock) record(body *def.InvokeCommandRequest) func(context.Context, *def.InvokeCommandRequest) (*def.InvokeCommandResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *def.InvokeCommandRequest) (*def.InvokeCommandResponse, error) {
+			return nil, oops.InternalService("no response programmed for InvokeCommand")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *InvokeCommandMock) Requests() []*def.InvokeCommandRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*def.InvokeCommandRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *InvokeCommandMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected InvokeCommand to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *InvokeCommandMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected InvokeCommand not to be called but it was called %d times", n)
+	}
+}
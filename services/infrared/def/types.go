@@ -3,17 +3,120 @@
 package infrareddef
 
 import (
+	json "encoding/json"
+	utf8 "unicode/utf8"
+
+	def "github.com/jakewright/home-automation/libraries/go/device/def"
 	oops "github.com/jakewright/home-automation/libraries/go/oops"
 )
 
-// GetDeviceRequest is defined in the .def file
-type GetDeviceRequest struct {
+// OnkyoHTR380Input is defined in the .def file
+type OnkyoHTR380Input string
+
+// Values of OnkyoHTR380Input
+const (
+	OnkyoHTR380Input_BD_DVD  OnkyoHTR380Input = "BD_DVD"
+	OnkyoHTR380Input_VCR_DVD OnkyoHTR380Input = "VCR_DVD"
+	OnkyoHTR380Input_CBL_SAT OnkyoHTR380Input = "CBL_SAT"
+	OnkyoHTR380Input_GAME    OnkyoHTR380Input = "GAME"
+	OnkyoHTR380Input_AUX     OnkyoHTR380Input = "AUX"
+	OnkyoHTR380Input_TUNER   OnkyoHTR380Input = "TUNER"
+	OnkyoHTR380Input_TV_CD   OnkyoHTR380Input = "TV_CD"
+	OnkyoHTR380Input_PORT    OnkyoHTR380Input = "PORT"
+)
+
+// Validate returns an error if the value is not one of the values of OnkyoHTR380Input
+func (e OnkyoHTR380Input) Validate() error {
+	switch e {
+	case OnkyoHTR380Input_BD_DVD, OnkyoHTR380Input_VCR_DVD, OnkyoHTR380Input_CBL_SAT, OnkyoHTR380Input_GAME, OnkyoHTR380Input_AUX, OnkyoHTR380Input_TUNER, OnkyoHTR380Input_TV_CD, OnkyoHTR380Input_PORT:
+		return nil
+	}
+
+	return oops.BadRequest("%q is not a valid OnkyoHTR380Input", string(e))
+}
+
+// MarshalJSON returns an error if the value is not one of the values of OnkyoHTR380Input
+func (e OnkyoHTR380Input) MarshalJSON() ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON returns an error if the value is not one of the values of OnkyoHTR380Input
+func (e *OnkyoHTR380Input) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if err := OnkyoHTR380Input(s).Validate(); err != nil {
+		return err
+	}
+
+	*e = OnkyoHTR380Input(s)
+	return nil
+}
+
+// prefixFieldPath returns a copy of an error returned by the Validate
+// function of a nested message with the path of the nested field
+// prepended to the field path in the error's metadata
+func prefixFieldPath(err error, path string) error {
+	e, ok := err.(*oops.Error)
+	if !ok {
+		return err
+	}
+
+	metadata := map[string]string{}
+	for k, v := range e.GetMetadata() {
+		metadata[k] = v
+	}
+
+	if field, ok := metadata["field"]; ok {
+		path += "." + field
+	}
+	metadata["field"] = path
+
+	// Validate functions only return bad request errors
+	return oops.BadRequest("%s", e.GetMessage(), metadata)
+}
+
+// OnkyoHTR380State is defined in the .def file
+type OnkyoHTR380State struct {
+	Power *bool `json:"power,omitempty"`
+}
+
+// GetPower returns the de-referenced value of Power.
+// The second return value states whether the field was set.
+func (m *OnkyoHTR380State) GetPower() (val bool, set bool) {
+	if m.Power == nil {
+		return
+	}
+
+	return *m.Power, true
+}
+
+// SetPower sets the value of Power
+func (m *OnkyoHTR380State) SetPower(v bool) *OnkyoHTR380State {
+	m.Power = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *OnkyoHTR380State) Validate() error {
+	return nil
+}
+
+// GetOnkyoHTR380Request is defined in the .def file
+type GetOnkyoHTR380Request struct {
 	DeviceId *string `json:"device_id,omitempty"`
 }
 
 // GetDeviceId returns the de-referenced value of DeviceId.
 // If the field is nil, the function panics because device_id is marked as required.
-func (m *GetDeviceRequest) GetDeviceId() (val string) {
+func (m *GetOnkyoHTR380Request) GetDeviceId() (val string) {
 	if m.DeviceId == nil {
 		panic("device_id marked as required but was not set. This should have been caught by the validate function.")
 	}
@@ -22,37 +125,37 @@ func (m *GetDeviceRequest) GetDeviceId() (val string) {
 }
 
 // SetDeviceId sets the value of DeviceId
-func (m *GetDeviceRequest) SetDeviceId(v string) *GetDeviceRequest {
+func (m *GetOnkyoHTR380Request) SetDeviceId(v string) *GetOnkyoHTR380Request {
 	m.DeviceId = &v
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
-func (m *GetDeviceRequest) Validate() error {
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *GetOnkyoHTR380Request) Validate() error {
 	if m.DeviceId == nil {
-		return oops.BadRequest("field 'device_id' is required")
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
+	}
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
 	}
-	return nil
-}
-
-// GetDeviceResponse is defined in the .def file
-type GetDeviceResponse struct {
-}
 
-// Validate returns an error if any of the fields have bad values
-func (m *GetDeviceResponse) Validate() error {
 	return nil
 }
 
-// UpdateDeviceRequest is defined in the .def file
-type UpdateDeviceRequest struct {
-	DeviceId *string                `json:"device_id,omitempty"`
-	State    map[string]interface{} `json:"state,omitempty"`
+// UpdateOnkyoHTR380Request is defined in the .def file
+type UpdateOnkyoHTR380Request struct {
+	DeviceId *string           `json:"device_id,omitempty"`
+	State    *OnkyoHTR380State `json:"state,omitempty"`
 }
 
 // GetDeviceId returns the de-referenced value of DeviceId.
 // If the field is nil, the function panics because device_id is marked as required.
-func (m *UpdateDeviceRequest) GetDeviceId() (val string) {
+func (m *UpdateOnkyoHTR380Request) GetDeviceId() (val string) {
 	if m.DeviceId == nil {
 		panic("device_id marked as required but was not set. This should have been caught by the validate function.")
 	}
@@ -61,40 +164,119 @@ func (m *UpdateDeviceRequest) GetDeviceId() (val string) {
 }
 
 // SetDeviceId sets the value of DeviceId
-func (m *UpdateDeviceRequest) SetDeviceId(v string) *UpdateDeviceRequest {
+func (m *UpdateOnkyoHTR380Request) SetDeviceId(v string) *UpdateOnkyoHTR380Request {
 	m.DeviceId = &v
 	return m
 }
 
 // GetState returns the de-referenced value of State.
 // The second return value states whether the field was set.
-func (m *UpdateDeviceRequest) GetState() (val map[string]interface{}, set bool) {
+func (m *UpdateOnkyoHTR380Request) GetState() (val OnkyoHTR380State, set bool) {
 	if m.State == nil {
 		return
 	}
 
-	return m.State, true
+	return *m.State, true
 }
 
 // SetState sets the value of State
-func (m *UpdateDeviceRequest) SetState(v map[string]interface{}) *UpdateDeviceRequest {
-	m.State = v
+func (m *UpdateOnkyoHTR380Request) SetState(v OnkyoHTR380State) *UpdateOnkyoHTR380Request {
+	m.State = &v
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
-func (m *UpdateDeviceRequest) Validate() error {
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *UpdateOnkyoHTR380Request) Validate() error {
 	if m.DeviceId == nil {
-		return oops.BadRequest("field 'device_id' is required")
+		return oops.BadRequest("field 'device_id' is required", map[string]string{
+			"field": "device_id",
+		})
+	}
+	if m.DeviceId != nil && utf8.RuneCountInString(*m.DeviceId) < 1 {
+		return oops.BadRequest("field 'device_id' should have length ≥ 1", map[string]string{
+			"field": "device_id",
+		})
 	}
+
+	if m.State != nil {
+		if err := m.State.Validate(); err != nil {
+			return prefixFieldPath(err, "state")
+		}
+	}
+
 	return nil
 }
 
-// UpdateDeviceResponse is defined in the .def file
-type UpdateDeviceResponse struct {
+// OnkyoHTR380Response is defined in the .def file
+type OnkyoHTR380Response struct {
+	Header     *def.Header              `json:"header,omitempty"`
+	Properties map[string]*def.Property `json:"properties,omitempty"`
+	State      *OnkyoHTR380State        `json:"state,omitempty"`
+}
+
+// GetHeader returns the de-referenced value of Header.
+// The second return value states whether the field was set.
+func (m *OnkyoHTR380Response) GetHeader() (val def.Header, set bool) {
+	if m.Header == nil {
+		return
+	}
+
+	return *m.Header, true
+}
+
+// SetHeader sets the value of Header
+func (m *OnkyoHTR380Response) SetHeader(v def.Header) *OnkyoHTR380Response {
+	m.Header = &v
+	return m
+}
+
+// GetProperties returns the de-referenced value of Properties.
+// The second return value states whether the field was set.
+func (m *OnkyoHTR380Response) GetProperties() (val map[string]*def.Property, set bool) {
+	if m.Properties == nil {
+		return
+	}
+
+	return m.Properties, true
+}
+
+// SetProperties sets the value of Properties
+func (m *OnkyoHTR380Response) SetProperties(v map[string]*def.Property) *OnkyoHTR380Response {
+	m.Properties = v
+	return m
 }
 
-// Validate returns an error if any of the fields have bad values
-func (m *UpdateDeviceResponse) Validate() error {
+// GetState returns the de-referenced value of State.
+// The second return value states whether the field was set.
+func (m *OnkyoHTR380Response) GetState() (val OnkyoHTR380State, set bool) {
+	if m.State == nil {
+		return
+	}
+
+	return *m.State, true
+}
+
+// SetState sets the value of State
+func (m *OnkyoHTR380Response) SetState(v OnkyoHTR380State) *OnkyoHTR380Response {
+	m.State = &v
+	return m
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
+func (m *OnkyoHTR380Response) Validate() error {
+	if m.Header != nil {
+		if err := m.Header.Validate(); err != nil {
+			return prefixFieldPath(err, "header")
+		}
+	}
+
+	if m.State != nil {
+		if err := m.State.Validate(); err != nil {
+			return prefixFieldPath(err, "state")
+		}
+	}
+
 	return nil
 }
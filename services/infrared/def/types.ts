@@ -0,0 +1,37 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import * as device from "../../../libraries/go/device/def/types";
+
+// OnkyoHTR380Input is defined in the .def file
+export type OnkyoHTR380Input =
+  | "BD_DVD"
+  | "VCR_DVD"
+  | "CBL_SAT"
+  | "GAME"
+  | "AUX"
+  | "TUNER"
+  | "TV_CD"
+  | "PORT";
+
+// OnkyoHTR380State is defined in the .def file
+export interface OnkyoHTR380State {
+  power?: boolean;
+}
+
+// GetOnkyoHTR380Request is defined in the .def file
+export interface GetOnkyoHTR380Request {
+  device_id: string;
+}
+
+// UpdateOnkyoHTR380Request is defined in the .def file
+export interface UpdateOnkyoHTR380Request {
+  device_id: string;
+  state?: OnkyoHTR380State;
+}
+
+// OnkyoHTR380Response is defined in the .def file
+export interface OnkyoHTR380Response {
+  header?: device.Header;
+  properties?: { [key: string]: device.Property };
+  state?: OnkyoHTR380State;
+}
@@ -1,12 +1,41 @@
 package domain
 
-import deviceregistrydef "github.com/jakewright/home-automation/services/device-registry/def"
+import (
+	"context"
 
-type Device interface {
-	ID() string
-	Copy() Device
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+	"github.com/jakewright/home-automation/services/infrared/ir"
+)
+
+// Device types
+const (
+	TypeOnkyoHTR380 = "infrared.htr380"
+)
+
+// RemoteDevice is a device that is controlled by sending it infrared
+// instructions. Changes to the device's state, and commands, queue up
+// instructions that are sent when the device is committed.
+type RemoteDevice interface {
+	Device
+
+	// LoadState updates the device's state from its state providers
+	LoadState(ctx context.Context, dispatcher taxi.Dispatcher) error
+
+	// Instructions returns the queued instructions and clears the queue
+	Instructions() []ir.Instruction
+
+	// Copy returns a copy of the device
+	Copy() RemoteDevice
 }
 
-func NewDeviceFromDeviceHeader(header *deviceregistrydef.DeviceHeader) (Device, error) {
-	// todo
+// NewDevice returns a RemoteDevice based on the header's type
+func NewDevice(h *devicedef.Header) (RemoteDevice, error) {
+	switch h.GetType() {
+	case TypeOnkyoHTR380:
+		return &OnkyoHTR380{Header: h}, nil
+	}
+
+	return nil, oops.InternalService("device %s has invalid type '%s'", h.GetId(), h.GetType())
 }
@@ -0,0 +1,179 @@
+// Code generated by devicegen. DO NOT EDIT.
+
+package domain
+
+import (
+	device "github.com/jakewright/home-automation/libraries/go/device"
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+	ptr "github.com/jakewright/home-automation/libraries/go/ptr"
+	def "github.com/jakewright/home-automation/services/infrared/def"
+)
+
+// Device is implemented by every type of device that the service controls
+type Device interface {
+	// ID returns the device's ID
+	ID() string
+
+	// DeviceHeader returns the device's header from the registry
+	DeviceHeader() *devicedef.Header
+}
+
+// InvokeCommand invokes the command on the device. An
+// error is returned if the device's type has no commands.
+func InvokeCommand(d Device, command string, args map[string]interface{}) error {
+	switch d := d.(type) {
+	case OnkyoHTR380Device:
+		return InvokeOnkyoHTR380Command(d, command, args)
+	}
+
+	return oops.BadRequest("device %q does not support commands", d.ID())
+}
+
+// OnkyoHTR380Device is implemented by OnkyoHTR380 devices
+type OnkyoHTR380Device interface {
+	Device
+
+	// State returns the current state of the device
+	State() *def.OnkyoHTR380State
+
+	// ApplyState updates the device with the
+	// properties that are set in the state
+	ApplyState(state *def.OnkyoHTR380State) error
+
+	// InputCommand handles the input command
+	InputCommand(args *OnkyoHTR380InputArgs) error
+
+	// MuteCommand handles the mute command
+	MuteCommand() error
+
+	// VolumeCommand handles the volume command
+	VolumeCommand(args *OnkyoHTR380VolumeArgs) error
+}
+
+// OnkyoHTR380Properties returns metadata
+// about the properties of OnkyoHTR380 devices
+func OnkyoHTR380Properties() map[string]*devicedef.Property {
+	return map[string]*devicedef.Property{
+		"power": {
+			Type: ptr.String("bool"),
+		},
+	}
+}
+
+// OnkyoHTR380Commands returns metadata about
+// the commands supported by OnkyoHTR380 devices
+func OnkyoHTR380Commands() map[string]*devicedef.Command {
+	return map[string]*devicedef.Command{
+		"input": {
+			Args: map[string]*devicedef.Arg{
+				"input": {
+					Required: ptr.Bool(true),
+					Type:     ptr.String("string"),
+					Options: []*devicedef.Option{
+						{Value: ptr.String("BD_DVD"), Name: ptr.String("Laptop")},
+						{Value: ptr.String("CBL_SAT"), Name: ptr.String("Roku")},
+						{Value: ptr.String("GAME"), Name: ptr.String("Game")},
+					},
+				},
+			},
+		},
+		"mute": {},
+		"volume": {
+			Args: map[string]*devicedef.Arg{
+				"delta": {
+					Required: ptr.Bool(true),
+					Type:     ptr.String("int"),
+					Min:      ptr.Float64(-10),
+					Max:      ptr.Float64(10),
+				},
+			},
+		},
+	}
+}
+
+// OnkyoHTR380InputArgs are the arguments of the input command.
+// Optional arguments are nil if they were not given.
+type OnkyoHTR380InputArgs struct {
+	Input string
+}
+
+// OnkyoHTR380VolumeArgs are the arguments of the volume command.
+// Optional arguments are nil if they were not given.
+type OnkyoHTR380VolumeArgs struct {
+	Delta int64
+}
+
+// InvokeOnkyoHTR380Command converts the arguments to their go types
+// and calls the device's method for the command. An error is returned
+// if the command is not known or if the arguments are not valid.
+func InvokeOnkyoHTR380Command(d OnkyoHTR380Device, command string, args map[string]interface{}) error {
+	switch command {
+	case "input":
+		a := &OnkyoHTR380InputArgs{}
+
+		for name, value := range args {
+			switch name {
+			case "input":
+				v, ok := value.(string)
+				if !ok {
+					return oops.BadRequest("argument 'input' should be a string")
+				}
+
+				switch v {
+				case "BD_DVD", "CBL_SAT", "GAME":
+				default:
+					return oops.BadRequest("argument 'input' received invalid option: %s", v)
+				}
+
+				a.Input = v
+			default:
+				return oops.BadRequest("argument %q not known for command 'input'", name)
+			}
+		}
+
+		if _, ok := args["input"]; !ok {
+			return oops.BadRequest("argument 'input' is required")
+		}
+
+		return d.InputCommand(a)
+	case "mute":
+		if len(args) > 0 {
+			return oops.BadRequest("command 'mute' does not take arguments")
+		}
+
+		return d.MuteCommand()
+	case "volume":
+		a := &OnkyoHTR380VolumeArgs{}
+
+		for name, value := range args {
+			switch name {
+			case "delta":
+				v, ok := device.ToInt(value)
+				if !ok {
+					return oops.BadRequest("argument 'delta' should be an integer")
+				}
+
+				if v < -10 {
+					return oops.BadRequest("argument 'delta' should be ≥ -10")
+				}
+
+				if v > 10 {
+					return oops.BadRequest("argument 'delta' should be ≤ 10")
+				}
+
+				a.Delta = v
+			default:
+				return oops.BadRequest("argument %q not known for command 'volume'", name)
+			}
+		}
+
+		if _, ok := args["delta"]; !ok {
+			return oops.BadRequest("argument 'delta' is required")
+		}
+
+		return d.VolumeCommand(a)
+	}
+
+	return oops.BadRequest("command %q not known for OnkyoHTR380 devices", command)
+}
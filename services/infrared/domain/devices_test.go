@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// recordingOnkyoHTR380 records the arguments of the commands it receives
+type recordingOnkyoHTR380 struct {
+	*OnkyoHTR380
+	input  *OnkyoHTR380InputArgs
+	volume *OnkyoHTR380VolumeArgs
+	muted  bool
+}
+
+func (d *recordingOnkyoHTR380) InputCommand(args *OnkyoHTR380InputArgs) error {
+	d.input = args
+	return nil
+}
+
+func (d *recordingOnkyoHTR380) MuteCommand() error {
+	d.muted = true
+	return nil
+}
+
+func (d *recordingOnkyoHTR380) VolumeCommand(args *OnkyoHTR380VolumeArgs) error {
+	d.volume = args
+	return nil
+}
+
+func newRecordingOnkyoHTR380() *recordingOnkyoHTR380 {
+	return &recordingOnkyoHTR380{
+		OnkyoHTR380: &OnkyoHTR380{Header: (&devicedef.Header{}).SetId("tv")},
+	}
+}
+
+func TestInvokeOnkyoHTR380Command(t *testing.T) {
+	d := newRecordingOnkyoHTR380()
+	require.NoError(t, InvokeOnkyoHTR380Command(d, "input", map[string]interface{}{"input": "GAME"}))
+	require.Equal(t, &OnkyoHTR380InputArgs{Input: "GAME"}, d.input)
+
+	require.NoError(t, InvokeOnkyoHTR380Command(d, "mute", nil))
+	require.True(t, d.muted)
+
+	// Integers are accepted whether they were decoded from JSON or not
+	for _, delta := range []interface{}{float64(-3), -3, int8(-3), int64(-3)} {
+		d := newRecordingOnkyoHTR380()
+		require.NoError(t, InvokeOnkyoHTR380Command(d, "volume", map[string]interface{}{"delta": delta}))
+		require.Equal(t, &OnkyoHTR380VolumeArgs{Delta: -3}, d.volume)
+	}
+
+	// The device is found by the generic function
+	d = newRecordingOnkyoHTR380()
+	require.NoError(t, InvokeCommand(d, "mute", nil))
+	require.True(t, d.muted)
+}
+
+func TestInvokeOnkyoHTR380Command_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{"unknown command", "explode", nil, `command "explode" not known`},
+		{"unknown arg", "volume", map[string]interface{}{"delta": 1.0, "speed": 2.0}, `argument "speed" not known`},
+		{"args to command without args", "mute", map[string]interface{}{"delta": 1.0}, "does not take arguments"},
+		{"missing required arg", "volume", nil, "argument 'delta' is required"},
+		{"missing required option", "input", map[string]interface{}{}, "argument 'input' is required"},
+		{"not an integer", "volume", map[string]interface{}{"delta": "loud"}, "should be an integer"},
+		{"fractional", "volume", map[string]interface{}{"delta": 1.5}, "should be an integer"},
+		{"below min", "volume", map[string]interface{}{"delta": -11.0}, "should be ≥ -10"},
+		{"above max", "volume", map[string]interface{}{"delta": 11}, "should be ≤ 10"},
+		{"not a string", "input", map[string]interface{}{"input": 1.0}, "should be a string"},
+		{"invalid option", "input", map[string]interface{}{"input": "RADIO"}, "invalid option: RADIO"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newRecordingOnkyoHTR380()
+
+			err := InvokeOnkyoHTR380Command(d, tc.command, tc.args)
+			require.True(t, oops.Is(err, oops.ErrBadRequest))
+			require.Contains(t, err.Error(), tc.wantErr)
+			require.Nil(t, d.input)
+			require.Nil(t, d.volume)
+			require.False(t, d.muted)
+		})
+	}
+}
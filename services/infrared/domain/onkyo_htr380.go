@@ -2,12 +2,12 @@ package domain
 
 import (
 	"context"
-	"math"
 
 	"github.com/jakewright/home-automation/libraries/go/device"
 	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
 	"github.com/jakewright/home-automation/libraries/go/oops"
-	deviceregistrydef "github.com/jakewright/home-automation/services/device-registry/def"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+	infrareddef "github.com/jakewright/home-automation/services/infrared/def"
 	"github.com/jakewright/home-automation/services/infrared/ir"
 )
 
@@ -24,172 +24,139 @@ const (
 	onkyoHTR380KeyVolumeUp   = "KEY_VOLUMEUP"
 	onkyoHTR380KeyVolumeDown = "KEY_VOLUMEDOWN"
 	onkyoHTR380KeyMute       = "KEY_MUTE"
-
-	onkyoHTR380InputBDDVD  = "BD_DVD"
-	onkyoHTR380InputVCRDVD = "VCR_DVD"
-	onkyoHTR380InputCBLSAT = "CBL_SAT"
-	onkyoHTR380InputGAME   = "GAME"
-	onkyoHTR380InputAUX    = "AUX"
-	onkyoHTR380InputTUNER  = "TUNER"
-	onkyoHTR380InputTVCD   = "TV_CD"
-	onkyoHTR380InputPORT   = "PORT"
 )
 
-var onkyoHTR380InputKeys = map[string]string{
-	onkyoHTR380InputBDDVD:  onkyoHTR380KeyBDDVD,
-	onkyoHTR380InputVCRDVD: onkyoHTR380KeyVCRDVD,
-	onkyoHTR380InputCBLSAT: onkyoHTR380KeyCBLSAT,
-	onkyoHTR380InputGAME:   onkyoHTR380KeyGAME,
-	onkyoHTR380InputAUX:    onkyoHTR380KeyAUX,
-	onkyoHTR380InputTUNER:  onkyoHTR380KeyTUNER,
-	onkyoHTR380InputTVCD:   onkyoHTR380KeyTVCD,
-	onkyoHTR380InputPORT:   onkyoHTR380KeyPORT,
+var onkyoHTR380InputKeys = map[infrareddef.OnkyoHTR380Input]string{
+	infrareddef.OnkyoHTR380Input_BD_DVD:  onkyoHTR380KeyBDDVD,
+	infrareddef.OnkyoHTR380Input_VCR_DVD: onkyoHTR380KeyVCRDVD,
+	infrareddef.OnkyoHTR380Input_CBL_SAT: onkyoHTR380KeyCBLSAT,
+	infrareddef.OnkyoHTR380Input_GAME:    onkyoHTR380KeyGAME,
+	infrareddef.OnkyoHTR380Input_AUX:     onkyoHTR380KeyAUX,
+	infrareddef.OnkyoHTR380Input_TUNER:   onkyoHTR380KeyTUNER,
+	infrareddef.OnkyoHTR380Input_TV_CD:   onkyoHTR380KeyTVCD,
+	infrareddef.OnkyoHTR380Input_PORT:    onkyoHTR380KeyPORT,
 }
 
+// OnkyoHTR380 is an AV receiver
 type OnkyoHTR380 struct {
-	*deviceregistrydef.DeviceHeader
-	power bool
+	*devicedef.Header
+	power        bool
+	instructions []ir.Instruction
 }
 
-func (d *OnkyoHTR380) ID() string {
-	return d.Id
-}
+var (
+	_ RemoteDevice      = (*OnkyoHTR380)(nil)
+	_ OnkyoHTR380Device = (*OnkyoHTR380)(nil)
+)
+
+// ID returns the device ID
+func (d *OnkyoHTR380) ID() string { return d.Header.GetId() }
+
+// DeviceHeader returns the device's header
+func (d *OnkyoHTR380) DeviceHeader() *devicedef.Header { return d.Header }
 
 func (d *OnkyoHTR380) key(key string) ir.Instruction {
 	return ir.Key("ONKYO_HT_R380", key)
 }
 
-func (d *OnkyoHTR380) LoadState(ctx context.Context) error {
-	state, err := device.LoadProvidedState(ctx, d.Id, d.StateProviders)
+// LoadState updates the power state from the state providers. If
+// the device has no state providers, the last known state is kept.
+func (d *OnkyoHTR380) LoadState(ctx context.Context, dispatcher taxi.Dispatcher) error {
+	if len(d.StateProviders) == 0 {
+		return nil
+	}
+
+	state, err := device.LoadProvidedState(ctx, dispatcher, d.ID(), d.StateProviders)
 	if err != nil {
-		return oops.WithMessage(err, "failed to load provided state for device %q", d.Id)
+		return oops.WithMessage(err, "failed to load provided state for device %q", d.ID())
 	}
 
-	if power, ok := state["power"].(bool); !ok {
-		return oops.InternalService("state provider didn't provide power state for device %q", d.Id)
-	} else {
-		d.power = power
+	if err := device.ValidateState(state, OnkyoHTR380Properties()); err != nil {
+		return oops.Wrap(err, oops.ErrInternalService, "state providers provided invalid state for device %q", d.ID())
+	}
+
+	power, ok := state["power"].(bool)
+	if !ok {
+		return oops.InternalService("state provider didn't provide power state for device %q", d.ID())
 	}
 
+	d.power = power
 	return nil
 }
 
-func (d *OnkyoHTR380) InstructionsFromState(state map[string]interface{}) ([]ir.Instruction, error) {
-	if err := device.ValidateState(state, d.ToDef()); err != nil {
-		return nil, err
-	}
+// State returns the current state of the device
+func (d *OnkyoHTR380) State() *infrareddef.OnkyoHTR380State {
+	return (&infrareddef.OnkyoHTR380State{}).SetPower(d.power)
+}
 
-	var instructions []ir.Instruction
+// ApplyState queues the instructions needed to reach the state
+func (d *OnkyoHTR380) ApplyState(state *infrareddef.OnkyoHTR380State) error {
+	if state == nil {
+		return nil
+	}
 
-	if power, ok := state["power"].(bool); ok && power != d.power {
-		instructions = append(instructions, d.key(onkyoHTR380KeyPower))
+	if power, ok := state.GetPower(); ok && power != d.power {
+		d.power = power
+		d.instructions = append(d.instructions,
+			d.key(onkyoHTR380KeyPower),
 
-		// Give the AV receiver plenty of time to turn on
-		instructions = append(instructions, ir.Wait(5000))
+			// Give the AV receiver plenty of time to turn on
+			ir.Wait(5000),
+		)
 	}
 
-	return instructions, nil
+	return nil
 }
 
-func (d *OnkyoHTR380) InstructionFromCommand(command string, args map[string]interface{}) ([]ir.Instruction, error) {
-	if err := device.ValidateCommand(command, args, d.ToDef().Commands); err != nil {
-		return nil, err
+// VolumeCommand changes the volume by delta steps
+func (d *OnkyoHTR380) VolumeCommand(args *OnkyoHTR380VolumeArgs) error {
+	key := onkyoHTR380KeyVolumeUp
+	if args.Delta < 0 {
+		key = onkyoHTR380KeyVolumeDown
+	}
+
+	steps := args.Delta
+	if steps < 0 {
+		steps = -steps
 	}
 
-	var instructions []ir.Instruction
-
-	switch command {
-	case "volume":
-		delta, ok := args["delta"].(float64)
-		if !ok {
-			return nil, oops.BadRequest("invalid volume argument 'delta': %v", args["delta"])
-		}
-
-		key := onkyoHTR380KeyVolumeUp
-		if delta < 0 {
-			key = onkyoHTR380KeyVolumeDown
-		}
-
-		// Send the key n + 1 times because the key needs to be
-		// pressed to activate the volume control before it changes
-		for i := 0.0; i <= math.Abs(delta); i++ {
-			instructions = append(instructions, d.key(key), ir.Wait(200))
-		}
-
-	case "mute":
-		instructions = append(instructions, d.key(onkyoHTR380KeyMute), ir.Wait(2000))
-
-	case "input":
-		input, ok := args["input"].(string)
-		if !ok {
-			return nil, oops.BadRequest("invalid input argument 'input': %v", args["input"])
-		}
-
-		key, ok := onkyoHTR380InputKeys[input]
-		if !ok {
-			return nil, oops.InternalService("failed to find key for input option %q", input)
-		}
-
-		instructions = append(instructions, d.key(key), ir.Wait(2000))
+	// Send the key n + 1 times because the key needs to be
+	// pressed to activate the volume control before it changes
+	for i := int64(0); i <= steps; i++ {
+		d.instructions = append(d.instructions, d.key(key), ir.Wait(200))
 	}
 
-	return instructions, nil
+	return nil
+}
+
+// MuteCommand toggles mute
+func (d *OnkyoHTR380) MuteCommand() error {
+	d.instructions = append(d.instructions, d.key(onkyoHTR380KeyMute), ir.Wait(2000))
+	return nil
 }
 
-//func (d *OnkyoHTR380) SetProperties(state map[string]interface{}) (bool, error) {
-//	if err := device.ValidateState(state, d.ToDef().State); err != nil {
-//		return false, err
-//	}
-//
-//	changed := false
-//
-//	power, ok := state["power"].(bool)
-//	if ok {
-//		if d.power != power {
-//			changed = true
-//		}
-//
-//		d.power = power
-//	}
-//
-//	return changed, nil
-//}
-
-func (d *OnkyoHTR380) ToDef() *devicedef.Device {
-	return &devicedef.Device{
-		Id:             d.ID(),
-		Name:           d.Name,
-		Type:           d.Type,
-		Kind:           d.Kind,
-		ControllerName: d.ControllerName,
-		Attributes:     d.Attributes,
-		StateProviders: d.StateProviders,
-		State: map[string]*devicedef.Property{
-			"power": device.BoolProperty(d.power),
-		},
-		Commands: map[string]*devicedef.Command{
-			"volume": {
-				Args: map[string]*devicedef.Arg{
-					"delta": device.IntArg(-10, 10, true),
-				},
-			},
-			"mute": {},
-			"input": {
-				Args: map[string]*devicedef.Arg{
-					"input": device.StringArgWithOptions([]*devicedef.Option{
-						{onkyoHTR380InputBDDVD, "Laptop"},
-						{onkyoHTR380InputCBLSAT, "Roku"},
-						{onkyoHTR380InputGAME, "Game"},
-					}, true),
-				},
-			},
-		},
+// InputCommand changes the input
+func (d *OnkyoHTR380) InputCommand(args *OnkyoHTR380InputArgs) error {
+	key, ok := onkyoHTR380InputKeys[infrareddef.OnkyoHTR380Input(args.Input)]
+	if !ok {
+		return oops.InternalService("failed to find key for input option %q", args.Input)
 	}
+
+	d.instructions = append(d.instructions, d.key(key), ir.Wait(2000))
+	return nil
+}
+
+// Instructions returns the queued instructions and clears the queue
+func (d *OnkyoHTR380) Instructions() []ir.Instruction {
+	instructions := d.instructions
+	d.instructions = nil
+	return instructions
 }
 
-func (d *OnkyoHTR380) Copy() Device {
+// Copy returns a copy of the device without any queued instructions
+func (d *OnkyoHTR380) Copy() RemoteDevice {
 	return &OnkyoHTR380{
-		DeviceHeader: d.DeviceHeader,
-		power:        d.power,
+		Header: d.Header,
+		power:  d.power,
 	}
 }
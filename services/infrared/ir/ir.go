@@ -19,7 +19,7 @@ func Wait(ms int) Instruction {
 
 func Key(device, key string) Instruction {
 	return func(ctx context.Context, lirc lircproxydef.LircProxyService) error {
-		return send(ctx, device, key)
+		return send(ctx, lirc, device, key)
 	}
 }
 
@@ -37,7 +37,7 @@ func (s *IRSend) Execute(ctx context.Context, ins []Instruction) error {
 	defer lock.Unlock()
 
 	for _, instruction := range ins {
-		if err := instruction(ctx); err != nil {
+		if err := instruction(ctx, s.LIRC); err != nil {
 			return err
 		}
 	}
@@ -50,10 +50,9 @@ func send(
 	lirc lircproxydef.LircProxyService,
 	device, key string,
 ) error {
-	if _, err := lirc.SendOnce(ctx, &lircproxydef.SendOnceRequest{
-		Device: device,
-		Key:    key,
-	}).Wait(); err != nil {
-		return
-	}
+	_, err := lirc.SendOnce(ctx, (&lircproxydef.SendOnceRequest{}).
+		SetDevice(device).
+		SetKey(key),
+	).Wait()
+	return err
 }
@@ -1,22 +1,60 @@
 package main
 
 import (
+	"context"
+
 	"github.com/jakewright/home-automation/libraries/go/bootstrap"
+	"github.com/jakewright/home-automation/libraries/go/healthz"
+	"github.com/jakewright/home-automation/libraries/go/slog"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+	deviceregistrydef "github.com/jakewright/home-automation/services/device-registry/def"
+	"github.com/jakewright/home-automation/services/infrared/ir"
+	"github.com/jakewright/home-automation/services/infrared/repository"
 	"github.com/jakewright/home-automation/services/infrared/routes"
+	lircproxydef "github.com/jakewright/home-automation/services/lirc-proxy/def"
 )
 
 //go:generate jrpc infrared.def
+//go:generate devicegen devices.json
+
+const serviceName = "infrared"
 
 func main() {
 	svc := bootstrap.Init(&bootstrap.Opts{
-		ServiceName: "service.infrared",
-		Firehose:    true,
+		ServiceName: serviceName,
 	})
 
-	r := routes.Register(svc, &routes.Controller{
-		Repository: nil,
-		IR:         nil,
+	if err := run(svc); err != nil {
+		slog.Panicf("Failed to run service: %v", err)
+	}
+}
+
+func run(svc *bootstrap.Service) error {
+	// Retry requests to the device registry so that a
+	// blip during a deploy doesn't stop the service starting
+	dispatcher := taxi.NewClient().
+		WithRetryPolicy(taxi.DefaultRetryPolicy).
+		WithCircuitBreaker(taxi.DefaultBreakerPolicy)
+	healthz.RegisterCheck("upstreams", dispatcher.HealthCheck)
+
+	repo, err := repository.Init(
+		context.Background(),
+		serviceName,
+		deviceregistrydef.NewClient(dispatcher),
+	)
+	if err != nil {
+		return err
+	}
+
+	routes.Register(svc, &routes.Controller{
+		Repository: repo,
+		IR: &ir.IRSend{
+			LIRC: lircproxydef.NewClient(dispatcher),
+		},
+		Dispatcher: dispatcher,
+		Publisher:  svc.FirehosePublisher(),
 	})
 
-	svc.Run(r)
+	svc.Run()
+	return nil
 }
@@ -8,20 +8,20 @@ import (
 
 // DeviceRepository holds devices
 type DeviceRepository struct {
-	devices map[string]domain.Device
+	devices map[string]domain.RemoteDevice
 	mux     *sync.RWMutex
 }
 
 // New returns a new DeviceRepository
 func New() *DeviceRepository {
 	return &DeviceRepository{
-		devices: make(map[string]domain.Device),
+		devices: make(map[string]domain.RemoteDevice),
 		mux:     &sync.RWMutex{},
 	}
 }
 
 // Find returns the device with the given ID
-func (r *DeviceRepository) Find(id string) domain.Device {
+func (r *DeviceRepository) Find(id string) domain.RemoteDevice {
 	r.mux.RLock()
 	defer r.mux.RUnlock()
 
@@ -35,7 +35,7 @@ func (r *DeviceRepository) Find(id string) domain.Device {
 
 // AddDevice adds the given device to the
 // repository if it does not already exist
-func (r *DeviceRepository) AddDevice(d domain.Device) {
+func (r *DeviceRepository) AddDevice(d domain.RemoteDevice) {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
@@ -48,7 +48,7 @@ func (r *DeviceRepository) AddDevice(d domain.Device) {
 
 // Save adds the given device to the repository
 // replacing any existing device with the same ID
-func (r *DeviceRepository) Save(d domain.Device) {
+func (r *DeviceRepository) Save(d domain.RemoteDevice) {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
@@ -8,33 +8,34 @@ import (
 	"github.com/jakewright/home-automation/services/infrared/domain"
 )
 
-// Loader loads device metadata and instantiates devices
-type Loader struct {
-	ServiceName string
-	Repository  *DeviceRepository
-}
-
-func (l *Loader) FetchDevices(ctx context.Context) error {
-	rsp, err := (&deviceregistrydef.ListDevicesRequest{
-		ControllerName: l.ServiceName,
-	}).Do(ctx)
+// Init loads devices from the device registry and populates a new repository
+func Init(
+	ctx context.Context,
+	serviceName string,
+	deviceRegistry deviceregistrydef.DeviceRegistryService,
+) (*DeviceRepository, error) {
+	rsp, err := deviceRegistry.ListDevices(ctx, &deviceregistrydef.ListDevicesRequest{
+		ControllerName: &serviceName,
+	}).Wait()
 	if err != nil {
-		return oops.WithMessage(err, "failed to fetch devices")
+		return nil, oops.WithMessage(err, "failed to fetch devices")
 	}
 
-	for _, device := range rsp.DeviceHeaders {
-		// Sanity check the controller name
-		if device.ControllerName != l.ServiceName {
-			return oops.InternalService("device %s is not for this controller", device.Id)
+	r := New()
+
+	for _, header := range rsp.DeviceHeaders {
+		// Be defensive against the device registry returning the wrong devices
+		if header.GetControllerName() != serviceName {
+			return nil, oops.InternalService("device %s is not for this controller", header.GetId())
 		}
 
-		device, err := domain.NewDeviceFromDeviceHeader(device)
+		device, err := domain.NewDevice(header)
 		if err != nil {
-			return oops.WithMessage(err, "failed to create device")
+			return nil, oops.WithMessage(err, "failed to create device")
 		}
 
-		l.Repository.AddDevice(device)
+		r.AddDevice(device)
 	}
 
-	return nil
+	return r, nil
 }
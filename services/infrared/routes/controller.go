@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"context"
+
+	"github.com/jakewright/home-automation/libraries/go/distsync"
+	"github.com/jakewright/home-automation/libraries/go/firehose"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+	"github.com/jakewright/home-automation/services/infrared/domain"
+	"github.com/jakewright/home-automation/services/infrared/ir"
+	"github.com/jakewright/home-automation/services/infrared/repository"
+)
+
+type executor interface {
+	Execute(context.Context, []ir.Instruction) error
+}
+
+// Controller handles requests
+type Controller struct {
+	Repository *repository.DeviceRepository
+	IR         executor
+	Dispatcher taxi.Dispatcher
+	Publisher  firehose.Publisher
+}
+
+// remoteSession holds the lock on a device and the
+// copy of the device that changes are made to
+type remoteSession struct {
+	device     domain.RemoteDevice
+	repository *repository.DeviceRepository
+	ir         executor
+	lock       distsync.Locker
+}
+
+// openDevice locks the device and loads its current state
+func (c *Controller) openDevice(ctx context.Context, id string) (deviceSession, error) {
+	lock, err := distsync.Lock(ctx, "device", id)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.Repository.Find(id)
+	if d == nil {
+		lock.Unlock()
+		return nil, oops.NotFound("device %q not found", id)
+	}
+
+	if err := d.LoadState(ctx, c.Dispatcher); err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+
+	return &remoteSession{
+		device:     d,
+		repository: c.Repository,
+		ir:         c.IR,
+		lock:       lock,
+	}, nil
+}
+
+// Device returns the copy of the device
+func (s *remoteSession) Device() domain.Device {
+	return s.device
+}
+
+// Commit sends the queued instructions and then
+// saves the device's new state in the repository
+func (s *remoteSession) Commit(ctx context.Context, _ interface{}) error {
+	if instructions := s.device.Instructions(); len(instructions) > 0 {
+		if err := s.ir.Execute(ctx, instructions); err != nil {
+			return oops.WithMessage(err, "failed to send IR instructions")
+		}
+	}
+
+	s.repository.Save(s.device)
+	return nil
+}
+
+// Close releases the lock on the device
+func (s *remoteSession) Close() {
+	s.lock.Unlock()
+}
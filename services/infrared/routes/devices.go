@@ -0,0 +1,161 @@
+// Code generated by devicegen. DO NOT EDIT.
+
+package routes
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+	slog "github.com/jakewright/home-automation/libraries/go/slog"
+	def "github.com/jakewright/home-automation/services/infrared/def"
+	domain "github.com/jakewright/home-automation/services/infrared/domain"
+)
+
+// deviceSession is a device that has been opened by the
+// controller for the duration of a request. Changes to the
+// device's state only take effect when Commit is called.
+type deviceSession interface {
+	// Device returns the device with its current state
+	Device() domain.Device
+
+	// Commit applies any changes made to the device's state. The
+	// request is passed so that the controller can read any fields
+	// that aren't part of the state, e.g. a transition.
+	Commit(ctx context.Context, req interface{}) error
+
+	// Close releases the device. It is
+	// always called when the request ends.
+	Close()
+}
+
+// deviceOpener must be implemented by the Controller. The openDevice
+// method should return a NotFound error if the device does not exist.
+type deviceOpener interface {
+	openDevice(ctx context.Context, id string) (deviceSession, error)
+}
+
+var _ deviceOpener = (*Controller)(nil)
+
+// deviceState returns the state of the device
+func deviceState(d domain.Device) interface{} {
+	switch d := d.(type) {
+	case domain.OnkyoHTR380Device:
+		return d.State()
+	}
+
+	return nil
+}
+
+// publishStateChange publishes a DeviceStateChangedEvent if the
+// device's state has changed. The new state has already been
+// committed so a failure is logged rather than returned.
+func (c *Controller) publishStateChange(ctx context.Context, d domain.Device, before, after interface{}) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	if err := (&devicedef.DeviceStateChangedEvent{}).
+		SetHeader(*d.DeviceHeader()).
+		SetState(after).
+		SetChangedAt(time.Now()).
+		Publish(ctx, c.Publisher); err != nil {
+		slog.FromContext(ctx).Errorf("Failed to publish state changed event: %v", err, map[string]string{
+			"device_id": d.ID(),
+		})
+	}
+}
+
+// InvokeCommand handles the standard command RPC
+func (c *Controller) InvokeCommand(ctx context.Context, body *devicedef.InvokeCommandRequest) (*devicedef.InvokeCommandResponse, error) {
+	errParams := map[string]string{
+		"device_id": body.GetDeviceId(),
+		"command":   body.GetCommand(),
+	}
+
+	s, err := c.openDevice(ctx, body.GetDeviceId())
+	if err != nil {
+		return nil, oops.WithMetadata(err, errParams)
+	}
+	defer s.Close()
+
+	before := deviceState(s.Device())
+
+	if err := domain.InvokeCommand(s.Device(), body.GetCommand(), body.Args); err != nil {
+		return nil, oops.WithMetadata(err, errParams)
+	}
+
+	if err := s.Commit(ctx, body); err != nil {
+		return nil, oops.WithMessage(err, "failed to commit device state", errParams)
+	}
+
+	c.publishStateChange(ctx, s.Device(), before, deviceState(s.Device()))
+
+	return &devicedef.InvokeCommandResponse{}, nil
+}
+
+// GetOnkyoHTR380 returns the current state of a OnkyoHTR380 device
+func (c *Controller) GetOnkyoHTR380(ctx context.Context, body *def.GetOnkyoHTR380Request) (*def.OnkyoHTR380Response, error) {
+	s, d, err := c.openOnkyoHTR380(ctx, body.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	return onkyoHTR380Response(d), nil
+}
+
+// UpdateOnkyoHTR380 applies the state in the request to a OnkyoHTR380 device
+func (c *Controller) UpdateOnkyoHTR380(ctx context.Context, body *def.UpdateOnkyoHTR380Request) (*def.OnkyoHTR380Response, error) {
+	errParams := map[string]string{
+		"device_id": body.GetDeviceId(),
+	}
+
+	s, d, err := c.openOnkyoHTR380(ctx, body.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	before := d.State()
+
+	if err := d.ApplyState(body.State); err != nil {
+		return nil, oops.WithMetadata(err, errParams)
+	}
+
+	if err := s.Commit(ctx, body); err != nil {
+		return nil, oops.WithMessage(err, "failed to commit device state", errParams)
+	}
+
+	c.publishStateChange(ctx, d, before, d.State())
+
+	return onkyoHTR380Response(d), nil
+}
+
+// openOnkyoHTR380 opens the device and checks that it is a OnkyoHTR380
+func (c *Controller) openOnkyoHTR380(ctx context.Context, id string) (deviceSession, domain.OnkyoHTR380Device, error) {
+	s, err := c.openDevice(ctx, id)
+	if err != nil {
+		return nil, nil, oops.WithMetadata(err, map[string]string{
+			"device_id": id,
+		})
+	}
+
+	d, ok := s.Device().(domain.OnkyoHTR380Device)
+	if !ok {
+		s.Close()
+		return nil, nil, oops.BadRequest("device %q is not a OnkyoHTR380", id)
+	}
+
+	return s, d, nil
+}
+
+func onkyoHTR380Response(d domain.OnkyoHTR380Device) *def.OnkyoHTR380Response {
+	return &def.OnkyoHTR380Response{
+		Header:     d.DeviceHeader(),
+		Properties: domain.OnkyoHTR380Properties(),
+		State:      d.State(),
+	}
+}
@@ -0,0 +1,419 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package routes
+
+import (
+	router "github.com/jakewright/home-automation/libraries/go/router"
+)
+
+func init() {
+	router.RegisterOpenAPI([]byte(openAPIDocument))
+}
+
+// openAPIDocument describes the service's RPCs in OpenAPI 3 format
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Infrared",
+    "version": "1.0.0"
+  },
+  "servers": [
+    {
+      "url": "http://infrared"
+    }
+  ],
+  "paths": {
+    "/command": {
+      "post": {
+        "operationId": "InvokeCommand",
+        "tags": [
+          "Infrared"
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/device.InvokeCommandRequest"
+              }
+            },
+            "application/msgpack": {
+              "schema": {
+                "$ref": "#/components/schemas/device.InvokeCommandRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/device.InvokeCommandResponse"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/device.InvokeCommandResponse"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/onkyo-htr380": {
+      "get": {
+        "operationId": "GetOnkyoHTR380",
+        "tags": [
+          "Infrared"
+        ],
+        "parameters": [
+          {
+            "name": "device_id",
+            "in": "query",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "minLength": 1
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/OnkyoHTR380Response"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/OnkyoHTR380Response"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      },
+      "patch": {
+        "operationId": "UpdateOnkyoHTR380",
+        "tags": [
+          "Infrared"
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UpdateOnkyoHTR380Request"
+              }
+            },
+            "application/msgpack": {
+              "schema": {
+                "$ref": "#/components/schemas/UpdateOnkyoHTR380Request"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/OnkyoHTR380Response"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/OnkyoHTR380Response"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "OnkyoHTR380Response": {
+        "type": "object",
+        "properties": {
+          "header": {
+            "$ref": "#/components/schemas/device.Header"
+          },
+          "properties": {
+            "type": "object",
+            "additionalProperties": {
+              "$ref": "#/components/schemas/device.Property"
+            }
+          },
+          "state": {
+            "$ref": "#/components/schemas/OnkyoHTR380State"
+          }
+        }
+      },
+      "OnkyoHTR380State": {
+        "type": "object",
+        "properties": {
+          "power": {
+            "type": "boolean"
+          }
+        }
+      },
+      "UpdateOnkyoHTR380Request": {
+        "type": "object",
+        "properties": {
+          "device_id": {
+            "type": "string",
+            "minLength": 1
+          },
+          "state": {
+            "$ref": "#/components/schemas/OnkyoHTR380State"
+          }
+        },
+        "required": [
+          "device_id"
+        ]
+      },
+      "device.Header": {
+        "type": "object",
+        "properties": {
+          "attributes": {
+            "type": "object",
+            "additionalProperties": {}
+          },
+          "controller_name": {
+            "type": "string"
+          },
+          "id": {
+            "type": "string",
+            "minLength": 1
+          },
+          "kind": {
+            "type": "string"
+          },
+          "name": {
+            "type": "string",
+            "minLength": 1
+          },
+          "room_id": {
+            "type": "string"
+          },
+          "state_providers": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          },
+          "type": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "id",
+          "name",
+          "type",
+          "kind",
+          "controller_name"
+        ]
+      },
+      "device.InvokeCommandRequest": {
+        "type": "object",
+        "properties": {
+          "args": {
+            "type": "object",
+            "additionalProperties": {}
+          },
+          "command": {
+            "type": "string",
+            "minLength": 1
+          },
+          "device_id": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "required": [
+          "device_id",
+          "command"
+        ]
+      },
+      "device.InvokeCommandResponse": {
+        "type": "object"
+      },
+      "device.Option": {
+        "type": "object",
+        "properties": {
+          "name": {
+            "type": "string",
+            "minLength": 1
+          },
+          "value": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "value",
+          "name"
+        ]
+      },
+      "device.Property": {
+        "type": "object",
+        "properties": {
+          "interpolation": {
+            "type": "string"
+          },
+          "max": {
+            "type": "number",
+            "format": "double"
+          },
+          "min": {
+            "type": "number",
+            "format": "double"
+          },
+          "options": {
+            "type": "array",
+            "items": {
+              "$ref": "#/components/schemas/device.Option"
+            }
+          },
+          "type": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "type"
+        ]
+      },
+      "taxi.Error": {
+        "type": "object",
+        "properties": {
+          "cause": {
+            "$ref": "#/components/schemas/taxi.RemoteError"
+          },
+          "code": {
+            "type": "string"
+          },
+          "error": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "metadata": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            }
+          },
+          "stack": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "error"
+        ]
+      },
+      "taxi.RemoteError": {
+        "type": "object",
+        "properties": {
+          "cause": {
+            "$ref": "#/components/schemas/taxi.RemoteError"
+          },
+          "code": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "metadata": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    }
+  }
+}`
@@ -5,6 +5,7 @@ package routes
 import (
 	context "context"
 
+	def1 "github.com/jakewright/home-automation/libraries/go/device/def"
 	taxi "github.com/jakewright/home-automation/libraries/go/taxi"
 	def "github.com/jakewright/home-automation/services/infrared/def"
 )
@@ -15,14 +16,15 @@ type taxiRouter interface {
 }
 
 type handler interface {
-	GetDevice(ctx context.Context, body *def.GetDeviceRequest) (*def.GetDeviceResponse, error)
-	UpdateDevice(ctx context.Context, body *def.UpdateDeviceRequest) (*def.UpdateDeviceResponse, error)
+	GetOnkyoHTR380(ctx context.Context, body *def.GetOnkyoHTR380Request) (*def.OnkyoHTR380Response, error)
+	UpdateOnkyoHTR380(ctx context.Context, body *def.UpdateOnkyoHTR380Request) (*def.OnkyoHTR380Response, error)
+	InvokeCommand(ctx context.Context, body *def1.InvokeCommandRequest) (*def1.InvokeCommandResponse, error)
 }
 
 // Register adds the service's routes to the router
 func Register(r taxiRouter, h handler) {
-	r.HandleFunc("GET", "/device", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
-		body := &def.GetDeviceRequest{}
+	r.HandleFunc("GET", "/onkyo-htr380", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def.GetOnkyoHTR380Request{}
 		if err := decode(body); err != nil {
 			return nil, err
 		}
@@ -31,11 +33,11 @@ func Register(r taxiRouter, h handler) {
 			return nil, err
 		}
 
-		return h.GetDevice(ctx, body)
+		return h.GetOnkyoHTR380(ctx, body)
 	})
 
-	r.HandleFunc("PATCH", "/device", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
-		body := &def.UpdateDeviceRequest{}
+	r.HandleFunc("PATCH", "/onkyo-htr380", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def.UpdateOnkyoHTR380Request{}
 		if err := decode(body); err != nil {
 			return nil, err
 		}
@@ -44,7 +46,20 @@ func Register(r taxiRouter, h handler) {
 			return nil, err
 		}
 
-		return h.UpdateDevice(ctx, body)
+		return h.UpdateOnkyoHTR380(ctx, body)
+	})
+
+	r.HandleFunc("POST", "/command", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &def1.InvokeCommandRequest{}
+		if err := decode(body); err != nil {
+			return nil, err
+		}
+
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+
+		return h.InvokeCommand(ctx, body)
 	})
 
 }
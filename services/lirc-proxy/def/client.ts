@@ -0,0 +1,24 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import { Fetch, RPCClient } from "../../../libraries/typescript/rpc";
+import {
+  SendOnceRequest,
+  SendOnceResponse,
+} from "./types";
+
+export { RPCError } from "../../../libraries/typescript/rpc";
+
+// LircProxyClient makes requests to the LircProxy service
+export class LircProxyClient extends RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(baseURL: string, fetch?: Fetch) {
+    super(baseURL, fetch);
+  }
+
+  // sendOnce makes a POST request to /send-once
+  sendOnce(body: SendOnceRequest): Promise<SendOnceResponse> {
+    return this.do("POST", "/send-once", body, false);
+  }
+}
@@ -0,0 +1,115 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package lircproxydef
+
+import (
+	context "context"
+	sync "sync"
+	testing "testing"
+
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// MockService is an in-memory implementation of LircProxyService
+// that can be used in tests. Responses are programmed, and requests
+// inspected, via the mock of each RPC, e.g. m.OnSendOnce().
+type MockService struct {
+	sendOnce *SendOnceMock
+}
+
+// Compile-time assertion that the mock implements the interface
+var _ LircProxyService = (*MockService)(nil)
+
+// NewMockService returns a new mock with no responses programmed
+func NewMockService() *MockService {
+	return &MockService{
+		sendOnce: &SendOnceMock{},
+	}
+}
+
+// OnSendOnce returns the mock of the SendOnce RPC
+func (m *MockService) OnSendOnce() *SendOnceMock {
+	return m.sendOnce
+}
+
+// SendOnce records the request and returns the programmed response
+func (m *MockService) SendOnce(ctx context.Context, body *SendOnceRequest) *SendOnceFuture {
+	handler := m.sendOnce.record(body)
+
+	done := make(chan struct{})
+	ftr := &SendOnceFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// SendOnceMock programs and records calls to the SendOnce RPC
+type SendOnceMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *SendOnceRequest) (*SendOnceResponse, error)
+	requests []*SendOnceRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *SendOnceMock) Returns(rsp *SendOnceResponse, err error) *SendOnceMock {
+	return m.Handle(func(context.Context, *SendOnceRequest) (*SendOnceResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *SendOnceMock) Handle(fn func(ctx context.Context, body *SendOnceRequest) (*SendOnceResponse, error)) *SendOnceMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *SendOnceMock) record(body *SendOnceRequest) func(context.Context, *SendOnceRequest) (*SendOnceResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *SendOnceRequest) (*SendOnceResponse, error) {
+			return nil, oops.InternalService("no response programmed for SendOnce")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *SendOnceMock) Requests() []*SendOnceRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*SendOnceRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *SendOnceMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected SendOnce to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *SendOnceMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected SendOnce not to be called but it was called %d times", n)
+	}
+}
@@ -3,6 +3,8 @@
 package lircproxydef
 
 import (
+	utf8 "unicode/utf8"
+
 	oops "github.com/jakewright/home-automation/libraries/go/oops"
 )
 
@@ -44,14 +46,31 @@ func (m *SendOnceRequest) SetKey(v string) *SendOnceRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *SendOnceRequest) Validate() error {
 	if m.Device == nil {
-		return oops.BadRequest("field 'device' is required")
+		return oops.BadRequest("field 'device' is required", map[string]string{
+			"field": "device",
+		})
+	}
+	if m.Device != nil && utf8.RuneCountInString(*m.Device) < 1 {
+		return oops.BadRequest("field 'device' should have length ≥ 1", map[string]string{
+			"field": "device",
+		})
 	}
+
 	if m.Key == nil {
-		return oops.BadRequest("field 'key' is required")
+		return oops.BadRequest("field 'key' is required", map[string]string{
+			"field": "key",
+		})
 	}
+	if m.Key != nil && utf8.RuneCountInString(*m.Key) < 1 {
+		return oops.BadRequest("field 'key' should have length ≥ 1", map[string]string{
+			"field": "key",
+		})
+	}
+
 	return nil
 }
 
@@ -59,7 +78,8 @@ func (m *SendOnceRequest) Validate() error {
 type SendOnceResponse struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *SendOnceResponse) Validate() error {
 	return nil
 }
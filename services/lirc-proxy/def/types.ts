@@ -0,0 +1,11 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+// SendOnceRequest is defined in the .def file
+export interface SendOnceRequest {
+  device: string;
+  key: string;
+}
+
+// SendOnceResponse is defined in the .def file
+export interface SendOnceResponse {
+}
@@ -33,17 +33,6 @@ func (c *Controller) SendOnce(
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	switch {
-	case body.GetDevice() == "":
-		return nil, oops.BadRequest(
-			"field 'device' must not be empty",
-		)
-	case body.GetKey() == "":
-		return nil, oops.BadRequest(
-			"field 'key' must not be empty",
-		)
-	}
-
 	args := []string{sendOnce, body.GetDevice(), body.GetKey()}
 	cmd := exec.CommandContext(ctx, irSend, args...)
 
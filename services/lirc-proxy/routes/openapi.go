@@ -0,0 +1,165 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package routes
+
+import (
+	router "github.com/jakewright/home-automation/libraries/go/router"
+)
+
+func init() {
+	router.RegisterOpenAPI([]byte(openAPIDocument))
+}
+
+// openAPIDocument describes the service's RPCs in OpenAPI 3 format
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "LircProxy",
+    "version": "1.0.0"
+  },
+  "servers": [
+    {
+      "url": "http://lirc-proxy"
+    }
+  ],
+  "paths": {
+    "/send-once": {
+      "post": {
+        "operationId": "SendOnce",
+        "tags": [
+          "LircProxy"
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/SendOnceRequest"
+              }
+            },
+            "application/msgpack": {
+              "schema": {
+                "$ref": "#/components/schemas/SendOnceRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/SendOnceResponse"
+                    }
+                  }
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "data": {
+                      "$ref": "#/components/schemas/SendOnceResponse"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              },
+              "application/msgpack": {
+                "schema": {
+                  "$ref": "#/components/schemas/taxi.Error"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "SendOnceRequest": {
+        "type": "object",
+        "properties": {
+          "device": {
+            "type": "string",
+            "minLength": 1
+          },
+          "key": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "required": [
+          "device",
+          "key"
+        ]
+      },
+      "SendOnceResponse": {
+        "type": "object"
+      },
+      "taxi.Error": {
+        "type": "object",
+        "properties": {
+          "cause": {
+            "$ref": "#/components/schemas/taxi.RemoteError"
+          },
+          "code": {
+            "type": "string"
+          },
+          "error": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "metadata": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            }
+          },
+          "stack": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "error"
+        ]
+      },
+      "taxi.RemoteError": {
+        "type": "object",
+        "properties": {
+          "cause": {
+            "$ref": "#/components/schemas/taxi.RemoteError"
+          },
+          "code": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "metadata": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    }
+  }
+}`
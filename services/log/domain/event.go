@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/jakewright/home-automation/libraries/go/slog"
+	"github.com/jakewright/home-automation/libraries/go/trace"
 )
 
 const jsonIndent = "    "
@@ -88,6 +89,18 @@ func NewEventFromBytes(b []byte) *Event {
 	return &e
 }
 
+// TraceID returns the trace ID from the event's
+// metadata or the empty string if it doesn't have one
+func (e *Event) TraceID() string {
+	metadata, ok := e.Metadata.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	id, _ := metadata[trace.MetadataKey].(string)
+	return id
+}
+
 // Format returns a formatted event that can be passed to an HTML template
 func (e *Event) Format() *FormattedEvent {
 	var metadataPretty []byte
@@ -44,7 +44,7 @@ func main() {
 
 	r := router.New(svc)
 	// r.Get("/", h.HandleRead)
-	// r.Get("/ws", h.HandleWebSocket)
+	// r.Get("/stream", h.HandleStream)
 	// r.Post("/write", h.HandleWrite)
 
 	svc.Run(r, watcher)
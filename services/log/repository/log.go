@@ -31,6 +31,11 @@ type LogQuery struct {
 	// Set this to slog.Severity(0) to return all events.
 	Severity slog.Severity
 
+	// TraceID is the ID of a chain of RPCs and Firehose events.
+	// If not an empty string, only events from that chain
+	// will be returned.
+	TraceID string
+
 	// SinceTime is the earliest inclusive time that events should
 	// be from. Set to the zero value to return all events.
 	SinceTime time.Time
@@ -97,6 +102,11 @@ func (r *LogRepository) findEvents(q *LogQuery) ([]*domain.Event, error) {
 				continue
 			}
 
+			// Filter by trace ID
+			if q.TraceID != "" && event.TraceID() != q.TraceID {
+				continue
+			}
+
 			// Filter by service
 			if len(q.Services) > 0 && !containsService(q.Services, event.Service) {
 				continue
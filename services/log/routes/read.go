@@ -2,7 +2,7 @@ package routes
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"html/template"
 	"net/http"
 	"path"
@@ -10,8 +10,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/gorilla/websocket"
-
 	"github.com/jakewright/home-automation/libraries/go/oops"
 	"github.com/jakewright/home-automation/libraries/go/slog"
 	"github.com/jakewright/home-automation/libraries/go/taxi"
@@ -24,6 +22,7 @@ const htmlTimeFormat = "2006-01-02T15:04"
 type readRequest struct {
 	Services  string `json:"services"`
 	Severity  int    `json:"severity"`
+	TraceID   string `json:"trace_id"`
 	SinceTime string `json:"since_time"` // The HTML datetime-local element formats time weirdly so we need to unmarshal to a string
 	UntilTime string `json:"until_time"`
 	SinceUUID string `json:"since_uuid"`
@@ -34,7 +33,7 @@ type readRequest struct {
 func (h *Handler) HandleRead(w http.ResponseWriter, r *http.Request) {
 	query, metadata, err := decodeBody(r)
 	if err != nil {
-		slog.Errorf("Failed to decode body: %v", err)
+		slog.FromContext(r.Context()).Errorf("Failed to decode body: %v", err)
 		_ = taxi.WriteError(w, err)
 		return
 	}
@@ -49,7 +48,7 @@ func (h *Handler) HandleRead(w http.ResponseWriter, r *http.Request) {
 
 	events, err := h.LogRepository.Find(query)
 	if err != nil {
-		slog.Errorf("Failed to find events: %v", err, metadata)
+		slog.FromContext(r.Context()).Errorf("Failed to find events: %v", err, metadata)
 		_ = taxi.WriteError(w, err)
 		return
 	}
@@ -73,6 +72,7 @@ func (h *Handler) HandleRead(w http.ResponseWriter, r *http.Request) {
 		FormattedEvents []*domain.FormattedEvent
 		Services        string
 		Severity        int
+		TraceID         string
 		SinceTime       string
 		UntilTime       string
 		LastUUID        string
@@ -81,6 +81,7 @@ func (h *Handler) HandleRead(w http.ResponseWriter, r *http.Request) {
 		FormattedEvents: formattedEvents,
 		Services:        strings.Join(query.Services, ", "),
 		Severity:        int(query.Severity),
+		TraceID:         query.TraceID,
 		SinceTime:       query.SinceTime.Format(htmlTimeFormat),
 		UntilTime:       query.UntilTime.Format(htmlTimeFormat),
 		LastUUID:        lastUUID,
@@ -89,7 +90,7 @@ func (h *Handler) HandleRead(w http.ResponseWriter, r *http.Request) {
 
 	t, err := template.ParseFiles(path.Join(h.TemplateDirectory, "index.html"))
 	if err != nil {
-		slog.Errorf("Failed to parse template: %v", err)
+		slog.FromContext(r.Context()).Errorf("Failed to parse template: %v", err)
 		_ = taxi.WriteError(w, err)
 		return
 	}
@@ -97,7 +98,7 @@ func (h *Handler) HandleRead(w http.ResponseWriter, r *http.Request) {
 	var buf bytes.Buffer
 	err = t.Execute(&buf, rsp)
 	if err != nil {
-		slog.Errorf("Failed to execute template: %v", err)
+		slog.FromContext(r.Context()).Errorf("Failed to execute template: %v", err)
 		_ = taxi.WriteError(w, err)
 		return
 	}
@@ -105,70 +106,38 @@ func (h *Handler) HandleRead(w http.ResponseWriter, r *http.Request) {
 	_, _ = buf.WriteTo(w)
 }
 
-var upgrader = websocket.Upgrader{
-	CheckOrigin: func(_ *http.Request) bool {
-		return true
-	},
-}
-
-// HandleWebSocket sends new log lines over a web socket
-func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	query, metadata, err := decodeBody(r)
-	if err != nil {
-		slog.Errorf("Failed to decode body: %v", err)
-		return
+// HandleStream sends new log lines as they are written
+func (h *Handler) HandleStream(ctx context.Context, decode taxi.Decoder, send taxi.Sender) error {
+	body := readRequest{}
+	if err := decode(&body); err != nil {
+		return err
 	}
 
-	// Upgrade the request to a WebSocket connection
-	ws, err := upgrader.Upgrade(w, r, nil)
+	query, err := parseQuery(&body)
 	if err != nil {
-		slog.Errorf("Failed to create websocket upgrader: %v", err, metadata)
-		return
+		return err
 	}
-	defer func() { _ = ws.Close() }()
-
-	// A loop must be started that reads and discards messages until a non-nil
-	// error is received so that close, ping and pong messages are processed.
-	// Close a channel to signal to the for loop below that the client has gone away.
-	done := make(chan struct{})
-	go func() {
-		defer close(done)
-		readLoop(ws)
-	}()
 
 	// Subscribe to new events that match the query in the request
 	events := make(chan *domain.Event, 50)
-	err = h.Watcher.Subscribe(events, query)
-	if err != nil {
-		slog.Errorf("Failed to subscribe to the watcher: %v", err, metadata)
-		return
+	if err := h.Watcher.Subscribe(events, query); err != nil {
+		return oops.WithMessage(err, "failed to subscribe to the watcher")
 	}
-	defer func() {
-		h.Watcher.Unsubscribe(events)
-	}()
+	defer h.Watcher.Unsubscribe(events)
 
 	for {
 		select {
 		case event, ok := <-events:
 			if !ok {
-				slog.Errorf("Events channel unexpectedly closed")
-				return
+				return oops.InternalService("events channel unexpectedly closed")
 			}
 
-			formattedEvent := event.Format()
-			b, err := json.Marshal(formattedEvent)
-			if err != nil {
-				slog.Errorf("Failed to marshal event: %v", err, metadata)
-				continue
+			if err := send(event.Format()); err != nil {
+				return err
 			}
-
-			if err := ws.WriteMessage(websocket.TextMessage, b); err != nil {
-				slog.Errorf("Failed to write message to websocket: %v", err, metadata)
-				return
-			}
-		case <-done:
-			// The WebSocket is closed so silently return
-			return
+		case <-ctx.Done():
+			// The client has gone away so silently return
+			return nil
 		}
 	}
 }
@@ -187,6 +156,7 @@ func decodeBody(r *http.Request) (*repository.LogQuery, map[string]string, error
 	metadata := map[string]string{
 		"services":  strings.Join(query.Services, ", "),
 		"severity":  query.Severity.String(),
+		"traceID":   query.TraceID,
 		"sinceTime": query.SinceTime.Format(time.RFC3339),
 		"untilTime": query.UntilTime.Format(time.RFC3339),
 		"sinceUUID": query.SinceUUID,
@@ -224,18 +194,10 @@ func parseQuery(body *readRequest) (*repository.LogQuery, error) {
 	return &repository.LogQuery{
 		Services:  services,
 		Severity:  severity,
+		TraceID:   body.TraceID,
 		SinceTime: sinceTime,
 		UntilTime: untilTime,
 		SinceUUID: body.SinceUUID,
 		Reverse:   body.Reverse,
 	}, nil
 }
-
-func readLoop(c *websocket.Conn) {
-	for {
-		if _, _, err := c.NextReader(); err != nil {
-			_ = c.Close()
-			break
-		}
-	}
-}
@@ -12,12 +12,20 @@ import (
 	"github.com/jakewright/home-automation/libraries/go/firehose"
 	"github.com/jakewright/home-automation/libraries/go/oops"
 	"github.com/jakewright/home-automation/libraries/go/slog"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
 	scenedef "github.com/jakewright/home-automation/services/scene/def"
 	"github.com/jakewright/home-automation/services/scene/domain"
 )
 
-// HandleSetSceneEvent sets the scene
-var HandleSetSceneEvent scenedef.SetSceneEventHandler = func(body *scenedef.SetSceneEvent) firehose.Result {
+// NewSetSceneEventHandler returns a handler that sets the scene. The
+// dispatcher is used to make requests to the device controllers.
+func NewSetSceneEventHandler(dispatcher taxi.Dispatcher) scenedef.SetSceneEventHandler {
+	return func(ctx context.Context, body *scenedef.SetSceneEvent) firehose.Result {
+		return setScene(ctx, dispatcher, body)
+	}
+}
+
+func setScene(ctx context.Context, dispatcher taxi.Dispatcher, body *scenedef.SetSceneEvent) firehose.Result {
 	metadata := map[string]string{
 		"scene_id": strconv.Itoa(int(body.SceneId)),
 	}
@@ -32,13 +40,13 @@ var HandleSetSceneEvent scenedef.SetSceneEventHandler = func(body *scenedef.SetS
 		return firehose.Discard(err)
 	}
 
-	lock, err := distsync.Lock(nil, "scene", body.SceneId)
+	lock, err := distsync.Lock(ctx, "scene", body.SceneId)
 	if err != nil {
 		return firehose.Fail(oops.WithMetadata(err, metadata))
 	}
 	defer lock.Unlock()
 
-	slog.Infof("Setting scene %d...", body.SceneId)
+	slog.FromContext(ctx).Infof("Setting scene %d...", body.SceneId)
 
 	// Organise the actions into stages
 	stages := constructStages(scene)
@@ -47,7 +55,7 @@ var HandleSetSceneEvent scenedef.SetSceneEventHandler = func(body *scenedef.SetS
 		var g errgroup.Group
 		for _, action := range stage {
 			g.Go(func() error {
-				return action.Perform(context.TODO())
+				return action.Perform(ctx, dispatcher)
 			})
 		}
 		if err := g.Wait(); err != nil {
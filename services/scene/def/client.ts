@@ -0,0 +1,52 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import { Fetch, RPCClient } from "../../../libraries/typescript/rpc";
+import {
+  CreateSceneRequest,
+  CreateSceneResponse,
+  DeleteSceneRequest,
+  DeleteSceneResponse,
+  ListScenesRequest,
+  ListScenesResponse,
+  ReadSceneRequest,
+  ReadSceneResponse,
+  SetSceneRequest,
+  SetSceneResponse,
+} from "./types";
+
+export { RPCError } from "../../../libraries/typescript/rpc";
+
+// SceneClient makes requests to the Scene service
+export class SceneClient extends RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(baseURL: string, fetch?: Fetch) {
+    super(baseURL, fetch);
+  }
+
+  // createScene makes a POST request to /scenes
+  createScene(body: CreateSceneRequest): Promise<CreateSceneResponse> {
+    return this.do("POST", "/scenes", body, false);
+  }
+
+  // readScene makes a GET request to /scene
+  readScene(body: ReadSceneRequest): Promise<ReadSceneResponse> {
+    return this.do("GET", "/scene", body, true);
+  }
+
+  // listScenes makes a GET request to /scenes
+  listScenes(body: ListScenesRequest): Promise<ListScenesResponse> {
+    return this.do("GET", "/scenes", body, true);
+  }
+
+  // deleteScene makes a DELETE request to /scene
+  deleteScene(body: DeleteSceneRequest): Promise<DeleteSceneResponse> {
+    return this.do("DELETE", "/scene", body, true);
+  }
+
+  // setScene makes a POST request to /scene/set
+  setScene(body: SetSceneRequest): Promise<SetSceneResponse> {
+    return this.do("POST", "/scene/set", body, false);
+  }
+}
@@ -18,8 +18,9 @@ func (m *SetSceneEvent) Publish(ctx context.Context, p firehose.Publisher) error
 	return p.Publish(ctx, "set-scene", m)
 }
 
-// SetSceneEventHandler implements the necessary functions to be a Firehose handler
-type SetSceneEventHandler func(*SetSceneEvent) firehose.Result
+// SetSceneEventHandler implements the necessary functions to be a Firehose handler.
+// The context carries the trace ID of the chain that published the event.
+type SetSceneEventHandler func(context.Context, *SetSceneEvent) firehose.Result
 
 // HandleEvent handles the Firehose event
 func (h SetSceneEventHandler) HandleEvent(ctx context.Context, decode firehose.Decoder) firehose.Result {
@@ -27,5 +28,5 @@ func (h SetSceneEventHandler) HandleEvent(ctx context.Context, decode firehose.D
 	if err := decode(&body); err != nil {
 		return firehose.Discard(oops.WithMessage(err, "failed to unmarshal payload"))
 	}
-	return h(&body)
+	return h(ctx, &body)
 }
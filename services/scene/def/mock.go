@@ -0,0 +1,471 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package scenedef
+
+import (
+	context "context"
+	sync "sync"
+	testing "testing"
+
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// MockService is an in-memory implementation of SceneService
+// that can be used in tests. Responses are programmed, and requests
+// inspected, via the mock of each RPC, e.g. m.OnCreateScene().
+type MockService struct {
+	createScene *CreateSceneMock
+	readScene   *ReadSceneMock
+	listScenes  *ListScenesMock
+	deleteScene *DeleteSceneMock
+	setScene    *SetSceneMock
+}
+
+// Compile-time assertion that the mock implements the interface
+var _ SceneService = (*MockService)(nil)
+
+// NewMockService returns a new mock with no responses programmed
+func NewMockService() *MockService {
+	return &MockService{
+		createScene: &CreateSceneMock{},
+		readScene:   &ReadSceneMock{},
+		listScenes:  &ListScenesMock{},
+		deleteScene: &DeleteSceneMock{},
+		setScene:    &SetSceneMock{},
+	}
+}
+
+// OnCreateScene returns the mock of the CreateScene RPC
+func (m *MockService) OnCreateScene() *CreateSceneMock {
+	return m.createScene
+}
+
+// CreateScene records the request and returns the programmed response
+func (m *MockService) CreateScene(ctx context.Context, body *CreateSceneRequest) *CreateSceneFuture {
+	handler := m.createScene.record(body)
+
+	done := make(chan struct{})
+	ftr := &CreateSceneFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// CreateSceneMock programs and records calls to the CreateScene RPC
+type CreateSceneMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *CreateSceneRequest) (*CreateSceneResponse, error)
+	requests []*CreateSceneRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *CreateSceneMock) Returns(rsp *CreateSceneResponse, err error) *CreateSceneMock {
+	return m.Handle(func(context.Context, *CreateSceneRequest) (*CreateSceneResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *CreateSceneMock) Handle(fn func(ctx context.Context, body *CreateSceneRequest) (*CreateSceneResponse, error)) *CreateSceneMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *CreateSceneMock) record(body *CreateSceneRequest) func(context.Context, *CreateSceneRequest) (*CreateSceneResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *CreateSceneRequest) (*CreateSceneResponse, error) {
+			return nil, oops.InternalService("no response programmed for CreateScene")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *CreateSceneMock) Requests() []*CreateSceneRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*CreateSceneRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *CreateSceneMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected CreateScene to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *CreateSceneMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected CreateScene not to be called but it was called %d times", n)
+	}
+}
+
+// OnReadScene returns the mock of the ReadScene RPC
+func (m *MockService) OnReadScene() *ReadSceneMock {
+	return m.readScene
+}
+
+// ReadScene records the request and returns the programmed response
+func (m *MockService) ReadScene(ctx context.Context, body *ReadSceneRequest) *ReadSceneFuture {
+	handler := m.readScene.record(body)
+
+	done := make(chan struct{})
+	ftr := &ReadSceneFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// ReadSceneMock programs and records calls to the ReadScene RPC
+type ReadSceneMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *ReadSceneRequest) (*ReadSceneResponse, error)
+	requests []*ReadSceneRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *ReadSceneMock) Returns(rsp *ReadSceneResponse, err error) *ReadSceneMock {
+	return m.Handle(func(context.Context, *ReadSceneRequest) (*ReadSceneResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *ReadSceneMock) Handle(fn func(ctx context.Context, body *ReadSceneRequest) (*ReadSceneResponse, error)) *ReadSceneMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *ReadSceneMock) record(body *ReadSceneRequest) func(context.Context, *ReadSceneRequest) (*ReadSceneResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *ReadSceneRequest) (*ReadSceneResponse, error) {
+			return nil, oops.InternalService("no response programmed for ReadScene")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *ReadSceneMock) Requests() []*ReadSceneRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*ReadSceneRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *ReadSceneMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected ReadScene to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *ReadSceneMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected ReadScene not to be called but it was called %d times", n)
+	}
+}
+
+// OnListScenes returns the mock of the ListScenes RPC
+func (m *MockService) OnListScenes() *ListScenesMock {
+	return m.listScenes
+}
+
+// ListScenes records the request and returns the programmed response
+func (m *MockService) ListScenes(ctx context.Context, body *ListScenesRequest) *ListScenesFuture {
+	handler := m.listScenes.record(body)
+
+	done := make(chan struct{})
+	ftr := &ListScenesFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// ListScenesMock programs and records calls to the ListScenes RPC
+type ListScenesMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *ListScenesRequest) (*ListScenesResponse, error)
+	requests []*ListScenesRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *ListScenesMock) Returns(rsp *ListScenesResponse, err error) *ListScenesMock {
+	return m.Handle(func(context.Context, *ListScenesRequest) (*ListScenesResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *ListScenesMock) Handle(fn func(ctx context.Context, body *ListScenesRequest) (*ListScenesResponse, error)) *ListScenesMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *ListScenesMock) record(body *ListScenesRequest) func(context.Context, *ListScenesRequest) (*ListScenesResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *ListScenesRequest) (*ListScenesResponse, error) {
+			return nil, oops.InternalService("no response programmed for ListScenes")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *ListScenesMock) Requests() []*ListScenesRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*ListScenesRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *ListScenesMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected ListScenes to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *ListScenesMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected ListScenes not to be called but it was called %d times", n)
+	}
+}
+
+// OnDeleteScene returns the mock of the DeleteScene RPC
+func (m *MockService) OnDeleteScene() *DeleteSceneMock {
+	return m.deleteScene
+}
+
+// DeleteScene records the request and returns the programmed response
+func (m *MockService) DeleteScene(ctx context.Context, body *DeleteSceneRequest) *DeleteSceneFuture {
+	handler := m.deleteScene.record(body)
+
+	done := make(chan struct{})
+	ftr := &DeleteSceneFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// DeleteSceneMock programs and records calls to the DeleteScene RPC
+type DeleteSceneMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *DeleteSceneRequest) (*DeleteSceneResponse, error)
+	requests []*DeleteSceneRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *DeleteSceneMock) Returns(rsp *DeleteSceneResponse, err error) *DeleteSceneMock {
+	return m.Handle(func(context.Context, *DeleteSceneRequest) (*DeleteSceneResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *DeleteSceneMock) Handle(fn func(ctx context.Context, body *DeleteSceneRequest) (*DeleteSceneResponse, error)) *DeleteSceneMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *DeleteSceneMock) record(body *DeleteSceneRequest) func(context.Context, *DeleteSceneRequest) (*DeleteSceneResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *DeleteSceneRequest) (*DeleteSceneResponse, error) {
+			return nil, oops.InternalService("no response programmed for DeleteScene")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *DeleteSceneMock) Requests() []*DeleteSceneRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*DeleteSceneRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *DeleteSceneMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected DeleteScene to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *DeleteSceneMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected DeleteScene not to be called but it was called %d times", n)
+	}
+}
+
+// OnSetScene returns the mock of the SetScene RPC
+func (m *MockService) OnSetScene() *SetSceneMock {
+	return m.setScene
+}
+
+// SetScene records the request and returns the programmed response
+func (m *MockService) SetScene(ctx context.Context, body *SetSceneRequest) *SetSceneFuture {
+	handler := m.setScene.record(body)
+
+	done := make(chan struct{})
+	ftr := &SetSceneFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// SetSceneMock programs and records calls to the SetScene RPC
+type SetSceneMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *SetSceneRequest) (*SetSceneResponse, error)
+	requests []*SetSceneRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *SetSceneMock) Returns(rsp *SetSceneResponse, err error) *SetSceneMock {
+	return m.Handle(func(context.Context, *SetSceneRequest) (*SetSceneResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *SetSceneMock) Handle(fn func(ctx context.Context, body *SetSceneRequest) (*SetSceneResponse, error)) *SetSceneMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *SetSceneMock) record(body *SetSceneRequest) func(context.Context, *SetSceneRequest) (*SetSceneResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *SetSceneRequest) (*SetSceneResponse, error) {
+			return nil, oops.InternalService("no response programmed for SetScene")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *SetSceneMock) Requests() []*SetSceneRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*SetSceneRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *SetSceneMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected SetScene to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *SetSceneMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected SetScene not to be called but it was called %d times", n)
+	}
+}
@@ -3,11 +3,82 @@
 package scenedef
 
 import (
+	json "encoding/json"
+	fmt "fmt"
 	time "time"
+	utf8 "unicode/utf8"
 
 	oops "github.com/jakewright/home-automation/libraries/go/oops"
 )
 
+// PropertyType is defined in the .def file
+type PropertyType string
+
+// Values of PropertyType
+const (
+	PropertyType_STRING  PropertyType = "string"
+	PropertyType_BOOLEAN PropertyType = "boolean"
+	PropertyType_NUMBER  PropertyType = "number"
+	PropertyType_NULL    PropertyType = "null"
+)
+
+// Validate returns an error if the value is not one of the values of PropertyType
+func (e PropertyType) Validate() error {
+	switch e {
+	case PropertyType_STRING, PropertyType_BOOLEAN, PropertyType_NUMBER, PropertyType_NULL:
+		return nil
+	}
+
+	return oops.BadRequest("%q is not a valid PropertyType", string(e))
+}
+
+// MarshalJSON returns an error if the value is not one of the values of PropertyType
+func (e PropertyType) MarshalJSON() ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON returns an error if the value is not one of the values of PropertyType
+func (e *PropertyType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if err := PropertyType(s).Validate(); err != nil {
+		return err
+	}
+
+	*e = PropertyType(s)
+	return nil
+}
+
+// prefixFieldPath returns a copy of an error returned by the Validate
+// function of a nested message with the path of the nested field
+// prepended to the field path in the error's metadata
+func prefixFieldPath(err error, path string) error {
+	e, ok := err.(*oops.Error)
+	if !ok {
+		return err
+	}
+
+	metadata := map[string]string{}
+	for k, v := range e.GetMetadata() {
+		metadata[k] = v
+	}
+
+	if field, ok := metadata["field"]; ok {
+		path += "." + field
+	}
+	metadata["field"] = path
+
+	// Validate functions only return bad request errors
+	return oops.BadRequest("%s", e.GetMessage(), metadata)
+}
+
 // Scene is defined in the .def file
 type Scene struct {
 	Id        *uint32    `json:"id,omitempty"`
@@ -114,13 +185,12 @@ func (m *Scene) SetUpdatedAt(v time.Time) *Scene {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *Scene) Validate() error {
-	if m.Actions != nil {
-		for _, r := range m.Actions {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+	for i, r := range m.Actions {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("actions[%d]", i))
 		}
 	}
 
@@ -129,17 +199,17 @@ func (m *Scene) Validate() error {
 
 // Action is defined in the .def file
 type Action struct {
-	Stage          *int32     `json:"stage,omitempty"`
-	Sequence       *int32     `json:"sequence,omitempty"`
-	Func           *string    `json:"func,omitempty"`
-	ControllerName *string    `json:"controller_name,omitempty"`
-	DeviceId       *string    `json:"device_id,omitempty"`
-	Command        *string    `json:"command,omitempty"`
-	Property       *string    `json:"property,omitempty"`
-	PropertyValue  *string    `json:"property_value,omitempty"`
-	PropertyType   *string    `json:"property_type,omitempty"`
-	CreatedAt      *time.Time `json:"created_at,omitempty"`
-	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+	Stage          *int32        `json:"stage,omitempty"`
+	Sequence       *int32        `json:"sequence,omitempty"`
+	Func           *string       `json:"func,omitempty"`
+	Command        *string       `json:"command,omitempty"`
+	Property       *string       `json:"property,omitempty"`
+	ControllerName *string       `json:"controller_name,omitempty"`
+	DeviceId       *string       `json:"device_id,omitempty"`
+	PropertyValue  *string       `json:"property_value,omitempty"`
+	PropertyType   *PropertyType `json:"property_type,omitempty"`
+	CreatedAt      *time.Time    `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time    `json:"updated_at,omitempty"`
 }
 
 // GetStage returns the de-referenced value of Stage.
@@ -184,73 +254,79 @@ func (m *Action) GetFunc() (val string, set bool) {
 	return *m.Func, true
 }
 
-// SetFunc sets the value of Func
+// SetFunc sets the value of Func and clears the other fields of the kind oneof
 func (m *Action) SetFunc(v string) *Action {
+	m.Command = nil
+	m.Property = nil
 	m.Func = &v
 	return m
 }
 
-// GetControllerName returns the de-referenced value of ControllerName.
+// GetCommand returns the de-referenced value of Command.
 // The second return value states whether the field was set.
-func (m *Action) GetControllerName() (val string, set bool) {
-	if m.ControllerName == nil {
+func (m *Action) GetCommand() (val string, set bool) {
+	if m.Command == nil {
 		return
 	}
 
-	return *m.ControllerName, true
+	return *m.Command, true
 }
 
-// SetControllerName sets the value of ControllerName
-func (m *Action) SetControllerName(v string) *Action {
-	m.ControllerName = &v
+// SetCommand sets the value of Command and clears the other fields of the kind oneof
+func (m *Action) SetCommand(v string) *Action {
+	m.Func = nil
+	m.Property = nil
+	m.Command = &v
 	return m
 }
 
-// GetDeviceId returns the de-referenced value of DeviceId.
+// GetProperty returns the de-referenced value of Property.
 // The second return value states whether the field was set.
-func (m *Action) GetDeviceId() (val string, set bool) {
-	if m.DeviceId == nil {
+func (m *Action) GetProperty() (val string, set bool) {
+	if m.Property == nil {
 		return
 	}
 
-	return *m.DeviceId, true
+	return *m.Property, true
 }
 
-// SetDeviceId sets the value of DeviceId
-func (m *Action) SetDeviceId(v string) *Action {
-	m.DeviceId = &v
+// SetProperty sets the value of Property and clears the other fields of the kind oneof
+func (m *Action) SetProperty(v string) *Action {
+	m.Func = nil
+	m.Command = nil
+	m.Property = &v
 	return m
 }
 
-// GetCommand returns the de-referenced value of Command.
+// GetControllerName returns the de-referenced value of ControllerName.
 // The second return value states whether the field was set.
-func (m *Action) GetCommand() (val string, set bool) {
-	if m.Command == nil {
+func (m *Action) GetControllerName() (val string, set bool) {
+	if m.ControllerName == nil {
 		return
 	}
 
-	return *m.Command, true
+	return *m.ControllerName, true
 }
 
-// SetCommand sets the value of Command
-func (m *Action) SetCommand(v string) *Action {
-	m.Command = &v
+// SetControllerName sets the value of ControllerName
+func (m *Action) SetControllerName(v string) *Action {
+	m.ControllerName = &v
 	return m
 }
 
-// GetProperty returns the de-referenced value of Property.
+// GetDeviceId returns the de-referenced value of DeviceId.
 // The second return value states whether the field was set.
-func (m *Action) GetProperty() (val string, set bool) {
-	if m.Property == nil {
+func (m *Action) GetDeviceId() (val string, set bool) {
+	if m.DeviceId == nil {
 		return
 	}
 
-	return *m.Property, true
+	return *m.DeviceId, true
 }
 
-// SetProperty sets the value of Property
-func (m *Action) SetProperty(v string) *Action {
-	m.Property = &v
+// SetDeviceId sets the value of DeviceId
+func (m *Action) SetDeviceId(v string) *Action {
+	m.DeviceId = &v
 	return m
 }
 
@@ -272,7 +348,7 @@ func (m *Action) SetPropertyValue(v string) *Action {
 
 // GetPropertyType returns the de-referenced value of PropertyType.
 // The second return value states whether the field was set.
-func (m *Action) GetPropertyType() (val string, set bool) {
+func (m *Action) GetPropertyType() (val PropertyType, set bool) {
 	if m.PropertyType == nil {
 		return
 	}
@@ -281,7 +357,7 @@ func (m *Action) GetPropertyType() (val string, set bool) {
 }
 
 // SetPropertyType sets the value of PropertyType
-func (m *Action) SetPropertyType(v string) *Action {
+func (m *Action) SetPropertyType(v PropertyType) *Action {
 	m.PropertyType = &v
 	return m
 }
@@ -318,8 +394,92 @@ func (m *Action) SetUpdatedAt(v time.Time) *Action {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Action_Kind identifies the field of the kind oneof that is set
+type Action_Kind string
+
+// Fields of the kind oneof
+const (
+	Action_Kind_None     Action_Kind = ""
+	Action_Kind_Func     Action_Kind = "func"
+	Action_Kind_Command  Action_Kind = "command"
+	Action_Kind_Property Action_Kind = "property"
+)
+
+// WhichKind returns the field of the kind oneof that is set.
+// If none of the fields are set, Action_Kind_None is returned.
+func (m *Action) WhichKind() Action_Kind {
+	if set := m.setKind(); len(set) > 0 {
+		return set[0]
+	}
+
+	return Action_Kind_None
+}
+
+// setKind returns all of the fields of the kind oneof that are set
+func (m *Action) setKind() []Action_Kind {
+	var set []Action_Kind
+	if m.Func != nil {
+		set = append(set, Action_Kind_Func)
+	}
+	if m.Command != nil {
+		set = append(set, Action_Kind_Command)
+	}
+	if m.Property != nil {
+		set = append(set, Action_Kind_Property)
+	}
+	return set
+}
+
+// validateOneofs returns an error if more than one field of a oneof
+// is set. If strict is true, it also returns an error if none of the
+// fields of a oneof are set.
+func (m *Action) validateOneofs(strict bool) error {
+	switch set := m.setKind(); {
+	case len(set) > 1:
+		return oops.BadRequest("only one of 'func', 'command' and 'property' can be set")
+	case strict && len(set) == 0:
+		return oops.BadRequest("one of 'func', 'command' and 'property' must be set")
+	}
+
+	return nil
+}
+
+// MarshalJSON returns an error if more than one field of a oneof is set
+func (m *Action) MarshalJSON() ([]byte, error) {
+	if err := m.validateOneofs(false); err != nil {
+		return nil, err
+	}
+
+	// The alias has the same fields but none of the
+	// methods so this does not recurse infinitely.
+	type alias Action
+	return json.Marshal((*alias)(m))
+}
+
+// UnmarshalJSON returns an error if more than one field of a oneof is set
+func (m *Action) UnmarshalJSON(b []byte) error {
+	type alias Action
+	if err := json.Unmarshal(b, (*alias)(m)); err != nil {
+		return err
+	}
+
+	return m.validateOneofs(false)
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *Action) Validate() error {
+	if m.PropertyType != nil {
+		if err := m.PropertyType.Validate(); err != nil {
+			return oops.WithMessage(err, "invalid value in field 'property_type'", map[string]string{
+				"field": "property_type",
+			})
+		}
+	}
+
+	if err := m.validateOneofs(true); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -378,39 +538,56 @@ func (m *CreateSceneRequest) SetActions(v []*CreateSceneRequest_Action) *CreateS
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *CreateSceneRequest) Validate() error {
 	if m.Name == nil {
-		return oops.BadRequest("field 'name' is required")
+		return oops.BadRequest("field 'name' is required", map[string]string{
+			"field": "name",
+		})
 	}
+	if m.Name != nil && utf8.RuneCountInString(*m.Name) < 1 {
+		return oops.BadRequest("field 'name' should have length ≥ 1", map[string]string{
+			"field": "name",
+		})
+	}
+
 	if m.OwnerId == nil {
-		return oops.BadRequest("field 'owner_id' is required")
+		return oops.BadRequest("field 'owner_id' is required", map[string]string{
+			"field": "owner_id",
+		})
 	}
-	if m.Actions != nil {
-		for _, r := range m.Actions {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+	for i, r := range m.Actions {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("actions[%d]", i))
 		}
 	}
 
 	if m.Actions == nil {
-		return oops.BadRequest("field 'actions' is required")
+		return oops.BadRequest("field 'actions' is required", map[string]string{
+			"field": "actions",
+		})
+	}
+	if m.Actions != nil && len(m.Actions) < 1 {
+		return oops.BadRequest("field 'actions' should have length ≥ 1", map[string]string{
+			"field": "actions",
+		})
 	}
+
 	return nil
 }
 
 // CreateSceneRequest_Action is defined in the .def file
 type CreateSceneRequest_Action struct {
-	Stage          *int32  `json:"stage,omitempty"`
-	Sequence       *int32  `json:"sequence,omitempty"`
-	Func           *string `json:"func,omitempty"`
-	ControllerName *string `json:"controller_name,omitempty"`
-	DeviceId       *string `json:"device_id,omitempty"`
-	Command        *string `json:"command,omitempty"`
-	Property       *string `json:"property,omitempty"`
-	PropertyValue  *string `json:"property_value,omitempty"`
-	PropertyType   *string `json:"property_type,omitempty"`
+	Stage          *int32        `json:"stage,omitempty"`
+	Sequence       *int32        `json:"sequence,omitempty"`
+	Func           *string       `json:"func,omitempty"`
+	Command        *string       `json:"command,omitempty"`
+	Property       *string       `json:"property,omitempty"`
+	ControllerName *string       `json:"controller_name,omitempty"`
+	DeviceId       *string       `json:"device_id,omitempty"`
+	PropertyValue  *string       `json:"property_value,omitempty"`
+	PropertyType   *PropertyType `json:"property_type,omitempty"`
 }
 
 // GetStage returns the de-referenced value of Stage.
@@ -455,73 +632,79 @@ func (m *CreateSceneRequest_Action) GetFunc() (val string, set bool) {
 	return *m.Func, true
 }
 
-// SetFunc sets the value of Func
+// SetFunc sets the value of Func and clears the other fields of the kind oneof
 func (m *CreateSceneRequest_Action) SetFunc(v string) *CreateSceneRequest_Action {
+	m.Command = nil
+	m.Property = nil
 	m.Func = &v
 	return m
 }
 
-// GetControllerName returns the de-referenced value of ControllerName.
+// GetCommand returns the de-referenced value of Command.
 // The second return value states whether the field was set.
-func (m *CreateSceneRequest_Action) GetControllerName() (val string, set bool) {
-	if m.ControllerName == nil {
+func (m *CreateSceneRequest_Action) GetCommand() (val string, set bool) {
+	if m.Command == nil {
 		return
 	}
 
-	return *m.ControllerName, true
+	return *m.Command, true
 }
 
-// SetControllerName sets the value of ControllerName
-func (m *CreateSceneRequest_Action) SetControllerName(v string) *CreateSceneRequest_Action {
-	m.ControllerName = &v
+// SetCommand sets the value of Command and clears the other fields of the kind oneof
+func (m *CreateSceneRequest_Action) SetCommand(v string) *CreateSceneRequest_Action {
+	m.Func = nil
+	m.Property = nil
+	m.Command = &v
 	return m
 }
 
-// GetDeviceId returns the de-referenced value of DeviceId.
+// GetProperty returns the de-referenced value of Property.
 // The second return value states whether the field was set.
-func (m *CreateSceneRequest_Action) GetDeviceId() (val string, set bool) {
-	if m.DeviceId == nil {
+func (m *CreateSceneRequest_Action) GetProperty() (val string, set bool) {
+	if m.Property == nil {
 		return
 	}
 
-	return *m.DeviceId, true
+	return *m.Property, true
 }
 
-// SetDeviceId sets the value of DeviceId
-func (m *CreateSceneRequest_Action) SetDeviceId(v string) *CreateSceneRequest_Action {
-	m.DeviceId = &v
+// SetProperty sets the value of Property and clears the other fields of the kind oneof
+func (m *CreateSceneRequest_Action) SetProperty(v string) *CreateSceneRequest_Action {
+	m.Func = nil
+	m.Command = nil
+	m.Property = &v
 	return m
 }
 
-// GetCommand returns the de-referenced value of Command.
+// GetControllerName returns the de-referenced value of ControllerName.
 // The second return value states whether the field was set.
-func (m *CreateSceneRequest_Action) GetCommand() (val string, set bool) {
-	if m.Command == nil {
+func (m *CreateSceneRequest_Action) GetControllerName() (val string, set bool) {
+	if m.ControllerName == nil {
 		return
 	}
 
-	return *m.Command, true
+	return *m.ControllerName, true
 }
 
-// SetCommand sets the value of Command
-func (m *CreateSceneRequest_Action) SetCommand(v string) *CreateSceneRequest_Action {
-	m.Command = &v
+// SetControllerName sets the value of ControllerName
+func (m *CreateSceneRequest_Action) SetControllerName(v string) *CreateSceneRequest_Action {
+	m.ControllerName = &v
 	return m
 }
 
-// GetProperty returns the de-referenced value of Property.
+// GetDeviceId returns the de-referenced value of DeviceId.
 // The second return value states whether the field was set.
-func (m *CreateSceneRequest_Action) GetProperty() (val string, set bool) {
-	if m.Property == nil {
+func (m *CreateSceneRequest_Action) GetDeviceId() (val string, set bool) {
+	if m.DeviceId == nil {
 		return
 	}
 
-	return *m.Property, true
+	return *m.DeviceId, true
 }
 
-// SetProperty sets the value of Property
-func (m *CreateSceneRequest_Action) SetProperty(v string) *CreateSceneRequest_Action {
-	m.Property = &v
+// SetDeviceId sets the value of DeviceId
+func (m *CreateSceneRequest_Action) SetDeviceId(v string) *CreateSceneRequest_Action {
+	m.DeviceId = &v
 	return m
 }
 
@@ -543,7 +726,7 @@ func (m *CreateSceneRequest_Action) SetPropertyValue(v string) *CreateSceneReque
 
 // GetPropertyType returns the de-referenced value of PropertyType.
 // The second return value states whether the field was set.
-func (m *CreateSceneRequest_Action) GetPropertyType() (val string, set bool) {
+func (m *CreateSceneRequest_Action) GetPropertyType() (val PropertyType, set bool) {
 	if m.PropertyType == nil {
 		return
 	}
@@ -552,13 +735,97 @@ func (m *CreateSceneRequest_Action) GetPropertyType() (val string, set bool) {
 }
 
 // SetPropertyType sets the value of PropertyType
-func (m *CreateSceneRequest_Action) SetPropertyType(v string) *CreateSceneRequest_Action {
+func (m *CreateSceneRequest_Action) SetPropertyType(v PropertyType) *CreateSceneRequest_Action {
 	m.PropertyType = &v
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// CreateSceneRequest_Action_Kind identifies the field of the kind oneof that is set
+type CreateSceneRequest_Action_Kind string
+
+// Fields of the kind oneof
+const (
+	CreateSceneRequest_Action_Kind_None     CreateSceneRequest_Action_Kind = ""
+	CreateSceneRequest_Action_Kind_Func     CreateSceneRequest_Action_Kind = "func"
+	CreateSceneRequest_Action_Kind_Command  CreateSceneRequest_Action_Kind = "command"
+	CreateSceneRequest_Action_Kind_Property CreateSceneRequest_Action_Kind = "property"
+)
+
+// WhichKind returns the field of the kind oneof that is set.
+// If none of the fields are set, CreateSceneRequest_Action_Kind_None is returned.
+func (m *CreateSceneRequest_Action) WhichKind() CreateSceneRequest_Action_Kind {
+	if set := m.setKind(); len(set) > 0 {
+		return set[0]
+	}
+
+	return CreateSceneRequest_Action_Kind_None
+}
+
+// setKind returns all of the fields of the kind oneof that are set
+func (m *CreateSceneRequest_Action) setKind() []CreateSceneRequest_Action_Kind {
+	var set []CreateSceneRequest_Action_Kind
+	if m.Func != nil {
+		set = append(set, CreateSceneRequest_Action_Kind_Func)
+	}
+	if m.Command != nil {
+		set = append(set, CreateSceneRequest_Action_Kind_Command)
+	}
+	if m.Property != nil {
+		set = append(set, CreateSceneRequest_Action_Kind_Property)
+	}
+	return set
+}
+
+// validateOneofs returns an error if more than one field of a oneof
+// is set. If strict is true, it also returns an error if none of the
+// fields of a oneof are set.
+func (m *CreateSceneRequest_Action) validateOneofs(strict bool) error {
+	switch set := m.setKind(); {
+	case len(set) > 1:
+		return oops.BadRequest("only one of 'func', 'command' and 'property' can be set")
+	case strict && len(set) == 0:
+		return oops.BadRequest("one of 'func', 'command' and 'property' must be set")
+	}
+
+	return nil
+}
+
+// MarshalJSON returns an error if more than one field of a oneof is set
+func (m *CreateSceneRequest_Action) MarshalJSON() ([]byte, error) {
+	if err := m.validateOneofs(false); err != nil {
+		return nil, err
+	}
+
+	// The alias has the same fields but none of the
+	// methods so this does not recurse infinitely.
+	type alias CreateSceneRequest_Action
+	return json.Marshal((*alias)(m))
+}
+
+// UnmarshalJSON returns an error if more than one field of a oneof is set
+func (m *CreateSceneRequest_Action) UnmarshalJSON(b []byte) error {
+	type alias CreateSceneRequest_Action
+	if err := json.Unmarshal(b, (*alias)(m)); err != nil {
+		return err
+	}
+
+	return m.validateOneofs(false)
+}
+
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *CreateSceneRequest_Action) Validate() error {
+	if m.PropertyType != nil {
+		if err := m.PropertyType.Validate(); err != nil {
+			return oops.WithMessage(err, "invalid value in field 'property_type'", map[string]string{
+				"field": "property_type",
+			})
+		}
+	}
+
+	if err := m.validateOneofs(true); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -583,10 +850,13 @@ func (m *CreateSceneResponse) SetScene(v Scene) *CreateSceneResponse {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *CreateSceneResponse) Validate() error {
-	if err := m.Scene.Validate(); err != nil {
-		return err
+	if m.Scene != nil {
+		if err := m.Scene.Validate(); err != nil {
+			return prefixFieldPath(err, "scene")
+		}
 	}
 
 	return nil
@@ -613,7 +883,8 @@ func (m *ReadSceneRequest) SetSceneId(v uint32) *ReadSceneRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ReadSceneRequest) Validate() error {
 	return nil
 }
@@ -639,10 +910,13 @@ func (m *ReadSceneResponse) SetScene(v Scene) *ReadSceneResponse {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ReadSceneResponse) Validate() error {
-	if err := m.Scene.Validate(); err != nil {
-		return err
+	if m.Scene != nil {
+		if err := m.Scene.Validate(); err != nil {
+			return prefixFieldPath(err, "scene")
+		}
 	}
 
 	return nil
@@ -669,7 +943,8 @@ func (m *ListScenesRequest) SetOwnerId(v uint32) *ListScenesRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ListScenesRequest) Validate() error {
 	return nil
 }
@@ -695,13 +970,12 @@ func (m *ListScenesResponse) SetScenes(v []*Scene) *ListScenesResponse {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ListScenesResponse) Validate() error {
-	if m.Scenes != nil {
-		for _, r := range m.Scenes {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+	for i, r := range m.Scenes {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("scenes[%d]", i))
 		}
 	}
 
@@ -729,7 +1003,8 @@ func (m *DeleteSceneRequest) SetSceneId(v int32) *DeleteSceneRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *DeleteSceneRequest) Validate() error {
 	return nil
 }
@@ -738,7 +1013,8 @@ func (m *DeleteSceneRequest) Validate() error {
 type DeleteSceneResponse struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *DeleteSceneResponse) Validate() error {
 	return nil
 }
@@ -764,7 +1040,8 @@ func (m *SetSceneRequest) SetSceneId(v uint32) *SetSceneRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *SetSceneRequest) Validate() error {
 	return nil
 }
@@ -773,7 +1050,8 @@ func (m *SetSceneRequest) Validate() error {
 type SetSceneResponse struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *SetSceneResponse) Validate() error {
 	return nil
 }
@@ -799,7 +1077,8 @@ func (m *SetSceneEvent) SetSceneId(v uint32) *SetSceneEvent {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *SetSceneEvent) Validate() error {
 	return nil
 }
@@ -0,0 +1,101 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+// PropertyType is defined in the .def file
+export type PropertyType =
+  | "string"
+  | "boolean"
+  | "number"
+  | "null";
+
+// Scene is defined in the .def file
+export interface Scene {
+  id?: number;
+  name?: string;
+  owner_id?: number;
+  actions?: Action[];
+  created_at?: string;
+  updated_at?: string;
+}
+
+// Action is defined in the .def file
+export interface Action {
+  stage?: number;
+  sequence?: number;
+  func?: string;
+  command?: string;
+  property?: string;
+  controller_name?: string;
+  device_id?: string;
+  property_value?: string;
+  property_type?: PropertyType;
+  created_at?: string;
+  updated_at?: string;
+}
+
+// CreateSceneRequest is defined in the .def file
+export interface CreateSceneRequest {
+  name: string;
+  owner_id: number;
+  actions: CreateSceneRequest_Action[];
+}
+
+// CreateSceneRequest_Action is defined in the .def file
+export interface CreateSceneRequest_Action {
+  stage?: number;
+  sequence?: number;
+  func?: string;
+  command?: string;
+  property?: string;
+  controller_name?: string;
+  device_id?: string;
+  property_value?: string;
+  property_type?: PropertyType;
+}
+
+// CreateSceneResponse is defined in the .def file
+export interface CreateSceneResponse {
+  scene?: Scene;
+}
+
+// ReadSceneRequest is defined in the .def file
+export interface ReadSceneRequest {
+  scene_id?: number;
+}
+
+// ReadSceneResponse is defined in the .def file
+export interface ReadSceneResponse {
+  scene?: Scene;
+}
+
+// ListScenesRequest is defined in the .def file
+export interface ListScenesRequest {
+  owner_id?: number;
+}
+
+// ListScenesResponse is defined in the .def file
+export interface ListScenesResponse {
+  scenes?: Scene[];
+}
+
+// DeleteSceneRequest is defined in the .def file
+export interface DeleteSceneRequest {
+  scene_id?: number;
+}
+
+// DeleteSceneResponse is defined in the .def file
+export interface DeleteSceneResponse {
+}
+
+// SetSceneRequest is defined in the .def file
+export interface SetSceneRequest {
+  scene_id?: number;
+}
+
+// SetSceneResponse is defined in the .def file
+export interface SetSceneResponse {
+}
+
+// SetSceneEvent is defined in the .def file
+export interface SetSceneEvent {
+  scene_id?: number;
+}
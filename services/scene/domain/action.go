@@ -2,21 +2,25 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/jakewright/home-automation/libraries/go/device"
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
 	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
 	"github.com/jakewright/home-automation/libraries/go/util"
 	scenedef "github.com/jakewright/home-automation/services/scene/def"
 )
 
 const (
 	funcSleep           = "sleep"
-	propertyTypeString  = "string"
-	propertyTypeBoolean = "boolean"
-	propertyTypeNumber  = "number"
-	propertyTypeNull    = "null"
+	propertyTypeString  = string(scenedef.PropertyType_STRING)
+	propertyTypeBoolean = string(scenedef.PropertyType_BOOLEAN)
+	propertyTypeNumber  = string(scenedef.PropertyType_NUMBER)
+	propertyTypeNull    = string(scenedef.PropertyType_NULL)
 )
 
 // Action is a single step in a scene
@@ -76,13 +80,14 @@ func (a *Action) Validate() error {
 	return nil
 }
 
-// Perform does the action
-func (a *Action) Perform(ctx context.Context) error {
+// Perform does the action. The dispatcher is used
+// to make requests to device controllers.
+func (a *Action) Perform(ctx context.Context, dispatcher taxi.Dispatcher) error {
 	if err := a.Validate(); err != nil {
 		return err
 	}
 
-	var f func(context.Context) error
+	var f func(context.Context, taxi.Dispatcher) error
 
 	switch {
 	case a.Func != "":
@@ -95,10 +100,10 @@ func (a *Action) Perform(ctx context.Context) error {
 		return nil
 	}
 
-	return f(ctx)
+	return f(ctx, dispatcher)
 }
 
-func (a *Action) parseFunc() (func(context.Context) error, error) {
+func (a *Action) parseFunc() (func(context.Context, taxi.Dispatcher) error, error) {
 	parts := strings.Split(a.Func, " ")
 	if len(parts) == 0 {
 		return nil, oops.BadRequest("failed to extract func name from '%s'", a.Func)
@@ -115,7 +120,7 @@ func (a *Action) parseFunc() (func(context.Context) error, error) {
 			return nil, err
 		}
 
-		return func(_ context.Context) error {
+		return func(context.Context, taxi.Dispatcher) error {
 			time.Sleep(d)
 			return nil
 		}, nil
@@ -124,14 +129,46 @@ func (a *Action) parseFunc() (func(context.Context) error, error) {
 	return nil, oops.BadRequest("unknown func %s", parts[0])
 }
 
-func (a *Action) parseCommand() (func(context.Context) error, error) {
-	// todo
-	return func(_ context.Context) error {
-		return nil
+// parseCommand parses commands of the form "name arg=value arg=value".
+// Values are parsed as JSON if possible so that numbers and booleans
+// have the right type, otherwise they are used as strings.
+// e.g. "volume delta=-3" or "input input=GAME"
+func (a *Action) parseCommand() (func(context.Context, taxi.Dispatcher) error, error) {
+	parts := strings.Fields(a.Command)
+	if len(parts) == 0 {
+		return nil, oops.BadRequest("failed to extract command name from '%s'", a.Command)
+	}
+
+	args := make(map[string]interface{}, len(parts)-1)
+	for _, part := range parts[1:] {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, oops.BadRequest("command argument '%s' should be of the form name=value", part)
+		}
+
+		if _, ok := args[kv[0]]; ok {
+			return nil, oops.BadRequest("command argument '%s' is set more than once", kv[0])
+		}
+
+		var v interface{}
+		if err := json.Unmarshal([]byte(kv[1]), &v); err != nil {
+			v = kv[1]
+		}
+
+		args[kv[0]] = v
+	}
+
+	body := (&devicedef.InvokeCommandRequest{}).
+		SetDeviceId(a.DeviceID).
+		SetCommand(parts[0]).
+		SetArgs(args)
+
+	return func(ctx context.Context, dispatcher taxi.Dispatcher) error {
+		return device.InvokeCommand(ctx, dispatcher, a.ControllerName, body)
 	}, nil
 }
 
-func (a *Action) parseProperty() (func(context.Context) error, error) {
+func (a *Action) parseProperty() (func(context.Context, taxi.Dispatcher) error, error) {
 	//url := fmt.Sprintf("%s/device/%s", a.ControllerName, a.DeviceID)
 
 	//val, err := marshalPropertyValue(a.PropertyType, a.PropertyValue)
@@ -143,7 +180,7 @@ func (a *Action) parseProperty() (func(context.Context) error, error) {
 	//	a.Property: val,
 	//}
 
-	return func(ctx context.Context) error {
+	return func(context.Context, taxi.Dispatcher) error {
 		// Todo: make this work again
 		//_, err := rpc.Patch(ctx, url, body, nil)
 		//return err
@@ -171,15 +208,24 @@ func marshalPropertyValue(t, v string) (interface{}, error) {
 
 // ToProto marshals to the proto type
 func (a *Action) ToProto() *scenedef.Action {
-	return &scenedef.Action{
-		Stage:          int32(a.Stage),
-		Sequence:       int32(a.Sequence),
-		Func:           a.Func,
-		ControllerName: a.ControllerName,
-		Command:        a.Command,
-		Property:       a.Property,
-		PropertyValue:  a.PropertyValue,
-		CreatedAt:      a.CreatedAt,
-		UpdatedAt:      a.UpdatedAt,
+	p := (&scenedef.Action{}).
+		SetStage(int32(a.Stage)).
+		SetSequence(int32(a.Sequence)).
+		SetControllerName(a.ControllerName).
+		SetDeviceId(a.DeviceID).
+		SetPropertyValue(a.PropertyValue).
+		SetCreatedAt(a.CreatedAt).
+		SetUpdatedAt(a.UpdatedAt)
+
+	// Func, command and property are a oneof so only one can be set
+	switch {
+	case a.Func != "":
+		p.SetFunc(a.Func)
+	case a.Command != "":
+		p.SetCommand(a.Command)
+	case a.Property != "":
+		p.SetProperty(a.Property)
 	}
+
+	return p
 }
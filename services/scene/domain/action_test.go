@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jakewright/home-automation/libraries/go/device"
+	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
+	"github.com/jakewright/home-automation/libraries/go/oops"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
+)
+
+// commandRecorder returns a dispatcher that
+// records the command requests that it receives
+func commandRecorder() (taxi.Dispatcher, *[]*devicedef.InvokeCommandRequest) {
+	var requests []*devicedef.InvokeCommandRequest
+
+	r := taxi.NewRouter()
+	r.HandleFunc(device.CommandMethod, device.CommandPath, func(_ context.Context, decode taxi.Decoder) (interface{}, error) {
+		body := &devicedef.InvokeCommandRequest{}
+		if err := decode(body); err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, body)
+		return &devicedef.InvokeCommandResponse{}, nil
+	})
+
+	return &taxi.MockClient{Handler: r}, &requests
+}
+
+func TestAction_parseCommand(t *testing.T) {
+	tests := []struct {
+		command     string
+		wantCommand string
+		wantArgs    map[string]interface{}
+	}{
+		{"mute", "mute", nil},
+		{"volume delta=-3", "volume", map[string]interface{}{"delta": float64(-3)}},
+		{"input  input=GAME", "input", map[string]interface{}{"input": "GAME"}},
+		{`input input="GAME"`, "input", map[string]interface{}{"input": "GAME"}},
+		{"power on=true level=1.5", "power", map[string]interface{}{"on": true, "level": 1.5}},
+		{"label text=a=b", "label", map[string]interface{}{"text": "a=b"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.command, func(t *testing.T) {
+			a := &Action{
+				ControllerName: "service.infrared",
+				DeviceID:       "tv",
+				Command:        tc.command,
+			}
+
+			f, err := a.parseCommand()
+			require.NoError(t, err)
+
+			dispatcher, requests := commandRecorder()
+			require.NoError(t, f(context.Background(), dispatcher))
+			require.Len(t, *requests, 1)
+
+			req := (*requests)[0]
+			require.Equal(t, "tv", req.GetDeviceId())
+			require.Equal(t, tc.wantCommand, req.GetCommand())
+
+			args, _ := req.GetArgs()
+			require.Equal(t, tc.wantArgs, args)
+		})
+	}
+}
+
+func TestAction_parseCommand_errors(t *testing.T) {
+	tests := []struct {
+		command string
+		wantErr string
+	}{
+		{"", "failed to extract command name"},
+		{"volume delta", "should be of the form name=value"},
+		{"volume =3", "should be of the form name=value"},
+		{"volume delta=1 delta=2", "is set more than once"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.command, func(t *testing.T) {
+			a := &Action{Command: tc.command}
+
+			_, err := a.parseCommand()
+			require.True(t, oops.Is(err, oops.ErrBadRequest))
+			require.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
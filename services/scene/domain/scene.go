@@ -23,12 +23,11 @@ func (s *Scene) ToProto() *scenedef.Scene {
 		actions[i] = a.ToProto()
 	}
 
-	return &scenedef.Scene{
-		Id:        s.ID,
-		Name:      s.Name,
-		OwnerId:   s.OwnerID,
-		Actions:   actions,
-		CreatedAt: s.CreatedAt,
-		UpdatedAt: s.UpdatedAt,
-	}
+	return (&scenedef.Scene{}).
+		SetId(s.ID).
+		SetName(s.Name).
+		SetOwnerId(s.OwnerID).
+		SetActions(actions).
+		SetCreatedAt(s.CreatedAt).
+		SetUpdatedAt(s.UpdatedAt)
 }
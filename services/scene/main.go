@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/jakewright/home-automation/libraries/go/bootstrap"
 	"github.com/jakewright/home-automation/libraries/go/firehose"
+	"github.com/jakewright/home-automation/libraries/go/taxi"
 	"github.com/jakewright/home-automation/services/scene/consumer"
 	"github.com/jakewright/home-automation/services/scene/routes"
 )
@@ -14,7 +15,7 @@ func main() {
 		ServiceName: "service.scene",
 	})
 
-	firehose.Subscribe(consumer.HandleSetSceneEvent)
+	firehose.Subscribe(consumer.NewSetSceneEventHandler(taxi.NewClient()))
 
 	routes.Register(svc, &routes.Controller{
 		Database: svc.Database(),
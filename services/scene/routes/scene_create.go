@@ -38,7 +38,7 @@ func (c *Controller) CreateScene(ctx context.Context, body *scenedef.CreateScene
 		return nil, err
 	}
 
-	slog.Infof("Created new scene %d", scene.ID)
+	slog.FromContext(ctx).Infof("Created new scene %d", scene.ID)
 
 	return &scenedef.CreateSceneResponse{
 		Scene: scene.ToProto(),
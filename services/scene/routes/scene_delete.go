@@ -20,6 +20,6 @@ func (c *Controller) DeleteScene(ctx context.Context, body *scenedef.DeleteScene
 		return nil, err
 	}
 
-	slog.Infof("Deleted scene %d", body.SceneId)
+	slog.FromContext(ctx).Infof("Deleted scene %d", body.SceneId)
 	return &scenedef.DeleteSceneResponse{}, nil
 }
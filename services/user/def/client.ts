@@ -0,0 +1,31 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+import { Fetch, RPCClient } from "../../../libraries/typescript/rpc";
+import {
+  GetUserRequest,
+  GetUserResponse,
+  ListUsersRequest,
+  ListUsersResponse,
+} from "./types";
+
+export { RPCError } from "../../../libraries/typescript/rpc";
+
+// UserClient makes requests to the User service
+export class UserClient extends RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(baseURL: string, fetch?: Fetch) {
+    super(baseURL, fetch);
+  }
+
+  // getUser makes a GET request to /user
+  getUser(body: GetUserRequest): Promise<GetUserResponse> {
+    return this.do("GET", "/user", body, true);
+  }
+
+  // listUsers makes a GET request to /users
+  listUsers(body: ListUsersRequest): Promise<ListUsersResponse> {
+    return this.do("GET", "/users", body, true);
+  }
+}
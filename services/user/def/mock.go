@@ -0,0 +1,204 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+package userdef
+
+import (
+	context "context"
+	sync "sync"
+	testing "testing"
+
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
+)
+
+// MockService is an in-memory implementation of UserService
+// that can be used in tests. Responses are programmed, and requests
+// inspected, via the mock of each RPC, e.g. m.OnGetUser().
+type MockService struct {
+	getUser   *GetUserMock
+	listUsers *ListUsersMock
+}
+
+// Compile-time assertion that the mock implements the interface
+var _ UserService = (*MockService)(nil)
+
+// NewMockService returns a new mock with no responses programmed
+func NewMockService() *MockService {
+	return &MockService{
+		getUser:   &GetUserMock{},
+		listUsers: &ListUsersMock{},
+	}
+}
+
+// OnGetUser returns the mock of the GetUser RPC
+func (m *MockService) OnGetUser() *GetUserMock {
+	return m.getUser
+}
+
+// GetUser records the request and returns the programmed response
+func (m *MockService) GetUser(ctx context.Context, body *GetUserRequest) *GetUserFuture {
+	handler := m.getUser.record(body)
+
+	done := make(chan struct{})
+	ftr := &GetUserFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// GetUserMock programs and records calls to the GetUser RPC
+type GetUserMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	requests []*GetUserRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *GetUserMock) Returns(rsp *GetUserResponse, err error) *GetUserMock {
+	return m.Handle(func(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *GetUserMock) Handle(fn func(ctx context.Context, body *GetUserRequest) (*GetUserResponse, error)) *GetUserMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *GetUserMock) record(body *GetUserRequest) func(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+			return nil, oops.InternalService("no response programmed for GetUser")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *GetUserMock) Requests() []*GetUserRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*GetUserRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *GetUserMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected GetUser to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *GetUserMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected GetUser not to be called but it was called %d times", n)
+	}
+}
+
+// OnListUsers returns the mock of the ListUsers RPC
+func (m *MockService) OnListUsers() *ListUsersMock {
+	return m.listUsers
+}
+
+// ListUsers records the request and returns the programmed response
+func (m *MockService) ListUsers(ctx context.Context, body *ListUsersRequest) *ListUsersFuture {
+	handler := m.listUsers.record(body)
+
+	done := make(chan struct{})
+	ftr := &ListUsersFuture{done: done}
+
+	if err := body.Validate(); err != nil {
+		ftr.err = err
+	} else {
+		ftr.rsp, ftr.err = handler(ctx, body)
+	}
+
+	close(done)
+	return ftr
+}
+
+// ListUsersMock programs and records calls to the ListUsers RPC
+type ListUsersMock struct {
+	mu       sync.Mutex
+	handler  func(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	requests []*ListUsersRequest
+}
+
+// Returns programs the mock to return the response and error
+func (m *ListUsersMock) Returns(rsp *ListUsersResponse, err error) *ListUsersMock {
+	return m.Handle(func(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+		return rsp, err
+	})
+}
+
+// Handle programs the mock to call fn for each request
+func (m *ListUsersMock) Handle(fn func(ctx context.Context, body *ListUsersRequest) (*ListUsersResponse, error)) *ListUsersMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+func (m *ListUsersMock) record(body *ListUsersRequest) func(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, body)
+
+	if m.handler == nil {
+		return func(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+			return nil, oops.InternalService("no response programmed for ListUsers")
+		}
+	}
+
+	return m.handler
+}
+
+// Requests returns the requests that the RPC has received, in order
+func (m *ListUsersMock) Requests() []*ListUsersRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make([]*ListUsersRequest, len(m.requests))
+	copy(requests, m.requests)
+	return requests
+}
+
+// AssertCalled fails the test if the RPC has not been called
+func (m *ListUsersMock) AssertCalled(t *testing.T) {
+	t.Helper()
+
+	if len(m.Requests()) == 0 {
+		t.Errorf("expected ListUsers to be called but it was not")
+	}
+}
+
+// AssertNotCalled fails the test if the RPC has been called
+func (m *ListUsersMock) AssertNotCalled(t *testing.T) {
+	t.Helper()
+
+	if n := len(m.Requests()); n > 0 {
+		t.Errorf("expected ListUsers not to be called but it was called %d times", n)
+	}
+}
@@ -3,9 +3,35 @@
 package userdef
 
 import (
+	fmt "fmt"
 	time "time"
+
+	oops "github.com/jakewright/home-automation/libraries/go/oops"
 )
 
+// prefixFieldPath returns a copy of an error returned by the Validate
+// function of a nested message with the path of the nested field
+// prepended to the field path in the error's metadata
+func prefixFieldPath(err error, path string) error {
+	e, ok := err.(*oops.Error)
+	if !ok {
+		return err
+	}
+
+	metadata := map[string]string{}
+	for k, v := range e.GetMetadata() {
+		metadata[k] = v
+	}
+
+	if field, ok := metadata["field"]; ok {
+		path += "." + field
+	}
+	metadata["field"] = path
+
+	// Validate functions only return bad request errors
+	return oops.BadRequest("%s", e.GetMessage(), metadata)
+}
+
 // User is defined in the .def file
 type User struct {
 	Id        *uint32    `json:"id,omitempty"`
@@ -78,7 +104,8 @@ func (m *User) SetUpdatedAt(v time.Time) *User {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *User) Validate() error {
 	return nil
 }
@@ -104,7 +131,8 @@ func (m *GetUserRequest) SetUserId(v uint32) *GetUserRequest {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *GetUserRequest) Validate() error {
 	return nil
 }
@@ -130,10 +158,13 @@ func (m *GetUserResponse) SetUser(v User) *GetUserResponse {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *GetUserResponse) Validate() error {
-	if err := m.User.Validate(); err != nil {
-		return err
+	if m.User != nil {
+		if err := m.User.Validate(); err != nil {
+			return prefixFieldPath(err, "user")
+		}
 	}
 
 	return nil
@@ -143,7 +174,8 @@ func (m *GetUserResponse) Validate() error {
 type ListUsersRequest struct {
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ListUsersRequest) Validate() error {
 	return nil
 }
@@ -169,13 +201,12 @@ func (m *ListUsersResponse) SetUsers(v []*User) *ListUsersResponse {
 	return m
 }
 
-// Validate returns an error if any of the fields have bad values
+// Validate returns an error if any of the fields have bad values.
+// The path of the field is set as "field" in the error's metadata.
 func (m *ListUsersResponse) Validate() error {
-	if m.Users != nil {
-		for _, r := range m.Users {
-			if err := r.Validate(); err != nil {
-				return err
-			}
+	for i, r := range m.Users {
+		if err := r.Validate(); err != nil {
+			return prefixFieldPath(err, fmt.Sprintf("users[%d]", i))
 		}
 	}
 
@@ -0,0 +1,28 @@
+// Code generated by jrpc. DO NOT EDIT.
+
+// User is defined in the .def file
+export interface User {
+  id?: number;
+  name?: string;
+  created_at?: string;
+  updated_at?: string;
+}
+
+// GetUserRequest is defined in the .def file
+export interface GetUserRequest {
+  user_id?: number;
+}
+
+// GetUserResponse is defined in the .def file
+export interface GetUserResponse {
+  user?: User;
+}
+
+// ListUsersRequest is defined in the .def file
+export interface ListUsersRequest {
+}
+
+// ListUsersResponse is defined in the .def file
+export interface ListUsersResponse {
+  users?: User[];
+}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jakewright/home-automation/libraries/go/svcdef"
+)
+
+// change is a single difference between two versions of a def file
+type change struct {
+	// Breaking is set if existing callers or
+	// handlers could stop working after the change
+	Breaking bool
+
+	// Subject is the thing that changed e.g. "rpc GetScene"
+	Subject string
+
+	// Description says what happened to the subject
+	Description string
+}
+
+func (c *change) String() string {
+	return fmt.Sprintf("%s: %s", c.Subject, c.Description)
+}
+
+// report collects the changes found by compare
+type report struct {
+	changes []*change
+}
+
+func (r *report) breaking(subject, format string, a ...interface{}) {
+	r.changes = append(r.changes, &change{
+		Breaking:    true,
+		Subject:     subject,
+		Description: fmt.Sprintf(format, a...),
+	})
+}
+
+func (r *report) compatible(subject, format string, a ...interface{}) {
+	r.changes = append(r.changes, &change{
+		Subject:     subject,
+		Description: fmt.Sprintf(format, a...),
+	})
+}
+
+// compare returns the differences between the old and new versions of a
+// def file. Messages and enums defined in imported files are not compared
+// because they are checked when the imported file itself is compared.
+func compare(old, new *svcdef.File) []*change {
+	r := &report{}
+
+	compareServices(r, old.Service, new.Service)
+	compareMessages(r, localMessages(old), localMessages(new))
+	compareEnums(r, localEnums(old), localEnums(new))
+
+	return r.changes
+}
+
+func compareServices(r *report, old, new *svcdef.Service) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		r.compatible("service "+new.Name, "added")
+		return
+	case new == nil:
+		r.breaking("service "+old.Name, "removed")
+		return
+	}
+
+	subject := "service " + new.Name
+
+	if old.Name != new.Name {
+		r.breaking(subject, "renamed from %s", old.Name)
+	}
+
+	if o, n := old.Options["path"], new.Options["path"]; o != n {
+		r.breaking(subject, "path changed from %v to %v", o, n)
+	}
+
+	oldRPCs := make(map[string]*svcdef.RPC, len(old.RPCs))
+	for _, rpc := range old.RPCs {
+		oldRPCs[rpc.Name] = rpc
+	}
+
+	newRPCs := make(map[string]*svcdef.RPC, len(new.RPCs))
+	for _, rpc := range new.RPCs {
+		newRPCs[rpc.Name] = rpc
+	}
+
+	for _, o := range old.RPCs {
+		if _, ok := newRPCs[o.Name]; !ok {
+			r.breaking("rpc "+o.Name, "removed")
+		}
+	}
+
+	for _, n := range new.RPCs {
+		o, ok := oldRPCs[n.Name]
+		if !ok {
+			r.compatible("rpc "+n.Name, "added")
+			continue
+		}
+
+		compareRPCs(r, o, n)
+	}
+}
+
+func compareRPCs(r *report, old, new *svcdef.RPC) {
+	subject := "rpc " + new.Name
+
+	for _, opt := range []string{"method", "path"} {
+		if o, n := old.Options[opt], new.Options[opt]; o != n {
+			r.breaking(subject, "%s changed from %v to %v", opt, o, n)
+		}
+	}
+
+	if o, n := typeString(old.InputType), typeString(new.InputType); o != n {
+		r.breaking(subject, "input type changed from %s to %s", o, n)
+	}
+
+	if o, n := typeString(old.OutputType), typeString(new.OutputType); o != n {
+		r.breaking(subject, "output type changed from %s to %s", o, n)
+	}
+
+	switch {
+	case !old.Stream && new.Stream:
+		r.breaking(subject, "changed to a stream")
+	case old.Stream && !new.Stream:
+		r.breaking(subject, "no longer a stream")
+	}
+
+	if o, n := isIdempotent(old.Options), isIdempotent(new.Options); o != n {
+		r.compatible(subject, "idempotent changed from %t to %t", o, n)
+	}
+}
+
+func compareMessages(r *report, old, new []*svcdef.Message) {
+	newByName := make(map[string]*svcdef.Message, len(new))
+	for _, m := range new {
+		newByName[m.QualifiedName] = m
+	}
+
+	oldByName := make(map[string]*svcdef.Message, len(old))
+	for _, m := range old {
+		oldByName[m.QualifiedName] = m
+
+		if _, ok := newByName[m.QualifiedName]; !ok {
+			r.breaking("message "+m.QualifiedName, "removed")
+		}
+	}
+
+	for _, n := range new {
+		o, ok := oldByName[n.QualifiedName]
+		if !ok {
+			r.compatible("message "+n.QualifiedName, "added")
+			continue
+		}
+
+		compareFields(r, o, n)
+	}
+}
+
+func compareFields(r *report, old, new *svcdef.Message) {
+	oldFields := make(map[string]*svcdef.Field, len(old.Fields))
+	for _, f := range old.Fields {
+		oldFields[f.Name] = f
+	}
+
+	newFields := make(map[string]*svcdef.Field, len(new.Fields))
+	for _, f := range new.Fields {
+		newFields[f.Name] = f
+	}
+
+	for _, o := range old.Fields {
+		if _, ok := newFields[o.Name]; !ok {
+			r.breaking(fieldSubject(old, o), "removed")
+		}
+	}
+
+	for _, n := range new.Fields {
+		subject := fieldSubject(new, n)
+
+		o, ok := oldFields[n.Name]
+		if !ok {
+			if isRequired(n.Options) {
+				r.breaking(subject, "added as a required field")
+			} else {
+				r.compatible(subject, "added")
+			}
+			continue
+		}
+
+		if ot, nt := typeString(o.Type), typeString(n.Type); ot != nt {
+			r.breaking(subject, "type changed from %s to %s", ot, nt)
+		}
+
+		switch or, nr := isRequired(o.Options), isRequired(n.Options); {
+		case !or && nr:
+			r.breaking(subject, "now required")
+		case or && !nr:
+			r.compatible(subject, "no longer required")
+		}
+
+		switch od, nd := o.Options["default"], n.Options["default"]; {
+		case od == nd:
+		case od == nil:
+			r.compatible(subject, "default %v added", nd)
+		case nd == nil:
+			r.breaking(subject, "default %v removed", od)
+		default:
+			r.breaking(subject, "default changed from %v to %v", od, nd)
+		}
+
+		if od, nd := isDeprecated(o.Options), isDeprecated(n.Options); !od && nd {
+			r.compatible(subject, "deprecated")
+		}
+
+		compareValidation(r, subject, o.Options, n.Options)
+
+		switch {
+		case o.Oneof == n.Oneof:
+		case n.Oneof == "":
+			r.compatible(subject, "moved out of oneof %s", o.Oneof)
+		default:
+			r.breaking(subject, "moved into oneof %s", n.Oneof)
+		}
+	}
+}
+
+// compareValidation reports changes to the options that restrict
+// the values a field accepts. Tightening them is breaking because
+// values that used to be valid could be rejected.
+func compareValidation(r *report, subject string, old, new map[string]interface{}) {
+	for _, opt := range []string{"min", "min_len"} {
+		compareBound(r, subject, opt, old[opt], new[opt], true)
+	}
+
+	for _, opt := range []string{"max", "max_len"} {
+		compareBound(r, subject, opt, old[opt], new[opt], false)
+	}
+
+	for _, opt := range []string{"non_empty", "unique"} {
+		o, _ := old[opt].(bool)
+		n, _ := new[opt].(bool)
+		switch {
+		case !o && n:
+			r.breaking(subject, "%s added", opt)
+		case o && !n:
+			r.compatible(subject, "%s removed", opt)
+		}
+	}
+
+	switch op, np := old["pattern"], new["pattern"]; {
+	case op == np:
+	case op == nil:
+		r.breaking(subject, "pattern %q added", np)
+	case np == nil:
+		r.compatible(subject, "pattern %q removed", op)
+	default:
+		r.breaking(subject, "pattern changed from %q to %q", op, np)
+	}
+}
+
+// compareBound reports a change to a lower or upper bound.
+// Raising a lower bound or lowering an upper bound is breaking.
+func compareBound(r *report, subject, opt string, old, new interface{}, lower bool) {
+	o, hasOld := toFloat(old)
+	n, hasNew := toFloat(new)
+
+	switch {
+	case !hasOld && !hasNew:
+	case !hasOld:
+		r.breaking(subject, "%s %v added", opt, new)
+	case !hasNew:
+		r.compatible(subject, "%s %v removed", opt, old)
+	case o == n:
+	case (n > o) == lower:
+		r.breaking(subject, "%s tightened from %v to %v", opt, old, new)
+	default:
+		r.compatible(subject, "%s loosened from %v to %v", opt, old, new)
+	}
+}
+
+func compareEnums(r *report, old, new []*svcdef.Enum) {
+	newByName := make(map[string]*svcdef.Enum, len(new))
+	for _, e := range new {
+		newByName[e.QualifiedName] = e
+	}
+
+	oldByName := make(map[string]*svcdef.Enum, len(old))
+	for _, e := range old {
+		oldByName[e.QualifiedName] = e
+
+		if _, ok := newByName[e.QualifiedName]; !ok {
+			r.breaking("enum "+e.QualifiedName, "removed")
+		}
+	}
+
+	for _, n := range new {
+		subject := "enum " + n.QualifiedName
+
+		o, ok := oldByName[n.QualifiedName]
+		if !ok {
+			r.compatible(subject, "added")
+			continue
+		}
+
+		oldValues := make(map[string]*svcdef.EnumValue, len(o.Values))
+		for _, v := range o.Values {
+			oldValues[v.Name] = v
+		}
+
+		newValues := make(map[string]*svcdef.EnumValue, len(n.Values))
+		for _, v := range n.Values {
+			newValues[v.Name] = v
+		}
+
+		for _, ov := range o.Values {
+			if _, ok := newValues[ov.Name]; !ok {
+				r.breaking(subject, "value %s removed", ov.Name)
+			}
+		}
+
+		for _, nv := range n.Values {
+			ov, ok := oldValues[nv.Name]
+			switch {
+			case !ok:
+				r.compatible(subject, "value %s added", nv.Name)
+			case ov.Value != nv.Value:
+				r.breaking(subject, "value %s changed from %q to %q", nv.Name, ov.Value, nv.Value)
+			}
+		}
+	}
+}
+
+// localMessages returns all of the messages defined
+// in the file, including nested messages but
+// excluding those that have been imported.
+func localMessages(f *svcdef.File) []*svcdef.Message {
+	var messages []*svcdef.Message
+	for _, m := range f.FlatMessages {
+		if strings.HasPrefix(m.QualifiedName, ".") {
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+// localEnums returns all of the enums defined
+// in the file, excluding those that have been imported
+func localEnums(f *svcdef.File) []*svcdef.Enum {
+	var enums []*svcdef.Enum
+	for _, e := range f.FlatEnums {
+		if strings.HasPrefix(e.QualifiedName, ".") {
+			enums = append(enums, e)
+		}
+	}
+	return enums
+}
+
+func fieldSubject(m *svcdef.Message, f *svcdef.Field) string {
+	return fmt.Sprintf("message %s: field %s", m.QualifiedName, f.Name)
+}
+
+// typeString returns a representation of the type that
+// is equal for two types if and only if they are the same.
+func typeString(t *svcdef.Type) string {
+	var s string
+
+	switch {
+	case t.Map:
+		s = fmt.Sprintf("map[%s]%s", typeString(t.MapKey), typeString(t.MapValue))
+	case t.Qualified != "":
+		s = t.Qualified
+	default:
+		s = t.Name
+	}
+
+	if t.Optional {
+		s = "*" + s
+	}
+
+	if t.Repeated {
+		s = "[]" + s
+	}
+
+	return s
+}
+
+func isRequired(opts map[string]interface{}) bool {
+	required, _ := opts["required"].(bool)
+	return required
+}
+
+func isDeprecated(opts map[string]interface{}) bool {
+	deprecated, _ := opts["deprecated"].(bool)
+	return deprecated
+}
+
+// toFloat converts a numeric option value to a float64
+func toFloat(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case int64:
+		return float64(t), true
+	case float64:
+		return t, true
+	}
+	return 0, false
+}
+
+func isIdempotent(opts map[string]interface{}) bool {
+	idempotent, _ := opts["idempotent"].(bool)
+	return idempotent
+}
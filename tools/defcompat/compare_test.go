@@ -0,0 +1,399 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/jakewright/home-automation/libraries/go/svcdef"
+)
+
+// memFileReader implements svcdef.FileReader
+// with files that are held in memory
+type memFileReader struct {
+	files map[string]string
+	seen  map[string]bool
+}
+
+func (r *memFileReader) ReadFile(filename string) ([]byte, error) {
+	r.seen[filename] = true
+
+	if s, ok := r.files[filename]; ok {
+		return []byte(s), nil
+	}
+
+	return nil, os.ErrNotExist
+}
+
+func (r *memFileReader) SeenFile(filename string) bool {
+	return r.seen[filename]
+}
+
+func mustParse(t *testing.T, def string) *svcdef.File {
+	fr := &memFileReader{
+		files: map[string]string{"test.def": def},
+		seen:  map[string]bool{},
+	}
+
+	f, err := svcdef.NewParser(fr).Parse("test.def")
+	assert.NilError(t, err)
+	return f
+}
+
+// changeStrings formats the changes so that they are easy to compare
+func changeStrings(changes []*change) []string {
+	var s []string
+	for _, c := range changes {
+		kind := "compatible"
+		if c.Breaking {
+			kind = "breaking"
+		}
+		s = append(s, kind+" "+c.String())
+	}
+	return s
+}
+
+// fooMessages are the messages used by the RPCs in service tests
+const fooMessages = `
+message GetFooRequest {
+	string id (required)
+}
+
+message GetFooResponse {
+	string bar
+}
+`
+
+const getFoo = `
+	rpc GetFoo(GetFooRequest) GetFooResponse {
+		method = "GET"
+		path = "/foo"
+	}
+`
+
+func service(path, rpcs string) string {
+	return "service Test {\n\tpath = \"" + path + "\"\n" + rpcs + "}\n" + fooMessages
+}
+
+func TestCompare_services(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []string
+	}{
+		{
+			name: "No changes",
+			old:  service("test", getFoo),
+			new:  service("test", getFoo),
+		},
+		{
+			name: "Service added",
+			old:  fooMessages,
+			new:  service("test", getFoo),
+			want: []string{"compatible service Test: added"},
+		},
+		{
+			name: "Service removed",
+			old:  service("test", getFoo),
+			new:  fooMessages,
+			want: []string{"breaking service Test: removed"},
+		},
+		{
+			name: "Path changed",
+			old:  service("test", getFoo),
+			new:  service("test2", getFoo),
+			want: []string{"breaking service Test: path changed from test to test2"},
+		},
+		{
+			name: "RPC added",
+			old:  service("test", getFoo),
+			new: service("test", getFoo+`
+	rpc ListFoos(GetFooRequest) GetFooResponse {
+		method = "GET"
+		path = "/foos"
+	}
+`),
+			want: []string{"compatible rpc ListFoos: added"},
+		},
+		{
+			name: "RPC removed",
+			old:  service("test", getFoo),
+			new:  service("test", ""),
+			want: []string{"breaking rpc GetFoo: removed"},
+		},
+		{
+			name: "RPC types changed",
+			old:  service("test", getFoo),
+			new: service("test", `
+	rpc GetFoo(GetFooResponse) GetFooRequest {
+		method = "GET"
+		path = "/foo"
+	}
+`),
+			want: []string{
+				"breaking rpc GetFoo: input type changed from .GetFooRequest to .GetFooResponse",
+				"breaking rpc GetFoo: output type changed from .GetFooResponse to .GetFooRequest",
+			},
+		},
+		{
+			name: "RPC options changed",
+			old:  service("test", getFoo),
+			new: service("test", `
+	rpc GetFoo(GetFooRequest) GetFooResponse {
+		method = "POST"
+		path = "/foo"
+		idempotent = true
+	}
+`),
+			want: []string{
+				"breaking rpc GetFoo: method changed from GET to POST",
+				"compatible rpc GetFoo: idempotent changed from false to true",
+			},
+		},
+		{
+			name: "RPC changed to a stream",
+			old:  service("test", getFoo),
+			new: service("test", `
+	rpc GetFoo(GetFooRequest) stream GetFooResponse {
+		method = "GET"
+		path = "/foo"
+	}
+`),
+			want: []string{"breaking rpc GetFoo: changed to a stream"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := compare(mustParse(t, tc.old), mustParse(t, tc.new))
+			assert.DeepEqual(t, tc.want, changeStrings(got))
+		})
+	}
+}
+
+func TestCompare_messages(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []string
+	}{
+		{
+			name: "Message added",
+			old:  `message Foo { string bar }`,
+			new:  `message Foo { string bar } message Baz { string qux }`,
+			want: []string{"compatible message .Baz: added"},
+		},
+		{
+			name: "Message removed",
+			old:  `message Foo { string bar } message Baz { string qux }`,
+			new:  `message Foo { string bar }`,
+			want: []string{"breaking message .Baz: removed"},
+		},
+		{
+			name: "Nested message removed",
+			old:  `message Foo { message Bar { string baz } Bar bar }`,
+			new:  `message Foo { string bar }`,
+			want: []string{
+				"breaking message .Foo.Bar: removed",
+				"breaking message .Foo: field bar: type changed from .Foo.Bar to string",
+			},
+		},
+		{
+			name: "Field added",
+			old:  `message Foo { string bar }`,
+			new:  `message Foo { string bar int32 baz }`,
+			want: []string{"compatible message .Foo: field baz: added"},
+		},
+		{
+			name: "Required field added",
+			old:  `message Foo { string bar }`,
+			new:  `message Foo { string bar int32 baz (required) }`,
+			want: []string{"breaking message .Foo: field baz: added as a required field"},
+		},
+		{
+			name: "Field removed",
+			old:  `message Foo { string bar int32 baz }`,
+			new:  `message Foo { string bar }`,
+			want: []string{"breaking message .Foo: field baz: removed"},
+		},
+		{
+			name: "Field type changed",
+			old:  `message Foo { int32 bar }`,
+			new:  `message Foo { int64 bar }`,
+			want: []string{"breaking message .Foo: field bar: type changed from int32 to int64"},
+		},
+		{
+			name: "Field made repeated",
+			old:  `message Foo { string bar }`,
+			new:  `message Foo { []string bar }`,
+			want: []string{"breaking message .Foo: field bar: type changed from string to []string"},
+		},
+		{
+			name: "Field made required",
+			old:  `message Foo { string bar }`,
+			new:  `message Foo { string bar (required) }`,
+			want: []string{"breaking message .Foo: field bar: now required"},
+		},
+		{
+			name: "Field no longer required",
+			old:  `message Foo { string bar (required) }`,
+			new:  `message Foo { string bar }`,
+			want: []string{"compatible message .Foo: field bar: no longer required"},
+		},
+		{
+			name: "Default added",
+			old:  `message Foo { int32 bar }`,
+			new:  `message Foo { int32 bar (default = 3) }`,
+			want: []string{"compatible message .Foo: field bar: default 3 added"},
+		},
+		{
+			name: "Default removed",
+			old:  `message Foo { int32 bar (default = 3) }`,
+			new:  `message Foo { int32 bar }`,
+			want: []string{"breaking message .Foo: field bar: default 3 removed"},
+		},
+		{
+			name: "Default changed",
+			old:  `message Foo { int32 bar (default = 3) }`,
+			new:  `message Foo { int32 bar (default = 4) }`,
+			want: []string{"breaking message .Foo: field bar: default changed from 3 to 4"},
+		},
+		{
+			name: "Field deprecated",
+			old:  `message Foo { string bar }`,
+			new:  `message Foo { string bar (deprecated) }`,
+			want: []string{"compatible message .Foo: field bar: deprecated"},
+		},
+		{
+			name: "Bounds added",
+			old:  `message Foo { int32 bar string baz }`,
+			new:  `message Foo { int32 bar (min = 1, max = 10) string baz (min_len = 1, max_len = 5) }`,
+			want: []string{
+				"breaking message .Foo: field bar: min 1 added",
+				"breaking message .Foo: field bar: max 10 added",
+				"breaking message .Foo: field baz: min_len 1 added",
+				"breaking message .Foo: field baz: max_len 5 added",
+			},
+		},
+		{
+			name: "Bounds tightened",
+			old:  `message Foo { int32 bar (min = 1, max = 10) string baz (min_len = 1, max_len = 5) }`,
+			new:  `message Foo { int32 bar (min = 2, max = 9.5) string baz (min_len = 2, max_len = 4) }`,
+			want: []string{
+				"breaking message .Foo: field bar: min tightened from 1 to 2",
+				"breaking message .Foo: field bar: max tightened from 10 to 9.5",
+				"breaking message .Foo: field baz: min_len tightened from 1 to 2",
+				"breaking message .Foo: field baz: max_len tightened from 5 to 4",
+			},
+		},
+		{
+			name: "Bounds loosened",
+			old:  `message Foo { int32 bar (min = 1, max = 10) string baz (min_len = 1, max_len = 5) }`,
+			new:  `message Foo { int32 bar (min = 0) string baz (min_len = 0, max_len = 6) }`,
+			want: []string{
+				"compatible message .Foo: field bar: min loosened from 1 to 0",
+				"compatible message .Foo: field bar: max 10 removed",
+				"compatible message .Foo: field baz: min_len loosened from 1 to 0",
+				"compatible message .Foo: field baz: max_len loosened from 5 to 6",
+			},
+		},
+		{
+			name: "Pattern and flags added",
+			old:  `message Foo { string bar []string baz map[string]string qux }`,
+			new:  `message Foo { string bar (pattern = "^a") []string baz (unique) map[string]string qux (non_empty) }`,
+			want: []string{
+				`breaking message .Foo: field bar: pattern "^a" added`,
+				"breaking message .Foo: field baz: unique added",
+				"breaking message .Foo: field qux: non_empty added",
+			},
+		},
+		{
+			name: "Pattern changed",
+			old:  `message Foo { string bar (pattern = "^a") }`,
+			new:  `message Foo { string bar (pattern = "^b") }`,
+			want: []string{`breaking message .Foo: field bar: pattern changed from "^a" to "^b"`},
+		},
+		{
+			name: "Pattern and flags removed",
+			old:  `message Foo { string bar (pattern = "^a") []string baz (unique) map[string]string qux (non_empty) }`,
+			new:  `message Foo { string bar []string baz map[string]string qux }`,
+			want: []string{
+				`compatible message .Foo: field bar: pattern "^a" removed`,
+				"compatible message .Foo: field baz: unique removed",
+				"compatible message .Foo: field qux: non_empty removed",
+			},
+		},
+		{
+			name: "Field moved into oneof",
+			old:  `message Foo { string bar string baz }`,
+			new:  `message Foo { oneof kind { string bar string baz } }`,
+			want: []string{
+				"breaking message .Foo: field bar: moved into oneof kind",
+				"breaking message .Foo: field baz: moved into oneof kind",
+			},
+		},
+		{
+			name: "Field moved out of oneof",
+			old:  `message Foo { oneof kind { string bar string baz } }`,
+			new:  `message Foo { string bar string baz }`,
+			want: []string{
+				"compatible message .Foo: field bar: moved out of oneof kind",
+				"compatible message .Foo: field baz: moved out of oneof kind",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := compare(mustParse(t, tc.old), mustParse(t, tc.new))
+			assert.DeepEqual(t, tc.want, changeStrings(got))
+		})
+	}
+}
+
+func TestCompare_enums(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []string
+	}{
+		{
+			name: "Enum added",
+			old:  `enum Foo { BAR }`,
+			new:  `enum Foo { BAR } enum Baz { QUX }`,
+			want: []string{"compatible enum .Baz: added"},
+		},
+		{
+			name: "Enum removed",
+			old:  `enum Foo { BAR } enum Baz { QUX }`,
+			new:  `enum Foo { BAR }`,
+			want: []string{"breaking enum .Baz: removed"},
+		},
+		{
+			name: "Value added",
+			old:  `enum Foo { BAR }`,
+			new:  `enum Foo { BAR BAZ }`,
+			want: []string{"compatible enum .Foo: value BAZ added"},
+		},
+		{
+			name: "Value removed",
+			old:  `enum Foo { BAR BAZ }`,
+			new:  `enum Foo { BAR }`,
+			want: []string{"breaking enum .Foo: value BAZ removed"},
+		},
+		{
+			name: "Value changed",
+			old:  `enum Foo { BAR = "bar" }`,
+			new:  `enum Foo { BAR = "baz" }`,
+			want: []string{`breaking enum .Foo: value BAR changed from "bar" to "baz"`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := compare(mustParse(t, tc.old), mustParse(t, tc.new))
+			assert.DeepEqual(t, tc.want, changeStrings(got))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jakewright/home-automation/libraries/go/svcdef"
+	"github.com/jakewright/home-automation/tools/deploy/pkg/git"
+)
+
+const usage = `usage:
+  defcompat [-revision rev] file.def   compare file.def with the version at the git revision
+  defcompat old.def new.def            compare two def files`
+
+func main() {
+	revision := flag.String("revision", "HEAD", "git revision to compare the working tree against")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var old, new *svcdef.File
+	var err error
+
+	switch flag.NArg() {
+	case 1:
+		old, new, err = parseRevision(*revision, flag.Arg(0))
+	case 2:
+		old, new, err = parseFiles(flag.Arg(0), flag.Arg(1))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var breaking bool
+	for _, c := range compare(old, new) {
+		if c.Breaking {
+			breaking = true
+			fmt.Printf("BREAKING    %s\n", c)
+		} else {
+			fmt.Printf("compatible  %s\n", c)
+		}
+	}
+
+	if breaking {
+		os.Exit(1)
+	}
+}
+
+// parseRevision parses the def file from the working tree
+// and the same file as it was at the given revision
+func parseRevision(revision, filename string) (*svcdef.File, *svcdef.File, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	fr := &gitFileReader{
+		repo:     &git.Repository{Dir: cwd},
+		revision: revision,
+	}
+
+	old, err := svcdef.NewParser(fr).Parse(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s at %s: %w", filename, revision, err)
+	}
+
+	new, err := svcdef.Parse(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return old, new, nil
+}
+
+// parseFiles parses two def files from the working tree
+func parseFiles(oldFilename, newFilename string) (*svcdef.File, *svcdef.File, error) {
+	old, err := svcdef.Parse(oldFilename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", oldFilename, err)
+	}
+
+	new, err := svcdef.Parse(newFilename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", newFilename, err)
+	}
+
+	return old, new, nil
+}
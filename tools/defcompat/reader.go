@@ -0,0 +1,50 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jakewright/home-automation/tools/deploy/pkg/git"
+)
+
+// gitFileReader implements svcdef.FileReader by reading
+// files as they were at a revision of the git repository
+type gitFileReader struct {
+	repo     *git.Repository
+	revision string
+	seen     map[string]bool
+}
+
+// ReadFile returns the bytes of the file with the given name
+func (r *gitFileReader) ReadFile(filename string) ([]byte, error) {
+	if r.seen == nil {
+		r.seen = map[string]bool{}
+	}
+
+	r.seen[filename] = true
+
+	// Git treats paths as relative to the root of the working
+	// tree unless they are explicitly relative to the directory.
+	if filepath.IsAbs(filename) {
+		rel, err := filepath.Rel(r.repo.Dir, filename)
+		if err != nil {
+			return nil, err
+		}
+		filename = rel
+	}
+
+	if !strings.HasPrefix(filename, "../") {
+		filename = "./" + filename
+	}
+
+	return r.repo.Show(r.revision, filepath.ToSlash(filename))
+}
+
+// SeenFile returns true if the file has already been read
+func (r *gitFileReader) SeenFile(filename string) bool {
+	if r.seen == nil {
+		r.seen = map[string]bool{}
+	}
+
+	return r.seen[filename]
+}
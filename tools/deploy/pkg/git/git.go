@@ -225,6 +225,19 @@ func (r *Repository) log(from, to, path string) ([]*Commit, error) {
 	return commits, nil
 }
 
+// Show returns the contents of the file at the given revision.
+// Paths starting with ./ or ../ are relative to the repository's
+// directory rather than the root of the working tree.
+func (r *Repository) Show(revision, filename string) ([]byte, error) {
+	result := r.exec("show", revision+":"+filename)
+
+	if result.Err != nil {
+		return nil, oops.WithMessage(result.Err, "failed to show %s at %s", filename, revision)
+	}
+
+	return []byte(result.Stdout), nil
+}
+
 func (r *Repository) exec(args ...string) *exe.Result {
 	return exe.Command("git", args...).Dir(r.Dir).Run()
 }
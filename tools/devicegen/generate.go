@@ -10,14 +10,18 @@ import (
 
 	toolsimports "golang.org/x/tools/imports"
 
-	devicedef "github.com/jakewright/home-automation/libraries/go/device/def"
 	"github.com/jakewright/home-automation/tools/libraries/imports"
 )
 
-const pkg = "domain"
-
 var resolver *imports.Resolver
 
+// deviceDefImport is added to the generated files with an alias
+// so that it doesn't clash with the service's own def package
+var deviceDefImport = &imports.Imp{
+	Alias: "devicedef",
+	Path:  "github.com/jakewright/home-automation/libraries/go/device/def",
+}
+
 func init() {
 	var err error
 	resolver, err = imports.NewResolver()
@@ -26,23 +30,32 @@ func init() {
 	}
 }
 
-type file map[string]*description
+type generator interface {
+	Data(im *imports.Manager) (interface{}, error)
+	Template() (*template.Template, error)
+	PackageDir() string
+	Filename() string
+}
 
-type description struct {
-	Properties map[string]property `json:"properties"`
+// baseGenerator holds the devices for the generators to embed
+type baseGenerator struct {
+	path    string
+	devices []*deviceData
 }
 
-type property struct {
-	Type    string              `json:"type"`
-	Min     *float64            `json:"min"`
-	Max     *float64            `json:"max"`
-	Options []*devicedef.Option `json:"options"`
+// hasCommands returns true if any of the devices have commands
+func (g *baseGenerator) hasCommands() bool {
+	for _, d := range g.devices {
+		if len(d.Commands) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
-type generator interface {
-	Data(im *imports.Manager) (interface{}, error)
-	Template() (*template.Template, error)
-	Filename() string
+// importPath returns the go import path of a package in the service
+func (g *baseGenerator) importPath(pkg string) (string, error) {
+	return resolver.Resolve(g.path, pkg)
 }
 
 func generate(path string) {
@@ -56,14 +69,26 @@ func generate(path string) {
 		panic(err)
 	}
 
+	devices, err := parseFile(f)
+	if err != nil {
+		panic(err)
+	}
+
+	base := baseGenerator{
+		path:    path,
+		devices: devices,
+	}
+
 	generators := []generator{
-		&propertyTypesGenerator{file: f},
+		&domainGenerator{base},
+		&routesGenerator{base},
 	}
 
 	for _, generator := range generators {
-		filename := generator.Filename()
+		// The file is generated in a package relative to the JSON file
+		filename := filepath.Join(filepath.Dir(path), generator.PackageDir(), generator.Filename())
 
-		self, err := resolver.Resolve(path, pkg)
+		self, err := resolver.Resolve(path, generator.PackageDir())
 		if err != nil {
 			panic(err)
 		}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// file is the format of a devicegen JSON file. It maps
+// each device type (in PascalCase) to its description.
+type file map[string]*description
+
+type description struct {
+	Properties map[string]*property `json:"properties"`
+	Commands   map[string]*command  `json:"commands"`
+}
+
+type property struct {
+	Type          string    `json:"type"`
+	Min           *float64  `json:"min"`
+	Max           *float64  `json:"max"`
+	Interpolation string    `json:"interpolation"`
+	Options       []*option `json:"options"`
+}
+
+type command struct {
+	Args map[string]*arg `json:"args"`
+}
+
+type arg struct {
+	Type     string    `json:"type"`
+	Required bool      `json:"required"`
+	Min      *float64  `json:"min"`
+	Max      *float64  `json:"max"`
+	Options  []*option `json:"options"`
+}
+
+type option struct {
+	Value string `json:"value"`
+	Name  string `json:"name"`
+}
+
+type deviceData struct {
+	Name       string // PascalCase
+	NameCamel  string // camelCase
+	Properties []*propertyData
+	Commands   []*commandData
+}
+
+type propertyData struct {
+	NameSnake     string
+	Type          string
+	Min           *float64
+	Max           *float64
+	Interpolation string
+	Options       []*option
+}
+
+type commandData struct {
+	NameSnake  string
+	NamePascal string
+	Args       []*argData
+}
+
+type argData struct {
+	NameSnake  string
+	NamePascal string
+	Type       string
+	GoType     string
+	Required   bool
+	Min        *float64
+	Max        *float64
+	Options    []*option
+}
+
+var reDeviceName = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// goTypes maps device property types to go types
+var goTypes = map[string]string{
+	"bool":   "bool",
+	"int":    "int64",
+	"string": "string",
+	"rgb":    "util.RGB",
+}
+
+// parseFile validates the descriptions in the file and returns
+// the devices sorted by name so the output is consistent
+func parseFile(f file) ([]*deviceData, error) {
+	var devices []*deviceData
+
+	for name, desc := range f {
+		if !reDeviceName.MatchString(name) {
+			return nil, fmt.Errorf("device name %q should be PascalCase", name)
+		}
+
+		d := &deviceData{
+			Name:      name,
+			NameCamel: strings.ToLower(name[0:1]) + name[1:],
+		}
+
+		for propertyName, p := range desc.Properties {
+			pd, err := parseProperty(propertyName, p)
+			if err != nil {
+				return nil, fmt.Errorf("device %s: %w", name, err)
+			}
+
+			d.Properties = append(d.Properties, pd)
+		}
+
+		for commandName, c := range desc.Commands {
+			cd, err := parseCommand(commandName, c)
+			if err != nil {
+				return nil, fmt.Errorf("device %s: %w", name, err)
+			}
+
+			d.Commands = append(d.Commands, cd)
+		}
+
+		sort.Slice(d.Properties, func(i, j int) bool {
+			return d.Properties[i].NameSnake < d.Properties[j].NameSnake
+		})
+
+		sort.Slice(d.Commands, func(i, j int) bool {
+			return d.Commands[i].NameSnake < d.Commands[j].NameSnake
+		})
+
+		devices = append(devices, d)
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Name < devices[j].Name
+	})
+
+	return devices, nil
+}
+
+func parseProperty(name string, p *property) (*propertyData, error) {
+	if !isSnake(name) {
+		return nil, fmt.Errorf("property name %q should be snake_case", name)
+	}
+
+	if err := validateType("property", name, p.Type, p.Min, p.Max, p.Options); err != nil {
+		return nil, err
+	}
+
+	switch p.Interpolation {
+	case "", "discrete":
+	case "continuous":
+		if p.Type != "int" && p.Type != "rgb" {
+			return nil, fmt.Errorf("%s property %q can't have continuous interpolation", p.Type, name)
+		}
+	default:
+		return nil, fmt.Errorf("property %q has invalid interpolation %q", name, p.Interpolation)
+	}
+
+	return &propertyData{
+		NameSnake:     name,
+		Type:          p.Type,
+		Min:           p.Min,
+		Max:           p.Max,
+		Interpolation: p.Interpolation,
+		Options:       p.Options,
+	}, nil
+}
+
+func parseCommand(name string, c *command) (*commandData, error) {
+	if !isSnake(name) {
+		return nil, fmt.Errorf("command name %q should be snake_case", name)
+	}
+
+	cd := &commandData{
+		NameSnake:  name,
+		NamePascal: snakeToPascalCase(name),
+	}
+
+	for argName, a := range c.Args {
+		if !isSnake(argName) {
+			return nil, fmt.Errorf("command %q: argument name %q should be snake_case", name, argName)
+		}
+
+		if err := validateType("argument", argName, a.Type, a.Min, a.Max, a.Options); err != nil {
+			return nil, fmt.Errorf("command %q: %w", name, err)
+		}
+
+		cd.Args = append(cd.Args, &argData{
+			NameSnake:  argName,
+			NamePascal: snakeToPascalCase(argName),
+			Type:       a.Type,
+			GoType:     goTypes[a.Type],
+			Required:   a.Required,
+			Min:        a.Min,
+			Max:        a.Max,
+			Options:    a.Options,
+		})
+	}
+
+	sort.Slice(cd.Args, func(i, j int) bool {
+		return cd.Args[i].NameSnake < cd.Args[j].NameSnake
+	})
+
+	return cd, nil
+}
+
+// validateType checks that the constraints make sense for the type.
+// Only int values can have a min or max and only strings can have options.
+func validateType(kind, name, typ string, min, max *float64, options []*option) error {
+	if _, ok := goTypes[typ]; !ok {
+		return fmt.Errorf("%s %q has invalid type %q", kind, name, typ)
+	}
+
+	if typ != "int" && min != nil {
+		return fmt.Errorf("%s %s %q can't have a min value", typ, kind, name)
+	}
+
+	if typ != "int" && max != nil {
+		return fmt.Errorf("%s %s %q can't have a max value", typ, kind, name)
+	}
+
+	for _, v := range []*float64{min, max} {
+		if v != nil && *v != float64(int64(*v)) {
+			return fmt.Errorf("%s %q should have integer bounds", kind, name)
+		}
+	}
+
+	if min != nil && max != nil && *min > *max {
+		return fmt.Errorf("%s %q has min greater than max", kind, name)
+	}
+
+	if typ != "string" && len(options) > 0 {
+		return fmt.Errorf("%s %s %q can't have options", typ, kind, name)
+	}
+
+	for _, o := range options {
+		if o.Value == "" || o.Name == "" {
+			return fmt.Errorf("%s %q has an option without a value and name", kind, name)
+		}
+	}
+
+	return nil
+}
+
+func isSnake(s string) bool {
+	return s != "" && s == strings.ToLower(s)
+}
+
+func snakeToPascalCase(s string) string {
+	var pascal string
+	upper := true
+
+	for _, c := range s {
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			pascal += strings.ToUpper(string(c))
+			upper = false
+		default:
+			pascal += string(c)
+		}
+	}
+
+	return pascal
+}
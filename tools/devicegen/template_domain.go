@@ -0,0 +1,289 @@
+package main
+
+import (
+	"text/template"
+
+	"github.com/jakewright/home-automation/tools/libraries/imports"
+)
+
+type domainData struct {
+	PackageName string
+	Imports     []*imports.Imp
+	DefAlias    string
+	Devices     []*deviceData
+	HasCommands bool
+}
+
+const domainTemplateText = `// Code generated by devicegen. DO NOT EDIT.
+
+package {{ .PackageName }}
+
+{{ if .Imports }}
+	import (
+		{{- range .Imports }}
+			{{ .Alias }} "{{ .Path }}"
+		{{- end}}
+	)
+{{- end }}
+
+{{ $def := .DefAlias }}
+
+// Device is implemented by every type of device that the service controls
+type Device interface {
+	// ID returns the device's ID
+	ID() string
+
+	// DeviceHeader returns the device's header from the registry
+	DeviceHeader() *devicedef.Header
+}
+
+{{ if .HasCommands }}
+	// InvokeCommand invokes the command on the device. An
+	// error is returned if the device's type has no commands.
+	func InvokeCommand(d Device, command string, args map[string]interface{}) error {
+		switch d := d.(type) {
+		{{- range $device := .Devices }}
+			{{- if $device.Commands }}
+				case {{ $device.Name }}Device:
+					return Invoke{{ $device.Name }}Command(d, command, args)
+			{{- end }}
+		{{- end }}
+		}
+
+		return oops.BadRequest("device %q does not support commands", d.ID())
+	}
+{{ end }}
+
+{{ range $device := .Devices }}
+	// {{ $device.Name }}Device is implemented by {{ $device.Name }} devices
+	type {{ $device.Name }}Device interface {
+		Device
+
+		// State returns the current state of the device
+		State() *{{ $def }}.{{ $device.Name }}State
+
+		// ApplyState updates the device with the
+		// properties that are set in the state
+		ApplyState(state *{{ $def }}.{{ $device.Name }}State) error
+
+		{{- range $command := $device.Commands }}
+
+			// {{ $command.NamePascal }}Command handles the {{ $command.NameSnake }} command
+			{{ $command.NamePascal }}Command({{ if $command.Args }}args *{{ $device.Name }}{{ $command.NamePascal }}Args{{ end }}) error
+		{{- end }}
+	}
+
+	// {{ $device.Name }}Properties returns metadata
+	// about the properties of {{ $device.Name }} devices
+	func {{ $device.Name }}Properties() map[string]*devicedef.Property {
+		return map[string]*devicedef.Property{
+			{{- range $p := $device.Properties }}
+				"{{ $p.NameSnake }}": {
+					Type: ptr.String("{{ $p.Type }}"),
+					{{- if $p.Min }}
+						Min: ptr.Float64({{ $p.Min }}),
+					{{- end }}
+					{{- if $p.Max }}
+						Max: ptr.Float64({{ $p.Max }}),
+					{{- end }}
+					{{- if $p.Interpolation }}
+						Interpolation: ptr.String("{{ $p.Interpolation }}"),
+					{{- end }}
+					{{- if $p.Options }}
+						Options: []*devicedef.Option{
+							{{- range $option := $p.Options }}
+								{Value: ptr.String({{ printf "%q" $option.Value }}), Name: ptr.String({{ printf "%q" $option.Name }})},
+							{{- end }}
+						},
+					{{- end }}
+				},
+			{{- end }}
+		}
+	}
+
+	{{ if $device.Commands }}
+		// {{ $device.Name }}Commands returns metadata about
+		// the commands supported by {{ $device.Name }} devices
+		func {{ $device.Name }}Commands() map[string]*devicedef.Command {
+			return map[string]*devicedef.Command{
+				{{- range $command := $device.Commands }}
+					"{{ $command.NameSnake }}": {
+						{{- if $command.Args }}
+							Args: map[string]*devicedef.Arg{
+								{{- range $a := $command.Args }}
+									"{{ $a.NameSnake }}": {
+										Required: ptr.Bool({{ $a.Required }}),
+										Type: ptr.String("{{ $a.Type }}"),
+										{{- if $a.Min }}
+											Min: ptr.Float64({{ $a.Min }}),
+										{{- end }}
+										{{- if $a.Max }}
+											Max: ptr.Float64({{ $a.Max }}),
+										{{- end }}
+										{{- if $a.Options }}
+											Options: []*devicedef.Option{
+												{{- range $option := $a.Options }}
+													{Value: ptr.String({{ printf "%q" $option.Value }}), Name: ptr.String({{ printf "%q" $option.Name }})},
+												{{- end }}
+											},
+										{{- end }}
+									},
+								{{- end }}
+							},
+						{{- end }}
+					},
+				{{- end }}
+			}
+		}
+
+		{{ range $command := $device.Commands }}
+			{{ if $command.Args }}
+				// {{ $device.Name }}{{ $command.NamePascal }}Args are the arguments of the {{ $command.NameSnake }} command.
+				// Optional arguments are nil if they were not given.
+				type {{ $device.Name }}{{ $command.NamePascal }}Args struct {
+					{{- range $a := $command.Args }}
+						{{ $a.NamePascal }} {{ if not $a.Required }}*{{ end }}{{ $a.GoType }}
+					{{- end }}
+				}
+			{{ end }}
+		{{ end }}
+
+		// Invoke{{ $device.Name }}Command converts the arguments to their go types
+		// and calls the device's method for the command. An error is returned
+		// if the command is not known or if the arguments are not valid.
+		func Invoke{{ $device.Name }}Command(d {{ $device.Name }}Device, command string, args map[string]interface{}) error {
+			switch command {
+			{{- range $command := $device.Commands }}
+				case "{{ $command.NameSnake }}":
+					{{- if $command.Args }}
+						a := &{{ $device.Name }}{{ $command.NamePascal }}Args{}
+
+						for name, value := range args {
+							switch name {
+							{{- range $a := $command.Args }}
+								case "{{ $a.NameSnake }}":
+									{{- if eq $a.Type "int" }}
+										v, ok := device.ToInt(value)
+										if !ok {
+											return oops.BadRequest("argument '{{ $a.NameSnake }}' should be an integer")
+										}
+
+										{{- if $a.Min }}
+
+											if v < {{ $a.Min }} {
+												return oops.BadRequest("argument '{{ $a.NameSnake }}' should be ≥ {{ $a.Min }}")
+											}
+										{{- end }}
+
+										{{- if $a.Max }}
+
+											if v > {{ $a.Max }} {
+												return oops.BadRequest("argument '{{ $a.NameSnake }}' should be ≤ {{ $a.Max }}")
+											}
+										{{- end }}
+									{{- else if eq $a.Type "bool" }}
+										v, ok := value.(bool)
+										if !ok {
+											return oops.BadRequest("argument '{{ $a.NameSnake }}' should be a bool")
+										}
+									{{- else if eq $a.Type "string" }}
+										v, ok := value.(string)
+										if !ok {
+											return oops.BadRequest("argument '{{ $a.NameSnake }}' should be a string")
+										}
+
+										{{- if $a.Options }}
+
+											switch v {
+											case {{ range $i, $option := $a.Options }}{{ if $i }}, {{ end }}{{ printf "%q" $option.Value }}{{ end }}:
+											default:
+												return oops.BadRequest("argument '{{ $a.NameSnake }}' received invalid option: %s", v)
+											}
+										{{- end }}
+									{{- else if eq $a.Type "rgb" }}
+										s, ok := value.(string)
+										if !ok {
+											return oops.BadRequest("argument '{{ $a.NameSnake }}' should be a hex color string")
+										}
+
+										c, err := util.HexToColor(s)
+										if err != nil {
+											return oops.WithMessage(err, "argument '{{ $a.NameSnake }}': failed to parse %q as RGB value", s)
+										}
+
+										v := util.RGB(c)
+									{{- end }}
+
+									a.{{ $a.NamePascal }} = {{ if not $a.Required }}&{{ end }}v
+							{{- end }}
+							default:
+								return oops.BadRequest("argument %q not known for command '{{ $command.NameSnake }}'", name)
+							}
+						}
+
+						{{- range $a := $command.Args }}
+							{{- if $a.Required }}
+
+								if _, ok := args["{{ $a.NameSnake }}"]; !ok {
+									return oops.BadRequest("argument '{{ $a.NameSnake }}' is required")
+								}
+							{{- end }}
+						{{- end }}
+
+						return d.{{ $command.NamePascal }}Command(a)
+					{{- else }}
+						if len(args) > 0 {
+							return oops.BadRequest("command '{{ $command.NameSnake }}' does not take arguments")
+						}
+
+						return d.{{ $command.NamePascal }}Command()
+					{{- end }}
+			{{- end }}
+			}
+
+			return oops.BadRequest("command %q not known for {{ $device.Name }} devices", command)
+		}
+	{{- end }}
+{{- end }}
+`
+
+type domainGenerator struct {
+	baseGenerator
+}
+
+func (g *domainGenerator) Template() (*template.Template, error) {
+	return template.New("domain_template").Parse(domainTemplateText)
+}
+
+func (g *domainGenerator) PackageDir() string {
+	return "domain"
+}
+
+func (g *domainGenerator) Data(im *imports.Manager) (interface{}, error) {
+	if len(g.devices) == 0 {
+		return nil, nil
+	}
+
+	defPath, err := g.importPath("def")
+	if err != nil {
+		return nil, err
+	}
+
+	im.Add("github.com/jakewright/home-automation/libraries/go/device")
+	im.Add("github.com/jakewright/home-automation/libraries/go/oops")
+	im.Add("github.com/jakewright/home-automation/libraries/go/ptr")
+	im.Add("github.com/jakewright/home-automation/libraries/go/util")
+	defAlias := im.Add(defPath)
+
+	return &domainData{
+		PackageName: g.PackageDir(),
+		Imports:     append(im.Get(), deviceDefImport),
+		DefAlias:    defAlias,
+		Devices:     g.devices,
+		HasCommands: g.hasCommands(),
+	}, nil
+}
+
+func (g *domainGenerator) Filename() string {
+	return "devices.go"
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"text/template"
+
+	"github.com/jakewright/home-automation/tools/libraries/imports"
+)
+
+type routesData struct {
+	PackageName string
+	Imports     []*imports.Imp
+	DefAlias    string
+	DomainAlias string
+	Devices     []*deviceData
+	HasCommands bool
+}
+
+const routesTemplateText = `// Code generated by devicegen. DO NOT EDIT.
+
+package {{ .PackageName }}
+
+{{ if .Imports }}
+	import (
+		{{- range .Imports }}
+			{{ .Alias }} "{{ .Path }}"
+		{{- end}}
+	)
+{{- end }}
+
+{{ $def := .DefAlias }}
+{{ $domain := .DomainAlias }}
+
+// deviceSession is a device that has been opened by the
+// controller for the duration of a request. Changes to the
+// device's state only take effect when Commit is called.
+type deviceSession interface {
+	// Device returns the device with its current state
+	Device() {{ $domain }}.Device
+
+	// Commit applies any changes made to the device's state. The
+	// request is passed so that the controller can read any fields
+	// that aren't part of the state, e.g. a transition.
+	Commit(ctx context.Context, req interface{}) error
+
+	// Close releases the device. It is
+	// always called when the request ends.
+	Close()
+}
+
+// deviceOpener must be implemented by the Controller. The openDevice
+// method should return a NotFound error if the device does not exist.
+type deviceOpener interface {
+	openDevice(ctx context.Context, id string) (deviceSession, error)
+}
+
+var _ deviceOpener = (*Controller)(nil)
+
+// deviceState returns the state of the device
+func deviceState(d {{ $domain }}.Device) interface{} {
+	switch d := d.(type) {
+	{{- range $device := .Devices }}
+		case {{ $domain }}.{{ $device.Name }}Device:
+			return d.State()
+	{{- end }}
+	}
+
+	return nil
+}
+
+// publishStateChange publishes a DeviceStateChangedEvent if the
+// device's state has changed. The new state has already been
+// committed so a failure is logged rather than returned.
+func (c *Controller) publishStateChange(ctx context.Context, d {{ $domain }}.Device, before, after interface{}) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	if err := (&devicedef.DeviceStateChangedEvent{}).
+		SetHeader(*d.DeviceHeader()).
+		SetState(after).
+		SetChangedAt(time.Now()).
+		Publish(ctx, c.Publisher); err != nil {
+		slog.FromContext(ctx).Errorf("Failed to publish state changed event: %v", err, map[string]string{
+			"device_id": d.ID(),
+		})
+	}
+}
+
+{{ if .HasCommands }}
+	// InvokeCommand handles the standard command RPC
+	func (c *Controller) InvokeCommand(ctx context.Context, body *devicedef.InvokeCommandRequest) (*devicedef.InvokeCommandResponse, error) {
+		errParams := map[string]string{
+			"device_id": body.GetDeviceId(),
+			"command":   body.GetCommand(),
+		}
+
+		s, err := c.openDevice(ctx, body.GetDeviceId())
+		if err != nil {
+			return nil, oops.WithMetadata(err, errParams)
+		}
+		defer s.Close()
+
+		before := deviceState(s.Device())
+
+		if err := {{ $domain }}.InvokeCommand(s.Device(), body.GetCommand(), body.Args); err != nil {
+			return nil, oops.WithMetadata(err, errParams)
+		}
+
+		if err := s.Commit(ctx, body); err != nil {
+			return nil, oops.WithMessage(err, "failed to commit device state", errParams)
+		}
+
+		c.publishStateChange(ctx, s.Device(), before, deviceState(s.Device()))
+
+		return &devicedef.InvokeCommandResponse{}, nil
+	}
+{{ end }}
+
+{{ range $device := .Devices }}
+	// Get{{ $device.Name }} returns the current state of a {{ $device.Name }} device
+	func (c *Controller) Get{{ $device.Name }}(ctx context.Context, body *{{ $def }}.Get{{ $device.Name }}Request) (*{{ $def }}.{{ $device.Name }}Response, error) {
+		s, d, err := c.open{{ $device.Name }}(ctx, body.GetDeviceId())
+		if err != nil {
+			return nil, err
+		}
+		defer s.Close()
+
+		return {{ $device.NameCamel }}Response(d), nil
+	}
+
+	// Update{{ $device.Name }} applies the state in the request to a {{ $device.Name }} device
+	func (c *Controller) Update{{ $device.Name }}(ctx context.Context, body *{{ $def }}.Update{{ $device.Name }}Request) (*{{ $def }}.{{ $device.Name }}Response, error) {
+		errParams := map[string]string{
+			"device_id": body.GetDeviceId(),
+		}
+
+		s, d, err := c.open{{ $device.Name }}(ctx, body.GetDeviceId())
+		if err != nil {
+			return nil, err
+		}
+		defer s.Close()
+
+		before := d.State()
+
+		if err := d.ApplyState(body.State); err != nil {
+			return nil, oops.WithMetadata(err, errParams)
+		}
+
+		if err := s.Commit(ctx, body); err != nil {
+			return nil, oops.WithMessage(err, "failed to commit device state", errParams)
+		}
+
+		c.publishStateChange(ctx, d, before, d.State())
+
+		return {{ $device.NameCamel }}Response(d), nil
+	}
+
+	// open{{ $device.Name }} opens the device and checks that it is a {{ $device.Name }}
+	func (c *Controller) open{{ $device.Name }}(ctx context.Context, id string) (deviceSession, {{ $domain }}.{{ $device.Name }}Device, error) {
+		s, err := c.openDevice(ctx, id)
+		if err != nil {
+			return nil, nil, oops.WithMetadata(err, map[string]string{
+				"device_id": id,
+			})
+		}
+
+		d, ok := s.Device().({{ $domain }}.{{ $device.Name }}Device)
+		if !ok {
+			s.Close()
+			return nil, nil, oops.BadRequest("device %q is not a {{ $device.Name }}", id)
+		}
+
+		return s, d, nil
+	}
+
+	func {{ $device.NameCamel }}Response(d {{ $domain }}.{{ $device.Name }}Device) *{{ $def }}.{{ $device.Name }}Response {
+		return &{{ $def }}.{{ $device.Name }}Response{
+			Header:     d.DeviceHeader(),
+			Properties: {{ $domain }}.{{ $device.Name }}Properties(),
+			State:      d.State(),
+		}
+	}
+{{ end }}
+`
+
+type routesGenerator struct {
+	baseGenerator
+}
+
+func (g *routesGenerator) Template() (*template.Template, error) {
+	return template.New("routes_template").Parse(routesTemplateText)
+}
+
+func (g *routesGenerator) PackageDir() string {
+	return "routes"
+}
+
+func (g *routesGenerator) Data(im *imports.Manager) (interface{}, error) {
+	if len(g.devices) == 0 {
+		return nil, nil
+	}
+
+	defPath, err := g.importPath("def")
+	if err != nil {
+		return nil, err
+	}
+
+	domainPath, err := g.importPath("domain")
+	if err != nil {
+		return nil, err
+	}
+
+	im.Add("context")
+	im.Add("reflect")
+	im.Add("time")
+	im.Add("github.com/jakewright/home-automation/libraries/go/oops")
+	im.Add("github.com/jakewright/home-automation/libraries/go/slog")
+	defAlias := im.Add(defPath)
+	domainAlias := im.Add(domainPath)
+
+	return &routesData{
+		PackageName: g.PackageDir(),
+		Imports:     append(im.Get(), deviceDefImport),
+		DefAlias:    defAlias,
+		DomainAlias: domainAlias,
+		Devices:     g.devices,
+		HasCommands: g.hasCommands(),
+	}, nil
+}
+
+func (g *routesGenerator) Filename() string {
+	return "devices.go"
+}
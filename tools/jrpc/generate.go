@@ -50,7 +50,11 @@ func generate(defPath string, file *svcdef.File) error {
 	generators := []generator{
 		&clientGenerator{},
 		&firehoseGenerator{},
+		&mockGenerator{},
+		&openAPIGenerator{},
 		&routerGenerator{},
+		&tsClientGenerator{},
+		&tsTypesGenerator{},
 		&typesGenerator{},
 	}
 
@@ -109,11 +113,13 @@ func generate(defPath string, file *svcdef.File) error {
 		b := buf.Bytes()
 
 		// Run gofmt on the code
-		b, err = imports.Process(filename, b, &imports.Options{
-			Comments: true,
-		})
-		if err != nil {
-			panic(err)
+		if filepath.Ext(filename) == ".go" {
+			b, err = imports.Process(filename, b, &imports.Options{
+				Comments: true,
+			})
+			if err != nil {
+				panic(err)
+			}
 		}
 
 		// Create the directory if necessary
@@ -14,6 +14,7 @@ import (
 var (
 	reValidGoStruct           = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*`)
 	reValidGoStructUnderscore = regexp.MustCompile("^[A-Z][a-zA-Z0-9_]*$")
+	reValidEnumValue          = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9_]*$")
 )
 
 // externalPackageName returns the name to use for the external package
@@ -65,6 +66,7 @@ type typeInfo struct {
 	TypeName      string
 	FullTypeName  string
 	IsMessageType bool
+	IsEnumType    bool
 	Repeated      bool
 	Pointer       bool
 }
@@ -93,7 +95,7 @@ func (ti *typeInfo) isFloat() bool {
 // if necessary, a path that needs to be imported.
 func resolveTypeName(t *svcdef.Type, f *svcdef.File, im *imports.Manager) (*typeInfo, error) {
 	var fullTypeName, importPath string
-	var messageType bool
+	var messageType, enumType bool
 	var err error
 
 	if t.Map { // map type
@@ -123,10 +125,10 @@ func resolveTypeName(t *svcdef.Type, f *svcdef.File, im *imports.Manager) (*type
 		}
 		fullTypeName = "map[" + fullTypeName
 
-	} else if strings.HasPrefix(t.Qualified, ".") { // local type (message is defined in the same def file)
+	} else if strings.HasPrefix(t.Qualified, ".") { // local type (message or enum is defined in the same def file)
 		// Remove the first dot and replace any others with underscores
 		fullTypeName = strings.ReplaceAll(t.Qualified[1:], ".", "_")
-		messageType = true
+		messageType, enumType = !t.Enum, t.Enum
 
 		// By convention, the type will be defined in the external package
 		importPath, err = resolver.Resolve(f.Path, packageDirExternal)
@@ -136,7 +138,7 @@ func resolveTypeName(t *svcdef.Type, f *svcdef.File, im *imports.Manager) (*type
 
 	} else if parts := strings.SplitN(t.Qualified, ".", 2); len(parts) == 2 { // imported type
 		fullTypeName = strings.ReplaceAll(parts[1], ".", "_")
-		messageType = true
+		messageType, enumType = !t.Enum, t.Enum
 
 		// Expect to find an import with an alias of parts[0], and again, by
 		// convention, the type name will be defined in the external package.
@@ -184,6 +186,7 @@ func resolveTypeName(t *svcdef.Type, f *svcdef.File, im *imports.Manager) (*type
 		TypeName:      typeName,
 		FullTypeName:  fullTypeName,
 		IsMessageType: messageType,
+		IsEnumType:    enumType,
 		Repeated:      t.Repeated,
 		Pointer:       fullTypeName[0] == '*',
 	}, nil
@@ -15,6 +15,8 @@ type clientDataEndpoint struct {
 	OutputType string
 	HTTPMethod string
 	URL        string
+	Idempotent bool
+	Stream     bool
 }
 
 type clientData struct {
@@ -39,11 +41,85 @@ package {{ .PackageName }}
 // {{ .ServiceName }}Service is the public interface of this service
 type {{ .ServiceName }}Service interface {
 	{{- range .Endpoints }}
-		{{ .NameUpper }}(ctx context.Context, body *{{ .InputType }}) *{{ .NameUpper }}Future
+		{{- if .Stream }}
+			{{ .NameUpper }}(ctx context.Context, body *{{ .InputType }}) *{{ .NameUpper }}Stream
+		{{- else }}
+			{{ .NameUpper }}(ctx context.Context, body *{{ .InputType }}) *{{ .NameUpper }}Future
+		{{- end }}
 	{{- end }}
 }
 
 {{- range $endpoint := .Endpoints }}
+	{{- if $endpoint.Stream }}
+	// {{ $endpoint.NameUpper }}Stream represents an in-flight {{ $endpoint.NameUpper }} request
+	type {{ $endpoint.NameUpper }}Stream struct {
+		c      chan *{{ $endpoint.OutputType }}
+		err    error
+		cancel context.CancelFunc
+	}
+
+	func new{{ $endpoint.NameUpper }}Stream(ctx context.Context, dispatcher taxi.Dispatcher, body *{{ $endpoint.InputType }}) *{{ $endpoint.NameUpper }}Stream {
+		ctx, cancel := context.WithCancel(ctx)
+
+		s := &{{ $endpoint.NameUpper }}Stream{
+			c:      make(chan *{{ $endpoint.OutputType }}),
+			cancel: cancel,
+		}
+
+		go func() {
+			defer close(s.c)
+			defer cancel()
+
+			taxiStream, err := dispatcher.Stream(ctx, &taxi.RPC{
+				Method: "{{ $endpoint.HTTPMethod }}",
+				URL: "{{ $endpoint.URL }}",
+				Body: body,
+			})
+			if err != nil {
+				s.err = err
+				return
+			}
+			defer func() { _ = taxiStream.Close() }()
+
+			for {
+				msg := &{{ $endpoint.OutputType }}{}
+				if err := taxiStream.Next(msg); err != nil {
+					// EOF means the stream ended normally. An
+					// error caused by Close() is not reported.
+					if err != io.EOF && ctx.Err() == nil {
+						s.err = err
+					}
+					return
+				}
+
+				select {
+				case s.c <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		return s
+	}
+
+	// C returns a channel that receives each message in the
+	// stream. The channel is closed when the stream ends.
+	func (s *{{ $endpoint.NameUpper }}Stream) C() <-chan *{{ $endpoint.OutputType }} {
+		return s.c
+	}
+
+	// Err returns the error that ended the stream or nil if it
+	// ended normally. It should be called after C is closed.
+	func (s *{{ $endpoint.NameUpper }}Stream) Err() error {
+		return s.err
+	}
+
+	// Close stops the stream. C will be closed shortly after.
+	func (s *{{ $endpoint.NameUpper }}Stream) Close() {
+		s.cancel()
+	}
+	{{- else }}
 	// {{ $endpoint.NameUpper }}Future represents an in-flight {{ $endpoint.NameUpper }} request
 	type {{ $endpoint.NameUpper }}Future struct {
 		done <-chan struct{}
@@ -56,6 +132,7 @@ type {{ .ServiceName }}Service interface {
 		<-f.done
 		return f.rsp, f.err
 	}
+	{{- end }}
 {{- end }}
 
 // Client makes requests to this service
@@ -74,12 +151,21 @@ func NewClient(dispatcher taxi.Dispatcher) *Client {
 }
 
 {{- range $endpoint := .Endpoints }}
+	{{- if $endpoint.Stream }}
+	// {{ $endpoint.NameUpper }} dispatches a streaming RPC to the service
+	func (c *Client) {{ $endpoint.NameUpper }}(ctx context.Context, body *{{ $endpoint.InputType }}) *{{ $endpoint.NameUpper }}Stream {
+		return new{{ $endpoint.NameUpper }}Stream(ctx, c.dispatcher, body)
+	}
+	{{- else }}
 	// {{ $endpoint.NameUpper }} dispatches an RPC to the service
 	func (c *Client) {{ $endpoint.NameUpper }}(ctx context.Context, body *{{ $endpoint.InputType }}) *{{ $endpoint.NameUpper }}Future {
 		taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
 			Method: "{{ $endpoint.HTTPMethod }}",
 			URL: "{{ $endpoint.URL }}",
 			Body: body,
+			{{- if $endpoint.Idempotent }}
+				Idempotent: true,
+			{{- end }}
 		})
 
 		done := make(chan struct{})
@@ -95,6 +181,7 @@ func NewClient(dispatcher taxi.Dispatcher) *Client {
 
 		return ftr
 	}
+	{{- end }}
 {{- end }}
 
 // MockClient can be used in tests
@@ -115,12 +202,21 @@ func NewMockClient(ctx context.Context, t *testing.T) *MockClient {
 }
 
 {{- range $endpoint := .Endpoints }}
+	{{- if $endpoint.Stream }}
+	// {{ $endpoint.NameUpper }} dispatches a streaming RPC to the mock client
+	func (c *MockClient) {{ $endpoint.NameUpper }}(ctx context.Context, body *{{ $endpoint.InputType }}) *{{ $endpoint.NameUpper }}Stream {
+		return new{{ $endpoint.NameUpper }}Stream(ctx, c.dispatcher, body)
+	}
+	{{- else }}
 	// {{ $endpoint.NameUpper }} dispatches an RPC to the mock client
 	func (c *MockClient) {{ $endpoint.NameUpper }}(ctx context.Context, body *{{ $endpoint.InputType }}) *{{ $endpoint.NameUpper }}Future {
 		taxiFtr := c.dispatcher.Dispatch(ctx, &taxi.RPC{
 			Method: "{{ $endpoint.HTTPMethod }}",
 			URL: "{{ $endpoint.URL }}",
 			Body: body,
+			{{- if $endpoint.Idempotent }}
+				Idempotent: true,
+			{{- end }}
 		})
 
 		done := make(chan struct{})
@@ -136,6 +232,7 @@ func NewMockClient(ctx context.Context, t *testing.T) *MockClient {
 
 		return ftr
 	}
+	{{- end }}
 {{- end }}
 
 `
@@ -154,6 +251,7 @@ func (g *clientGenerator) PackageDir() string {
 
 func (g *clientGenerator) Data(im *imports.Manager) (interface{}, error) {
 	im.Add("context")
+	im.Add("io")
 	im.Add("github.com/jakewright/home-automation/libraries/go/taxi")
 
 	if g.file.Service == nil {
@@ -193,12 +291,16 @@ func (g *clientGenerator) Data(im *imports.Manager) (interface{}, error) {
 			return nil, fmt.Errorf("failed to resolve RPC %q output type: %w", r.Name, err)
 		}
 
+		idempotent, _ := r.Options["idempotent"].(bool)
+
 		endpoints[i] = &clientDataEndpoint{
 			NameUpper:  nameUpper,
 			InputType:  inType.TypeName,
 			OutputType: outType.TypeName,
 			HTTPMethod: method,
 			URL:        "http://" + path.Join(routerPath, rpcPath),
+			Idempotent: idempotent,
+			Stream:     r.Stream,
 		}
 	}
 
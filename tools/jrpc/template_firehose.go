@@ -39,8 +39,9 @@ import (
 		return p.Publish(ctx, "{{ .EventName }}", m)
 	}
 
-	// {{ .TypeName }}Handler implements the necessary functions to be a Firehose handler
-	type {{ .TypeName }}Handler func(*{{ .TypeName }}) firehose.Result
+	// {{ .TypeName }}Handler implements the necessary functions to be a Firehose handler.
+	// The context carries the trace ID of the chain that published the event.
+	type {{ .TypeName }}Handler func(context.Context, *{{ .TypeName }}) firehose.Result
 
 	// HandleEvent handles the Firehose event
 	func (h {{ .TypeName }}Handler) HandleEvent(ctx context.Context, decode firehose.Decoder) firehose.Result {
@@ -48,7 +49,7 @@ import (
 		if err := decode(&body); err != nil {
 			return firehose.Discard(oops.WithMessage(err, "failed to unmarshal payload"))
 		}
-		return h(&body)
+		return h(ctx, &body)
 	}
 {{ end }}
 `
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jakewright/home-automation/tools/libraries/imports"
+)
+
+type mockDataEndpoint struct {
+	NameUpper  string
+	NameLower  string
+	InputType  string
+	OutputType string
+	Stream     bool
+}
+
+type mockData struct {
+	PackageName string
+	Imports     []*imports.Imp
+	ServiceName string
+	Endpoints   []*mockDataEndpoint
+}
+
+const mockTemplateText = `// Code generated by jrpc. DO NOT EDIT.
+
+package {{ .PackageName }}
+
+{{ if .Imports }}
+	import (
+		{{- range .Imports }}
+			{{ .Alias }} "{{ .Path }}"
+		{{- end}}
+	)
+{{- end }}
+
+// MockService is an in-memory implementation of {{ .ServiceName }}Service
+// that can be used in tests. Responses are programmed, and requests
+// inspected, via the mock of each RPC, e.g. m.On{{ (index .Endpoints 0).NameUpper }}().
+type MockService struct {
+	{{- range .Endpoints }}
+		{{ .NameLower }} *{{ .NameUpper }}Mock
+	{{- end }}
+}
+
+// Compile-time assertion that the mock implements the interface
+var _ {{ .ServiceName }}Service = (*MockService)(nil)
+
+// NewMockService returns a new mock with no responses programmed
+func NewMockService() *MockService {
+	return &MockService{
+		{{- range .Endpoints }}
+			{{ .NameLower }}: &{{ .NameUpper }}Mock{},
+		{{- end }}
+	}
+}
+
+{{- range $endpoint := .Endpoints }}
+	// On{{ $endpoint.NameUpper }} returns the mock of the {{ $endpoint.NameUpper }} RPC
+	func (m *MockService) On{{ $endpoint.NameUpper }}() *{{ $endpoint.NameUpper }}Mock {
+		return m.{{ $endpoint.NameLower }}
+	}
+
+	{{ if $endpoint.Stream -}}
+		// {{ $endpoint.NameUpper }} records the request and streams the programmed messages
+		func (m *MockService) {{ $endpoint.NameUpper }}(ctx context.Context, body *{{ $endpoint.InputType }}) *{{ $endpoint.NameUpper }}Stream {
+			handler := m.{{ $endpoint.NameLower }}.record(body)
+
+			ctx, cancel := context.WithCancel(ctx)
+
+			s := &{{ $endpoint.NameUpper }}Stream{
+				c:      make(chan *{{ $endpoint.OutputType }}),
+				cancel: cancel,
+			}
+
+			go func() {
+				defer close(s.c)
+				defer cancel()
+
+				if err := body.Validate(); err != nil {
+					s.err = err
+					return
+				}
+
+				err := handler(ctx, body, func(msg *{{ $endpoint.OutputType }}) error {
+					select {
+					case s.c <- msg:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				})
+
+				// An error caused by Close() is not reported
+				if ctx.Err() == nil {
+					s.err = err
+				}
+			}()
+
+			return s
+		}
+
+		// {{ $endpoint.NameUpper }}Mock programs and records calls to the {{ $endpoint.NameUpper }} RPC
+		type {{ $endpoint.NameUpper }}Mock struct {
+			mu       sync.Mutex
+			handler  func(context.Context, *{{ $endpoint.InputType }}, func(*{{ $endpoint.OutputType }}) error) error
+			requests []*{{ $endpoint.InputType }}
+		}
+
+		// Returns programs the mock to send the messages and then end the stream with err
+		func (m *{{ $endpoint.NameUpper }}Mock) Returns(msgs []*{{ $endpoint.OutputType }}, err error) *{{ $endpoint.NameUpper }}Mock {
+			return m.Handle(func(_ context.Context, _ *{{ $endpoint.InputType }}, send func(*{{ $endpoint.OutputType }}) error) error {
+				for _, msg := range msgs {
+					if err := send(msg); err != nil {
+						return err
+					}
+				}
+				return err
+			})
+		}
+
+		// Handle programs the mock to call fn for each request
+		func (m *{{ $endpoint.NameUpper }}Mock) Handle(fn func(ctx context.Context, body *{{ $endpoint.InputType }}, send func(*{{ $endpoint.OutputType }}) error) error) *{{ $endpoint.NameUpper }}Mock {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.handler = fn
+			return m
+		}
+
+		func (m *{{ $endpoint.NameUpper }}Mock) record(body *{{ $endpoint.InputType }}) func(context.Context, *{{ $endpoint.InputType }}, func(*{{ $endpoint.OutputType }}) error) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			m.requests = append(m.requests, body)
+
+			if m.handler == nil {
+				return func(context.Context, *{{ $endpoint.InputType }}, func(*{{ $endpoint.OutputType }}) error) error {
+					return oops.InternalService("no response programmed for {{ $endpoint.NameUpper }}")
+				}
+			}
+
+			return m.handler
+		}
+	{{- else -}}
+		// {{ $endpoint.NameUpper }} records the request and returns the programmed response
+		func (m *MockService) {{ $endpoint.NameUpper }}(ctx context.Context, body *{{ $endpoint.InputType }}) *{{ $endpoint.NameUpper }}Future {
+			handler := m.{{ $endpoint.NameLower }}.record(body)
+
+			done := make(chan struct{})
+			ftr := &{{ $endpoint.NameUpper }}Future{done: done}
+
+			if err := body.Validate(); err != nil {
+				ftr.err = err
+			} else {
+				ftr.rsp, ftr.err = handler(ctx, body)
+			}
+
+			close(done)
+			return ftr
+		}
+
+		// {{ $endpoint.NameUpper }}Mock programs and records calls to the {{ $endpoint.NameUpper }} RPC
+		type {{ $endpoint.NameUpper }}Mock struct {
+			mu       sync.Mutex
+			handler  func(context.Context, *{{ $endpoint.InputType }}) (*{{ $endpoint.OutputType }}, error)
+			requests []*{{ $endpoint.InputType }}
+		}
+
+		// Returns programs the mock to return the response and error
+		func (m *{{ $endpoint.NameUpper }}Mock) Returns(rsp *{{ $endpoint.OutputType }}, err error) *{{ $endpoint.NameUpper }}Mock {
+			return m.Handle(func(context.Context, *{{ $endpoint.InputType }}) (*{{ $endpoint.OutputType }}, error) {
+				return rsp, err
+			})
+		}
+
+		// Handle programs the mock to call fn for each request
+		func (m *{{ $endpoint.NameUpper }}Mock) Handle(fn func(ctx context.Context, body *{{ $endpoint.InputType }}) (*{{ $endpoint.OutputType }}, error)) *{{ $endpoint.NameUpper }}Mock {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.handler = fn
+			return m
+		}
+
+		func (m *{{ $endpoint.NameUpper }}Mock) record(body *{{ $endpoint.InputType }}) func(context.Context, *{{ $endpoint.InputType }}) (*{{ $endpoint.OutputType }}, error) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			m.requests = append(m.requests, body)
+
+			if m.handler == nil {
+				return func(context.Context, *{{ $endpoint.InputType }}) (*{{ $endpoint.OutputType }}, error) {
+					return nil, oops.InternalService("no response programmed for {{ $endpoint.NameUpper }}")
+				}
+			}
+
+			return m.handler
+		}
+	{{- end }}
+
+	// Requests returns the requests that the RPC has received, in order
+	func (m *{{ $endpoint.NameUpper }}Mock) Requests() []*{{ $endpoint.InputType }} {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		requests := make([]*{{ $endpoint.InputType }}, len(m.requests))
+		copy(requests, m.requests)
+		return requests
+	}
+
+	// AssertCalled fails the test if the RPC has not been called
+	func (m *{{ $endpoint.NameUpper }}Mock) AssertCalled(t *testing.T) {
+		t.Helper()
+
+		if len(m.Requests()) == 0 {
+			t.Errorf("expected {{ $endpoint.NameUpper }} to be called but it was not")
+		}
+	}
+
+	// AssertNotCalled fails the test if the RPC has been called
+	func (m *{{ $endpoint.NameUpper }}Mock) AssertNotCalled(t *testing.T) {
+		t.Helper()
+
+		if n := len(m.Requests()); n > 0 {
+			t.Errorf("expected {{ $endpoint.NameUpper }} not to be called but it was called %d times", n)
+		}
+	}
+{{- end }}
+`
+
+type mockGenerator struct {
+	baseGenerator
+}
+
+func (g *mockGenerator) Template() (*template.Template, error) {
+	return template.New("mock_template").Parse(mockTemplateText)
+}
+
+func (g *mockGenerator) PackageDir() string {
+	return packageDirExternal
+}
+
+func (g *mockGenerator) Data(im *imports.Manager) (interface{}, error) {
+	im.Add("context")
+	im.Add("sync")
+	im.Add("testing")
+	im.Add("github.com/jakewright/home-automation/libraries/go/oops")
+
+	if g.file.Service == nil {
+		return nil, nil
+	}
+
+	if len(g.file.Service.RPCs) == 0 {
+		return nil, nil
+	}
+
+	endpoints := make([]*mockDataEndpoint, len(g.file.Service.RPCs))
+	for i, r := range g.file.Service.RPCs {
+		inType, err := resolveTypeName(r.InputType, g.file, im)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve RPC %q input type: %w", r.Name, err)
+		}
+
+		outType, err := resolveTypeName(r.OutputType, g.file, im)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve RPC %q output type: %w", r.Name, err)
+		}
+
+		endpoints[i] = &mockDataEndpoint{
+			NameUpper:  strings.ToUpper(r.Name[0:1]) + r.Name[1:],
+			NameLower:  strings.ToLower(r.Name[0:1]) + r.Name[1:],
+			InputType:  inType.TypeName,
+			OutputType: outType.TypeName,
+			Stream:     r.Stream,
+		}
+	}
+
+	return &mockData{
+		PackageName: externalPackageName(g.options),
+		Imports:     im.Get(),
+		ServiceName: g.file.Service.Name,
+		Endpoints:   endpoints,
+	}, nil
+}
+
+func (g *mockGenerator) Filename() string {
+	return "mock.go"
+}
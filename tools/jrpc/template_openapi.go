@@ -0,0 +1,612 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/jakewright/home-automation/libraries/go/ptr"
+	"github.com/jakewright/home-automation/libraries/go/svcdef"
+	"github.com/jakewright/home-automation/tools/libraries/imports"
+)
+
+// Content types that taxi routers understand
+var openAPIContentTypes = []string{"application/json", "application/msgpack"}
+
+// Content type of streaming responses
+const openAPIContentTypeEventStream = "text/event-stream"
+
+type openAPIData struct {
+	PackageName string
+	Imports     []*imports.Imp
+	Document    string
+}
+
+const openAPITemplateText = `// Code generated by jrpc. DO NOT EDIT.
+
+package {{ .PackageName }}
+
+{{ if .Imports }}
+	import (
+		{{- range .Imports }}
+			{{ .Alias }} "{{ .Path }}"
+		{{- end}}
+	)
+{{ end }}
+
+func init() {
+	router.RegisterOpenAPI([]byte(openAPIDocument))
+}
+
+// openAPIDocument describes the service's RPCs in OpenAPI 3 format
+const openAPIDocument = ` + "`" + `{{ .Document }}` + "`" + `
+`
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       *openAPIInfo                            `json:"info"`
+	Servers    []*openAPIServer                        `json:"servers"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components *openAPIComponents                      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Tags        []string                    `json:"tags"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty"`
+	MinLength            *int64                    `json:"minLength,omitempty"`
+	MaxLength            *int64                    `json:"maxLength,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	MinItems             *int64                    `json:"minItems,omitempty"`
+	MaxItems             *int64                    `json:"maxItems,omitempty"`
+	UniqueItems          bool                      `json:"uniqueItems,omitempty"`
+	MinProperties        *int64                    `json:"minProperties,omitempty"`
+	Default              interface{}               `json:"default,omitempty"`
+	Deprecated           bool                      `json:"deprecated,omitempty"`
+}
+
+// Schemas for the taxi error envelope
+const (
+	openAPIErrorSchema  = "taxi.Error"
+	openAPIRemoteSchema = "taxi.RemoteError"
+)
+
+type openAPIGenerator struct {
+	baseGenerator
+	schemas map[string]*openAPISchema
+}
+
+func (g *openAPIGenerator) Template() (*template.Template, error) {
+	return template.New("openapi_template").Parse(openAPITemplateText)
+}
+
+func (g *openAPIGenerator) PackageDir() string {
+	packageDir := packageDirRouter
+	if g.options.RouterPackageName != "" {
+		packageDir = g.options.RouterPackageName
+	}
+	return packageDir
+}
+
+func (g *openAPIGenerator) Data(im *imports.Manager) (interface{}, error) {
+	// Don't generate anything if there's no service definition
+	if g.file.Service == nil {
+		return nil, nil
+	}
+
+	im.Add("github.com/jakewright/home-automation/libraries/go/router")
+
+	routerPath, ok := g.file.Service.Options["path"].(string)
+	if !ok {
+		return nil, fmt.Errorf("path not set on service %q", g.file.Service.Name)
+	}
+
+	g.schemas = map[string]*openAPISchema{
+		openAPIErrorSchema:  openAPIErrorEnvelope(),
+		openAPIRemoteSchema: openAPIRemoteError(),
+	}
+
+	doc := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: &openAPIInfo{
+			Title: g.file.Service.Name,
+			// Services are not versioned
+			Version: "1.0.0",
+		},
+		Servers:    []*openAPIServer{{URL: "http://" + routerPath}},
+		Paths:      map[string]map[string]*openAPIOperation{},
+		Components: &openAPIComponents{Schemas: g.schemas},
+	}
+
+	for _, r := range g.file.Service.RPCs {
+		method, err := getMethod(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPC %q method: %w", r.Name, err)
+		}
+
+		rpcPath, err := getPath(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPC %q path: %w", r.Name, err)
+		}
+
+		op, err := g.operation(r, method)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate operation for RPC %q: %w", r.Name, err)
+		}
+
+		if doc.Paths[rpcPath] == nil {
+			doc.Paths[rpcPath] = map[string]*openAPIOperation{}
+		}
+		doc.Paths[rpcPath][strings.ToLower(method)] = op
+	}
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+	}
+
+	// The document is written as a raw string literal
+	// so backticks have to be escaped as JSON unicode
+	document := strings.ReplaceAll(string(b), "`", `\u0060`)
+
+	return &openAPIData{
+		PackageName: g.PackageDir(),
+		Imports:     im.Get(),
+		Document:    document,
+	}, nil
+}
+
+func (g *openAPIGenerator) Filename() string {
+	return "openapi.go"
+}
+
+// operation returns the OpenAPI operation for the RPC. The input message
+// of GET, HEAD and DELETE RPCs is described as query parameters because
+// request bodies are not well supported for these methods.
+func (g *openAPIGenerator) operation(r *svcdef.RPC, method string) (*openAPIOperation, error) {
+	out, err := g.messageRef(r.OutputType.Qualified)
+	if err != nil {
+		return nil, err
+	}
+
+	ok := &openAPIResponse{
+		Description: "OK",
+		Content: openAPIContent(&openAPISchema{
+			Type:       "object",
+			Properties: map[string]*openAPISchema{"data": out},
+		}),
+	}
+
+	// Streams are sent as server-sent events. The data of
+	// each event is a message of the output type.
+	if r.Stream {
+		ok = &openAPIResponse{
+			Description: "A stream of server-sent events",
+			Content: map[string]*openAPIMediaType{
+				openAPIContentTypeEventStream: {Schema: out},
+			},
+		}
+	}
+
+	op := &openAPIOperation{
+		OperationID: r.Name,
+		Tags:        []string{g.file.Service.Name},
+		Responses: map[string]*openAPIResponse{
+			"200": ok,
+			"default": {
+				Description: "Error",
+				Content:     openAPIContent(&openAPISchema{Ref: openAPIRef(openAPIErrorSchema)}),
+			},
+		},
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		op.Parameters, err = g.queryParameters(r.InputType.Qualified)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		in, err := g.messageRef(r.InputType.Qualified)
+		if err != nil {
+			return nil, err
+		}
+
+		op.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  openAPIContent(in),
+		}
+	}
+
+	return op, nil
+}
+
+// queryParameters returns a parameter for each of the message's fields that
+// can be represented in a query string, i.e. scalars and repeated scalars.
+func (g *openAPIGenerator) queryParameters(qualified string) ([]*openAPIParameter, error) {
+	m, err := g.findMessage(qualified)
+	if err != nil {
+		return nil, err
+	}
+
+	var params []*openAPIParameter
+	for _, f := range m.Fields {
+		if f.Type.Map || !(isBuiltInType(f.Type) || f.Type.Enum) {
+			continue
+		}
+
+		schema, err := g.fieldSchema(f, "")
+		if err != nil {
+			return nil, err
+		}
+
+		required, _ := f.Options["required"].(bool)
+
+		params = append(params, &openAPIParameter{
+			Name:     f.Name,
+			In:       "query",
+			Required: required,
+			Schema:   schema,
+		})
+	}
+
+	return params, nil
+}
+
+// messageRef adds a schema for the message, and any messages that it
+// references, to the components and returns a reference to it.
+func (g *openAPIGenerator) messageRef(qualified string) (*openAPISchema, error) {
+	name := openAPISchemaName(qualified)
+	if _, ok := g.schemas[name]; ok {
+		return &openAPISchema{Ref: openAPIRef(name)}, nil
+	}
+
+	m, err := g.findMessage(qualified)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the schema before generating the fields
+	// in case the message references itself
+	schema := &openAPISchema{
+		Type:       "object",
+		Properties: map[string]*openAPISchema{},
+	}
+	g.schemas[name] = schema
+
+	// Types in imported files are qualified relative to that file
+	alias, _ := m.Lineage()
+
+	for _, f := range m.Fields {
+		fs, err := g.fieldSchema(f, alias)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate schema for field %q in message %q: %w", f.Name, m.Name, err)
+		}
+
+		schema.Properties[f.Name] = fs
+
+		if required, _ := f.Options["required"].(bool); required {
+			schema.Required = append(schema.Required, f.Name)
+		}
+	}
+
+	return &openAPISchema{Ref: openAPIRef(name)}, nil
+}
+
+// fieldSchema returns the schema for the field. The alias is the import
+// alias of the file in which the field's message was defined.
+func (g *openAPIGenerator) fieldSchema(f *svcdef.Field, alias string) (*openAPISchema, error) {
+	schema, err := g.typeSchema(f.Type, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the options to the items of repeated fields
+	target := schema
+	if f.Type.Repeated {
+		target = schema.Items
+	}
+
+	if v, ok := f.Options["min"]; ok {
+		target.Minimum, err = openAPINumber(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min option: %w", err)
+		}
+	}
+
+	if v, ok := f.Options["max"]; ok {
+		target.Maximum, err = openAPINumber(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max option: %w", err)
+		}
+	}
+
+	// The length constraints apply to the
+	// array itself if the field is repeated
+	minLen, hasMinLen := f.Options["min_len"].(int64)
+	maxLen, hasMaxLen := f.Options["max_len"].(int64)
+	switch {
+	case f.Type.Repeated:
+		if hasMinLen {
+			schema.MinItems = &minLen
+		}
+		if hasMaxLen {
+			schema.MaxItems = &maxLen
+		}
+	default:
+		if hasMinLen {
+			schema.MinLength = &minLen
+		}
+		if hasMaxLen {
+			schema.MaxLength = &maxLen
+		}
+	}
+
+	if v, ok := f.Options["pattern"].(string); ok {
+		schema.Pattern = v
+	}
+
+	if nonEmpty, _ := f.Options["non_empty"].(bool); nonEmpty {
+		schema.MinProperties = ptr.Int64(1)
+	}
+
+	if unique, _ := f.Options["unique"].(bool); unique {
+		schema.UniqueItems = true
+	}
+
+	// Fields with a default cannot be repeated so the default is
+	// always set on the schema itself. The same goes for deprecation.
+	if v, ok := f.Options["default"]; ok {
+		schema.Default = v
+	}
+
+	if deprecated, _ := f.Options["deprecated"].(bool); deprecated {
+		schema.Deprecated = true
+	}
+
+	return schema, nil
+}
+
+func (g *openAPIGenerator) typeSchema(t *svcdef.Type, alias string) (*openAPISchema, error) {
+	var schema *openAPISchema
+
+	switch {
+	case t.Map:
+		// Map keys are always strings in JSON
+		value, err := g.typeSchema(t.MapValue, alias)
+		if err != nil {
+			return nil, err
+		}
+		schema = &openAPISchema{
+			Type:                 "object",
+			AdditionalProperties: value,
+		}
+
+	case isBuiltInType(t):
+		schema = openAPIBuiltInSchema(t.Name)
+
+	default:
+		qualified := t.Qualified
+		if alias != "" && strings.HasPrefix(qualified, ".") {
+			qualified = alias + qualified
+		}
+
+		var err error
+		if t.Enum {
+			schema, err = g.enumRef(qualified)
+		} else {
+			schema, err = g.messageRef(qualified)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if t.Repeated {
+		schema = &openAPISchema{
+			Type:  "array",
+			Items: schema,
+		}
+	}
+
+	return schema, nil
+}
+
+// enumRef adds a schema for the enum to the
+// components and returns a reference to it.
+func (g *openAPIGenerator) enumRef(qualified string) (*openAPISchema, error) {
+	name := openAPISchemaName(qualified)
+	if _, ok := g.schemas[name]; ok {
+		return &openAPISchema{Ref: openAPIRef(name)}, nil
+	}
+
+	e, err := g.findEnum(qualified)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		values[i] = v.Value
+	}
+
+	g.schemas[name] = &openAPISchema{
+		Type: "string",
+		Enum: values,
+	}
+
+	return &openAPISchema{Ref: openAPIRef(name)}, nil
+}
+
+func (g *openAPIGenerator) findEnum(qualified string) (*svcdef.Enum, error) {
+	for _, e := range g.file.FlatEnums {
+		if e.QualifiedName == qualified {
+			return e, nil
+		}
+	}
+
+	return nil, fmt.Errorf("enum %q not found", qualified)
+}
+
+func (g *openAPIGenerator) findMessage(qualified string) (*svcdef.Message, error) {
+	for _, m := range g.file.FlatMessages {
+		if m.QualifiedName == qualified {
+			return m, nil
+		}
+	}
+
+	return nil, fmt.Errorf("message %q not found", qualified)
+}
+
+// isBuiltInType returns whether the type is a
+// scalar type rather than a message or a map
+func isBuiltInType(t *svcdef.Type) bool {
+	if t.Map {
+		return false
+	}
+
+	_, ok := typeMap[t.Name]
+	return ok
+}
+
+func openAPIBuiltInSchema(name string) *openAPISchema {
+	switch name {
+	case typeAny:
+		return &openAPISchema{}
+	case typeBool:
+		return &openAPISchema{Type: "boolean"}
+	case typeString:
+		return &openAPISchema{Type: "string"}
+	case typeInt8, typeInt32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case typeInt64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case typeUint8, typeUint32:
+		return &openAPISchema{Type: "integer", Format: "int32", Minimum: ptr.Float64(0)}
+	case typeUint64:
+		return &openAPISchema{Type: "integer", Format: "int64", Minimum: ptr.Float64(0)}
+	case typeFloat32:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case typeFloat64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case typeBytes:
+		return &openAPISchema{Type: "string", Format: "byte"}
+	case typeTime:
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case typeRGB:
+		// Colors are marshaled as hex codes e.g. #FBEE13
+		return &openAPISchema{Type: "string", Format: "color"}
+	}
+
+	return &openAPISchema{}
+}
+
+func openAPIContent(schema *openAPISchema) map[string]*openAPIMediaType {
+	content := make(map[string]*openAPIMediaType, len(openAPIContentTypes))
+	for _, t := range openAPIContentTypes {
+		content[t] = &openAPIMediaType{Schema: schema}
+	}
+	return content
+}
+
+// openAPIErrorEnvelope describes the body of an error response
+func openAPIErrorEnvelope() *openAPISchema {
+	return &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"error":    {Type: "string"},
+			"stack":    {Type: "string"},
+			"code":     {Type: "string"},
+			"message":  {Type: "string"},
+			"metadata": {Type: "object", AdditionalProperties: &openAPISchema{Type: "string"}},
+			"cause":    {Ref: openAPIRef(openAPIRemoteSchema)},
+		},
+		Required: []string{"error"},
+	}
+}
+
+// openAPIRemoteError describes an error wrapped by another error
+func openAPIRemoteError() *openAPISchema {
+	return &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"code":     {Type: "string"},
+			"message":  {Type: "string"},
+			"metadata": {Type: "object", AdditionalProperties: &openAPISchema{Type: "string"}},
+			"cause":    {Ref: openAPIRef(openAPIRemoteSchema)},
+		},
+	}
+}
+
+// openAPISchemaName converts a qualified message or enum name into a schema
+// name, e.g. ".Foo.Bar" becomes "Foo.Bar" and "device.Header" is
+// left as it is.
+func openAPISchemaName(qualified string) string {
+	return strings.TrimPrefix(qualified, ".")
+}
+
+func openAPIRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+func openAPINumber(v interface{}) (*float64, error) {
+	switch t := v.(type) {
+	case int64:
+		return ptr.Float64(float64(t)), nil
+	case float64:
+		return ptr.Float64(t), nil
+	}
+
+	return nil, fmt.Errorf("value has invalid type %T", v)
+}
@@ -11,17 +11,29 @@ import (
 const packageDirRouter = "routes"
 
 type routerDataEndpoint struct {
-	NameUpper  string
-	InputType  string
-	OutputType string
-	HTTPMethod string
-	Path       string
+	NameUpper   string
+	InputType   string
+	OutputType  string
+	HTTPMethod  string
+	Path        string
+	Stream      bool
+	HasDefaults bool
+
+	// Deprecated are the JSON names and go names of
+	// deprecated fields in the endpoint's input type
+	Deprecated []*routerDataField
+}
+
+type routerDataField struct {
+	GoName   string
+	JSONName string
 }
 
 type routerData struct {
 	PackageName string
 	Imports     []*imports.Imp
 	Endpoints   []*routerDataEndpoint
+	HasStreams  bool
 }
 
 const routerTemplateText = `// Code generated by jrpc. DO NOT EDIT.
@@ -39,29 +51,79 @@ package {{ .PackageName }}
 // taxiRouter is an interface implemented by taxi.Router
 type taxiRouter interface {
 	HandleFunc(method, path string, handler func(context.Context, taxi.Decoder) (interface{}, error))
+	{{- if .HasStreams }}
+		HandleStreamFunc(method, path string, handler func(context.Context, taxi.Decoder, taxi.Sender) error)
+	{{- end }}
 }
 
 type handler interface {
 	{{- range .Endpoints }}
-		{{ .NameUpper }}(ctx context.Context, body *{{ .InputType }}) (*{{ .OutputType }}, error)
+		{{- if .Stream }}
+			{{ .NameUpper }}(ctx context.Context, body *{{ .InputType }}, send func(*{{ .OutputType }}) error) error
+		{{- else }}
+			{{ .NameUpper }}(ctx context.Context, body *{{ .InputType }}) (*{{ .OutputType }}, error)
+		{{- end }}
 	{{- end }}
 }
 
 // Register adds the service's routes to the router
 func Register(r taxiRouter, h handler) {
-	{{ range .Endpoints -}}
-		r.HandleFunc("{{ .HTTPMethod }}", "{{ .Path }}", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
-			body := &{{ .InputType }}{}
-			if err := decode(body); err != nil {
-				return nil, err
-			}
-
-			if err := body.Validate(); err != nil {
-				return nil, err
-			}
-
-			return h.{{ .NameUpper }}(ctx, body)
-		})
+	{{ range $endpoint := .Endpoints -}}
+		{{ if .Stream -}}
+			r.HandleStreamFunc("{{ .HTTPMethod }}", "{{ .Path }}", func(ctx context.Context, decode taxi.Decoder, send taxi.Sender) error {
+				body := &{{ .InputType }}{}
+				if err := decode(body); err != nil {
+					return err
+				}
+
+				{{- range .Deprecated }}
+
+					if body.{{ .GoName }} != nil {
+						slog.FromContext(ctx).Warnf("{{ $endpoint.NameUpper }} request set deprecated field '{{ .JSONName }}'", map[string]string{
+							"field": "{{ .JSONName }}",
+						})
+					}
+				{{- end }}
+				{{- if .HasDefaults }}
+
+					body.ApplyDefaults()
+				{{- end }}
+
+				if err := body.Validate(); err != nil {
+					return err
+				}
+
+				return h.{{ .NameUpper }}(ctx, body, func(msg *{{ .OutputType }}) error {
+					return send(msg)
+				})
+			})
+		{{- else -}}
+			r.HandleFunc("{{ .HTTPMethod }}", "{{ .Path }}", func(ctx context.Context, decode taxi.Decoder) (interface{}, error) {
+				body := &{{ .InputType }}{}
+				if err := decode(body); err != nil {
+					return nil, err
+				}
+
+				{{- range .Deprecated }}
+
+					if body.{{ .GoName }} != nil {
+						slog.FromContext(ctx).Warnf("{{ $endpoint.NameUpper }} request set deprecated field '{{ .JSONName }}'", map[string]string{
+							"field": "{{ .JSONName }}",
+						})
+					}
+				{{- end }}
+				{{- if .HasDefaults }}
+
+					body.ApplyDefaults()
+				{{- end }}
+
+				if err := body.Validate(); err != nil {
+					return nil, err
+				}
+
+				return h.{{ .NameUpper }}(ctx, body)
+			})
+		{{- end }}
 
 	{{ end -}}
 }
@@ -90,6 +152,7 @@ func (g *routerGenerator) Data(im *imports.Manager) (interface{}, error) {
 	}
 
 	im.Add("context")
+	im.Add("github.com/jakewright/home-automation/libraries/go/slog")
 	im.Add("github.com/jakewright/home-automation/libraries/go/taxi")
 
 	// Make sure the service name is a suitable go struct name
@@ -101,6 +164,7 @@ func (g *routerGenerator) Data(im *imports.Manager) (interface{}, error) {
 		return nil, nil
 	}
 
+	var hasStreams bool
 	endpoints := make([]*routerDataEndpoint, len(g.file.Service.RPCs))
 	for i, r := range g.file.Service.RPCs {
 		nameUpper := strings.ToUpper(r.Name[0:1]) + r.Name[1:]
@@ -125,19 +189,51 @@ func (g *routerGenerator) Data(im *imports.Manager) (interface{}, error) {
 			return nil, fmt.Errorf("failed to resolve RPC %q output type: %w", r.Name, err)
 		}
 
-		endpoints[i] = &routerDataEndpoint{
+		endpoint := &routerDataEndpoint{
 			NameUpper:  nameUpper,
 			InputType:  inType.TypeName,
 			OutputType: outType.TypeName,
 			HTTPMethod: method,
 			Path:       rpcPath,
+			Stream:     r.Stream,
 		}
+
+		// Only the fields of the input message itself are
+		// checked, not the fields of any nested messages.
+		for _, m := range g.file.FlatMessages {
+			if m.QualifiedName != r.InputType.Qualified {
+				continue
+			}
+
+			for _, f := range m.Fields {
+				if _, ok := f.Options["default"]; ok {
+					endpoint.HasDefaults = true
+				}
+
+				if deprecated, _ := f.Options["deprecated"].(bool); deprecated {
+					goName, jsonName, err := convertFieldName(f.Name)
+					if err != nil {
+						return nil, err
+					}
+
+					endpoint.Deprecated = append(endpoint.Deprecated, &routerDataField{
+						GoName:   goName,
+						JSONName: jsonName,
+					})
+				}
+			}
+		}
+
+		endpoints[i] = endpoint
+
+		hasStreams = hasStreams || r.Stream
 	}
 
 	return &routerData{
 		PackageName: g.PackageDir(), // This doesn't support separate package name to dir
 		Imports:     im.Get(),
 		Endpoints:   endpoints,
+		HasStreams:  hasStreams,
 	}, nil
 }
 
@@ -2,18 +2,46 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/jakewright/home-automation/libraries/go/ptr"
+	"github.com/jakewright/home-automation/libraries/go/svcdef"
 	"github.com/jakewright/home-automation/tools/libraries/imports"
 )
 
 const packageDirExternal = "def"
 
 type typesDataMessage struct {
+	Name        string
+	Fields      []*typesDataField
+	Oneofs      []*typesDataOneof
+	HasDefaults bool
+}
+
+type typesDataOneof struct {
+	GoName    string // e.g. Kind
+	JSONName  string // e.g. kind
+	TypeName  string // e.g. Action_Kind
+	FieldList string // e.g. 'func', 'command' and 'property'
+	Fields    []*typesDataOneofField
+}
+
+type typesDataOneofField struct {
+	GoName    string
+	JSONName  string
+	ConstName string
+}
+
+type typesDataEnum struct {
 	Name   string
-	Fields []*typesDataField
+	Values []*typesDataEnumValue
+}
+
+type typesDataEnumValue struct {
+	GoName string
+	Value  string
 }
 
 type typesDataField struct {
@@ -21,19 +49,41 @@ type typesDataField struct {
 	JSONName      string
 	Type          string
 	IsMessageType bool // Used to know whether the field can be recursively validated
+	IsEnumType    bool // Used to know whether the field's value should be validated
 	Repeated      bool
 	Ptr           bool // Ptr is set if the field is not a reference type (slice or map)
 
+	// Oneof is the name of the oneof that the field is
+	// in and Siblings are the other fields in the oneof
+	Oneof    string
+	Siblings []string
+
 	// Field options
-	Required bool
-	Min      *float64
-	Max      *float64
+	Required   bool
+	Deprecated bool
+	Default    string // Default is a go expression for the default value
+	Min        *float64
+	Max        *float64
+	MinLen     *int64
+	MaxLen     *int64
+	Pattern    string // Pattern is the name of the regexp variable
+	NonEmpty   bool
+	Unique     bool
+	ItemType   string // ItemType is the type of the items of a repeated field
+}
+
+type typesDataPattern struct {
+	VarName string
+	Pattern string
 }
 
 type typesData struct {
-	PackageName string
-	Imports     []*imports.Imp
-	Messages    []*typesDataMessage
+	PackageName       string
+	Imports           []*imports.Imp
+	Enums             []*typesDataEnum
+	Messages          []*typesDataMessage
+	Patterns          []*typesDataPattern
+	HasNestedMessages bool
 }
 
 const typesTemplateText = `// Code generated by jrpc. DO NOT EDIT.
@@ -48,10 +98,93 @@ package {{ .PackageName }}
 	)
 {{- end }}
 
+{{ range $enum := .Enums }}
+	// {{ $enum.Name }} is defined in the .def file
+	type {{ $enum.Name }} string
+
+	// Values of {{ $enum.Name }}
+	const (
+		{{- range $enum.Values }}
+			{{ .GoName }} {{ $enum.Name }} = {{ printf "%q" .Value }}
+		{{- end }}
+	)
+
+	// Validate returns an error if the value is not one of the values of {{ $enum.Name }}
+	func (e {{ $enum.Name }}) Validate() error {
+		switch e {
+		case {{ range $i, $v := $enum.Values }}{{ if $i }}, {{ end }}{{ $v.GoName }}{{ end }}:
+			return nil
+		}
+
+		return oops.BadRequest("%q is not a valid {{ $enum.Name }}", string(e))
+	}
+
+	// MarshalJSON returns an error if the value is not one of the values of {{ $enum.Name }}
+	func (e {{ $enum.Name }}) MarshalJSON() ([]byte, error) {
+		if err := e.Validate(); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(string(e))
+	}
+
+	// UnmarshalJSON returns an error if the value is not one of the values of {{ $enum.Name }}
+	func (e *{{ $enum.Name }}) UnmarshalJSON(b []byte) error {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+
+		if err := {{ $enum.Name }}(s).Validate(); err != nil {
+			return err
+		}
+
+		*e = {{ $enum.Name }}(s)
+		return nil
+	}
+{{ end }}
+
+{{ if .Patterns }}
+	// Patterns used to validate fields
+	var (
+		{{- range .Patterns }}
+			{{ .VarName }} = regexp.MustCompile({{ printf "%q" .Pattern }})
+		{{- end }}
+	)
+{{ end }}
+
+{{ if .HasNestedMessages }}
+	// prefixFieldPath returns a copy of an error returned by the Validate
+	// function of a nested message with the path of the nested field
+	// prepended to the field path in the error's metadata
+	func prefixFieldPath(err error, path string) error {
+		e, ok := err.(*oops.Error)
+		if !ok {
+			return err
+		}
+
+		metadata := map[string]string{}
+		for k, v := range e.GetMetadata() {
+			metadata[k] = v
+		}
+
+		if field, ok := metadata["field"]; ok {
+			path += "." + field
+		}
+		metadata["field"] = path
+
+		// Validate functions only return bad request errors
+		return oops.BadRequest("%s", e.GetMessage(), metadata)
+	}
+{{ end }}
+
 {{ range $message := .Messages }}
 	// {{ $message.Name }} is defined in the .def file
 	type {{ $message.Name }} struct {
 		{{- range $field := .Fields }}
+			{{- if $field.Deprecated }}
+				// Deprecated: {{ $field.JSONName }} is marked as deprecated in the def file
+			{{- end }}
 			{{ $field.GoName }} {{ if $field.Ptr }}*{{ end }}{{ $field.Type }} ` + "`" + `json:"{{ $field.JSONName }},omitempty"` + "`" + `
 		{{- end }}
 	}
@@ -59,60 +192,266 @@ package {{ .PackageName }}
 	{{- range $field := .Fields }}
 		// Get{{ $field.GoName }} returns the de-referenced value of {{ $field.GoName }}.
 		{{ if $field.Required }} // If the field is nil, the function panics because {{ $field.JSONName }} is marked as required. 
+		{{- else if $field.Default }} // If the field is nil, the default value is returned. The second return value states whether the field is non-nil.
+		// Defaults are applied when the message is unmarshaled, so it is true for a decoded message even if the sender did not set the field.
 		{{- else }} // The second return value states whether the field was set. {{ end }}
 		func (m *{{ $message.Name }}) Get{{ $field.GoName }}() (val {{ $field.Type }}{{ if not $field.Required }}, set bool{{ end }}) {
 			if m.{{ $field.GoName }} == nil {
-				{{ if $field.Required }} panic("{{ $field.JSONName }} marked as required but was not set. This should have been caught by the validate function.") {{ else }} return {{ end }}
+				{{ if $field.Required }} panic("{{ $field.JSONName }} marked as required but was not set. This should have been caught by the validate function.")
+				{{- else if $field.Default }} return {{ $field.Default }}, false
+				{{- else }} return {{ end }}
 			}
 
 			return {{ if $field.Ptr }}*{{ end }}m.{{ $field.GoName }}{{ if not $field.Required }}, true{{ end }}
 		}
 
-		// Set{{ $field.GoName }} sets the value of {{ $field.GoName }}
+		{{ if $field.Oneof -}}
+			// Set{{ $field.GoName }} sets the value of {{ $field.GoName }} and clears the other fields of the {{ $field.Oneof }} oneof
+		{{- else -}}
+			// Set{{ $field.GoName }} sets the value of {{ $field.GoName }}
+		{{- end }}
 		func (m *{{ $message.Name }}) Set{{ $field.GoName }}(v {{ $field.Type }}) *{{ $message.Name }} {
+			{{- range $field.Siblings }}
+				m.{{ . }} = nil
+			{{- end }}
 			m.{{ $field.GoName }} = {{ if $field.Ptr }}&{{ end }}v
 			return m
 		}
 	{{- end }}
 
-	// Validate returns an error if any of the fields have bad values
+	{{- range $oneof := .Oneofs }}
+		// {{ $oneof.TypeName }} identifies the field of the {{ $oneof.JSONName }} oneof that is set
+		type {{ $oneof.TypeName }} string
+
+		// Fields of the {{ $oneof.JSONName }} oneof
+		const (
+			{{ $oneof.TypeName }}_None {{ $oneof.TypeName }} = ""
+			{{- range $oneof.Fields }}
+				{{ .ConstName }} {{ $oneof.TypeName }} = "{{ .JSONName }}"
+			{{- end }}
+		)
+
+		// Which{{ $oneof.GoName }} returns the field of the {{ $oneof.JSONName }} oneof that is set.
+		// If none of the fields are set, {{ $oneof.TypeName }}_None is returned.
+		func (m *{{ $message.Name }}) Which{{ $oneof.GoName }}() {{ $oneof.TypeName }} {
+			if set := m.set{{ $oneof.GoName }}(); len(set) > 0 {
+				return set[0]
+			}
+
+			return {{ $oneof.TypeName }}_None
+		}
+
+		// set{{ $oneof.GoName }} returns all of the fields of the {{ $oneof.JSONName }} oneof that are set
+		func (m *{{ $message.Name }}) set{{ $oneof.GoName }}() []{{ $oneof.TypeName }} {
+			var set []{{ $oneof.TypeName }}
+			{{- range $oneof.Fields }}
+				if m.{{ .GoName }} != nil {
+					set = append(set, {{ .ConstName }})
+				}
+			{{- end }}
+			return set
+		}
+	{{- end }}
+
+	{{- if $message.Oneofs }}
+		// validateOneofs returns an error if more than one field of a oneof
+		// is set. If strict is true, it also returns an error if none of the
+		// fields of a oneof are set.
+		func (m *{{ $message.Name }}) validateOneofs(strict bool) error {
+			{{- range $oneof := .Oneofs }}
+				switch set := m.set{{ $oneof.GoName }}(); {
+				case len(set) > 1:
+					return oops.BadRequest("only one of {{ $oneof.FieldList }} can be set")
+				case strict && len(set) == 0:
+					return oops.BadRequest("one of {{ $oneof.FieldList }} must be set")
+				}
+			{{ end }}
+			return nil
+		}
+
+		// MarshalJSON returns an error if more than one field of a oneof is set
+		func (m *{{ $message.Name }}) MarshalJSON() ([]byte, error) {
+			if err := m.validateOneofs(false); err != nil {
+				return nil, err
+			}
+
+			// The alias has the same fields but none of the
+			// methods so this does not recurse infinitely.
+			type alias {{ $message.Name }}
+			return json.Marshal((*alias)(m))
+		}
+	{{- end }}
+
+	{{- if $message.HasDefaults }}
+		// ApplyDefaults sets the fields that have a default value in the def file if they are not set
+		func (m *{{ $message.Name }}) ApplyDefaults() {
+			{{- range $field := .Fields }}
+				{{- if $field.Default }}
+					if m.{{ $field.GoName }} == nil {
+						v := {{ $field.Default }}
+						m.{{ $field.GoName }} = &v
+					}
+				{{- end }}
+			{{- end }}
+		}
+	{{- end }}
+
+	{{- if or $message.Oneofs $message.HasDefaults }}
+		// UnmarshalJSON
+		{{- if $message.HasDefaults }} applies default values to fields that are not set{{ end }}
+		{{- if and $message.Oneofs $message.HasDefaults }} and{{ end }}
+		{{- if $message.Oneofs }} returns an error if more than one field of a oneof is set{{ end }}
+		func (m *{{ $message.Name }}) UnmarshalJSON(b []byte) error {
+			type alias {{ $message.Name }}
+			if err := json.Unmarshal(b, (*alias)(m)); err != nil {
+				return err
+			}
+			{{- if $message.HasDefaults }}
+
+				m.ApplyDefaults()
+			{{- end }}
+
+			{{ if $message.Oneofs -}}
+				return m.validateOneofs(false)
+			{{- else -}}
+				return nil
+			{{- end }}
+		}
+	{{- end }}
+
+	// Validate returns an error if any of the fields have bad values.
+	// The path of the field is set as "field" in the error's metadata.
 	func (m *{{ $message.Name }}) Validate() error {
 		{{- range $field := $message.Fields -}}
 			{{ if $field.IsMessageType -}}
 				{{ if $field.Repeated -}}
+					for i, r := range m.{{ $field.GoName }} {
+						if err := r.Validate(); err != nil {
+							return prefixFieldPath(err, fmt.Sprintf("{{ $field.JSONName }}[%d]", i))
+						}
+					}
+				{{ else -}}
 					if m.{{ $field.GoName }} != nil {
-						for _, r := range m.{{ $field.GoName }} {
-							if err := r.Validate(); err != nil {
-								return err
-							}
+						if err := m.{{ $field.GoName }}.Validate(); err != nil {
+							return prefixFieldPath(err, "{{ $field.JSONName }}")
+						}
+					}
+				{{ end }}
+			{{ end -}}
+
+			{{ if $field.IsEnumType -}}
+				{{ if $field.Repeated -}}
+					for i, r := range m.{{ $field.GoName }} {
+						if err := r.Validate(); err != nil {
+							return oops.WithMessage(err, "invalid value in field '{{ $field.JSONName }}'", map[string]string{
+								"field": fmt.Sprintf("{{ $field.JSONName }}[%d]", i),
+							})
 						}
 					}
 				{{ else -}}
-					if err := m.{{ $field.GoName }}.Validate(); err != nil {
-						return err
+					if m.{{ $field.GoName }} != nil {
+						if err := m.{{ $field.GoName }}.Validate(); err != nil {
+							return oops.WithMessage(err, "invalid value in field '{{ $field.JSONName }}'", map[string]string{
+								"field": "{{ $field.JSONName }}",
+							})
+						}
 					}
 				{{ end }}
 			{{ end -}}
 
 			{{ if $field.Required -}}
 				if m.{{ $field.GoName }} == nil {
-					return oops.BadRequest("field '{{ $field.JSONName }}' is required")
+					return oops.BadRequest("field '{{ $field.JSONName }}' is required", map[string]string{
+						"field": "{{ $field.JSONName }}",
+					})
 				}
 			{{ end -}}
 
 			{{ if $field.Min -}}
 				if m.{{ $field.GoName }} != nil && *m.{{ $field.GoName }} < {{ $field.Min }} {
-					return oops.BadRequest("field '{{ $field.JSONName }}' should be ≥ {{ $field.Min }}")
+					return oops.BadRequest("field '{{ $field.JSONName }}' should be ≥ {{ $field.Min }}", map[string]string{
+						"field": "{{ $field.JSONName }}",
+					})
 				}
 			{{ end -}}
 
 			{{ if $field.Max -}}
 				if m.{{ $field.GoName }} != nil && *m.{{ $field.GoName }} > {{ $field.Max }} {
-					return oops.BadRequest("field '{{ $field.JSONName }}' should be ≤ {{ $field.Max }}")
+					return oops.BadRequest("field '{{ $field.JSONName }}' should be ≤ {{ $field.Max }}", map[string]string{
+						"field": "{{ $field.JSONName }}",
+					})
+				}
+			{{ end -}}
+
+			{{ if $field.MinLen -}}
+				{{ if $field.Repeated -}}
+					if m.{{ $field.GoName }} != nil && len(m.{{ $field.GoName }}) < {{ $field.MinLen }} {
+						return oops.BadRequest("field '{{ $field.JSONName }}' should have length ≥ {{ $field.MinLen }}", map[string]string{
+							"field": "{{ $field.JSONName }}",
+						})
+					}
+				{{ else -}}
+					if m.{{ $field.GoName }} != nil && utf8.RuneCountInString(*m.{{ $field.GoName }}) < {{ $field.MinLen }} {
+						return oops.BadRequest("field '{{ $field.JSONName }}' should have length ≥ {{ $field.MinLen }}", map[string]string{
+							"field": "{{ $field.JSONName }}",
+						})
+					}
+				{{ end }}
+			{{ end -}}
+
+			{{ if $field.MaxLen -}}
+				{{ if $field.Repeated -}}
+					if len(m.{{ $field.GoName }}) > {{ $field.MaxLen }} {
+						return oops.BadRequest("field '{{ $field.JSONName }}' should have length ≤ {{ $field.MaxLen }}", map[string]string{
+							"field": "{{ $field.JSONName }}",
+						})
+					}
+				{{ else -}}
+					if m.{{ $field.GoName }} != nil && utf8.RuneCountInString(*m.{{ $field.GoName }}) > {{ $field.MaxLen }} {
+						return oops.BadRequest("field '{{ $field.JSONName }}' should have length ≤ {{ $field.MaxLen }}", map[string]string{
+							"field": "{{ $field.JSONName }}",
+						})
+					}
+				{{ end }}
+			{{ end -}}
+
+			{{ if $field.Pattern -}}
+				if m.{{ $field.GoName }} != nil && !{{ $field.Pattern }}.MatchString(*m.{{ $field.GoName }}) {
+					return oops.BadRequest("field '{{ $field.JSONName }}' should match the pattern %s", {{ $field.Pattern }}, map[string]string{
+						"field": "{{ $field.JSONName }}",
+					})
+				}
+			{{ end -}}
+
+			{{ if $field.NonEmpty -}}
+				if m.{{ $field.GoName }} != nil && len(m.{{ $field.GoName }}) == 0 {
+					return oops.BadRequest("field '{{ $field.JSONName }}' should not be empty", map[string]string{
+						"field": "{{ $field.JSONName }}",
+					})
+				}
+			{{ end -}}
+
+			{{ if $field.Unique -}}
+				if len(m.{{ $field.GoName }}) > 1 {
+					seen := make(map[{{ $field.ItemType }}]bool, len(m.{{ $field.GoName }}))
+					for _, v := range m.{{ $field.GoName }} {
+						if seen[v] {
+							return oops.BadRequest("field '{{ $field.JSONName }}' contains the duplicate value %v", v, map[string]string{
+								"field": "{{ $field.JSONName }}",
+							})
+						}
+						seen[v] = true
+					}
 				}
 			{{ end -}}
 		{{ end -}}
 
+		{{ if $message.Oneofs -}}
+			if err := m.validateOneofs(true); err != nil {
+				return err
+			}
+		{{ end -}}
+
 		return nil
 	}
 {{ end }}
@@ -137,11 +476,45 @@ func (g *typesGenerator) Data(im *imports.Manager) (interface{}, error) {
 	// util is needed for the color type if any fields have type "rgb"
 	im.Add("github.com/jakewright/home-automation/libraries/go/util")
 
-	if len(g.file.Messages) == 0 {
+	// json is needed to marshal enums
+	im.Add("encoding/json")
+
+	// These are needed by some of the validation constraints
+	im.Add("fmt")
+	im.Add("regexp")
+	im.Add("unicode/utf8")
+
+	if len(g.file.Messages) == 0 && len(g.file.Enums) == 0 {
 		return nil, nil
 	}
 
+	var enums []*typesDataEnum
+	for _, e := range g.file.Enums {
+		if !reValidGoStructUnderscore.MatchString(e.Name) {
+			return nil, fmt.Errorf("invalid enum name %s", e.Name)
+		}
+
+		values := make([]*typesDataEnumValue, len(e.Values))
+		for i, v := range e.Values {
+			if !reValidEnumValue.MatchString(v.Name) {
+				return nil, fmt.Errorf("invalid value name %s in enum %s", v.Name, e.Name)
+			}
+
+			values[i] = &typesDataEnumValue{
+				GoName: e.Name + "_" + v.Name,
+				Value:  v.Value,
+			}
+		}
+
+		enums = append(enums, &typesDataEnum{
+			Name:   e.Name,
+			Values: values,
+		})
+	}
+
 	var messages []*typesDataMessage
+	var patterns []*typesDataPattern
+	var hasNestedMessages bool
 	for _, m := range g.file.FlatMessages {
 		alias, parts := m.Lineage()
 		if alias != "" {
@@ -154,6 +527,52 @@ func (g *typesGenerator) Data(im *imports.Manager) (interface{}, error) {
 			return nil, fmt.Errorf("invalid message name %s", name)
 		}
 
+		oneofs := make([]*typesDataOneof, len(m.Oneofs))
+		siblings := map[string][]string{}
+		for i, o := range m.Oneofs {
+			oneofGoName, oneofJSONName, err := convertFieldName(o.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			oneof := &typesDataOneof{
+				GoName:   oneofGoName,
+				JSONName: oneofJSONName,
+				TypeName: name + "_" + oneofGoName,
+			}
+
+			var names []string
+			for _, f := range o.Fields {
+				goName, jsonName, err := convertFieldName(f.Name)
+				if err != nil {
+					return nil, err
+				}
+
+				oneof.Fields = append(oneof.Fields, &typesDataOneofField{
+					GoName:    goName,
+					JSONName:  jsonName,
+					ConstName: oneof.TypeName + "_" + goName,
+				})
+
+				names = append(names, "'"+jsonName+"'")
+			}
+
+			oneof.FieldList = strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+
+			// Record the other fields in the oneof so
+			// that the setters can clear them.
+			for _, f := range oneof.Fields {
+				for _, g := range oneof.Fields {
+					if f != g {
+						siblings[f.JSONName] = append(siblings[f.JSONName], g.GoName)
+					}
+				}
+			}
+
+			oneofs[i] = oneof
+		}
+
+		var hasDefaults bool
 		fields := make([]*typesDataField, len(m.Fields))
 		for i, f := range m.Fields {
 			goName, jsonName, err := convertFieldName(f.Name)
@@ -173,6 +592,20 @@ func (g *typesGenerator) Data(im *imports.Manager) (interface{}, error) {
 				required = v
 			}
 
+			var deprecated bool
+			if v, ok := f.Options["deprecated"].(bool); ok {
+				deprecated = v
+			}
+
+			var def string
+			if v, ok := f.Options["default"]; ok {
+				def, err = g.defaultValue(f, typ, v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid default on field %q in message %q: %w", f.Name, m.Name, err)
+				}
+				hasDefaults = true
+			}
+
 			var min *float64
 			if v, ok := f.Options["min"]; ok {
 				switch t := v.(type) {
@@ -209,29 +642,71 @@ func (g *typesGenerator) Data(im *imports.Manager) (interface{}, error) {
 				}
 			}
 
+			// The parser has already checked that the
+			// constraints can be applied to the field.
+			var minLen, maxLen *int64
+			if v, ok := f.Options["min_len"].(int64); ok {
+				minLen = &v
+			}
+			if v, ok := f.Options["max_len"].(int64); ok {
+				maxLen = &v
+			}
+
+			var pattern string
+			if v, ok := f.Options["pattern"].(string); ok {
+				pattern = "pattern" + name + "_" + goName
+				patterns = append(patterns, &typesDataPattern{
+					VarName: pattern,
+					Pattern: v,
+				})
+			}
+
+			nonEmpty, _ := f.Options["non_empty"].(bool)
+			unique, _ := f.Options["unique"].(bool)
+
+			if typ.IsMessageType {
+				hasNestedMessages = true
+			}
+
 			fields[i] = &typesDataField{
 				GoName:        goName,
 				JSONName:      jsonName,
 				Type:          typ.FullTypeName,
 				IsMessageType: typ.IsMessageType,
+				IsEnumType:    typ.IsEnumType,
 				Repeated:      typ.Repeated,
 				Ptr:           !(f.Type.Map || f.Type.Repeated || f.Type.Name == typeAny), // [1]
+				Oneof:         f.Oneof,
+				Siblings:      siblings[jsonName],
 				Required:      required,
+				Deprecated:    deprecated,
+				Default:       def,
 				Min:           min,
 				Max:           max,
+				MinLen:        minLen,
+				MaxLen:        maxLen,
+				Pattern:       pattern,
+				NonEmpty:      nonEmpty,
+				Unique:        unique,
+				ItemType:      typ.TypeName,
 			}
 		}
 
 		messages = append(messages, &typesDataMessage{
-			Name:   name,
-			Fields: fields,
+			Name:        name,
+			Fields:      fields,
+			Oneofs:      oneofs,
+			HasDefaults: hasDefaults,
 		})
 	}
 
 	return &typesData{
-		PackageName: externalPackageName(g.options),
-		Imports:     im.Get(),
-		Messages:    messages,
+		PackageName:       externalPackageName(g.options),
+		Imports:           im.Get(),
+		Enums:             enums,
+		Messages:          messages,
+		Patterns:          patterns,
+		HasNestedMessages: hasNestedMessages,
 	}, nil
 }
 
@@ -239,6 +714,39 @@ func (g *typesGenerator) Filename() string {
 	return "types.go"
 }
 
+// defaultValue returns a go expression for the field's default value.
+// The parser has already checked that the value suits the field's type.
+func (g *typesGenerator) defaultValue(f *svcdef.Field, typ *typeInfo, v interface{}) (string, error) {
+	if typ.IsEnumType {
+		for _, e := range g.file.FlatEnums {
+			if e.QualifiedName != f.Type.Qualified {
+				continue
+			}
+
+			for _, ev := range e.Values {
+				if ev.Value == v {
+					return typ.TypeName + "_" + ev.Name, nil
+				}
+			}
+		}
+
+		return "", fmt.Errorf("%v is not a value of %s", v, f.Type.Qualified)
+	}
+
+	switch t := v.(type) {
+	case bool:
+		return strconv.FormatBool(t), nil
+	case string:
+		return strconv.Quote(t), nil
+	case int64:
+		return fmt.Sprintf("%s(%d)", typ.TypeName, t), nil
+	case float64:
+		return fmt.Sprintf("%s(%s)", typ.TypeName, strconv.FormatFloat(t, 'g', -1, 64)), nil
+	}
+
+	return "", fmt.Errorf("unsupported default value %v", v)
+}
+
 // [1] A note on reference types
 // An empty array in JSON becomes an empty slice in go when unmarshaled.
 // If the JSON field is not set, then the slice in go remains nil. It
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jakewright/home-automation/libraries/go/svcdef"
+	"github.com/jakewright/home-automation/tools/libraries/imports"
+)
+
+// The TypeScript files are written alongside the generated go
+// code in the external package so they can be imported by the
+// web client using a relative path.
+
+type tsImport struct {
+	Alias string
+	Path  string
+}
+
+type tsTypesField struct {
+	Name       string
+	Type       string
+	Optional   bool
+	Deprecated bool
+	Default    string
+}
+
+type tsTypesMessage struct {
+	Name   string
+	Fields []*tsTypesField
+}
+
+type tsTypesEnum struct {
+	Name   string
+	Values []string
+}
+
+type tsTypesData struct {
+	Imports  []*tsImport
+	Enums    []*tsTypesEnum
+	Messages []*tsTypesMessage
+}
+
+const tsTypesTemplateText = `// Code generated by jrpc. DO NOT EDIT.
+{{ if .Imports }}
+{{ range .Imports -}}
+import * as {{ .Alias }} from "{{ .Path }}";
+{{ end -}}
+{{ end }}
+{{- range .Enums }}
+// {{ .Name }} is defined in the .def file
+export type {{ .Name }} ={{ range .Values }}
+  | {{ printf "%q" . }}{{ end }};
+{{ end }}
+{{- range .Messages }}
+// {{ .Name }} is defined in the .def file
+export interface {{ .Name }} {
+  {{- range .Fields }}
+  {{- if .Deprecated }}
+  /** @deprecated */
+  {{- else if .Default }}
+  /** @default {{ .Default }} */
+  {{- end }}
+  {{ .Name }}{{ if .Optional }}?{{ end }}: {{ .Type }};
+  {{- end }}
+}
+{{ end -}}
+`
+
+type tsTypesGenerator struct {
+	baseGenerator
+}
+
+func (g *tsTypesGenerator) Template() (*template.Template, error) {
+	return template.New("ts_types_template").Parse(tsTypesTemplateText)
+}
+
+func (g *tsTypesGenerator) PackageDir() string {
+	return packageDirExternal
+}
+
+func (g *tsTypesGenerator) Data(_ *imports.Manager) (interface{}, error) {
+	if len(g.file.Messages) == 0 && len(g.file.Enums) == 0 {
+		return nil, nil
+	}
+
+	// Enums are unions of string literal types
+	// which match the marshaled values
+	var enums []*tsTypesEnum
+	for _, e := range g.file.Enums {
+		values := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			values[i] = v.Value
+		}
+
+		enums = append(enums, &tsTypesEnum{
+			Name:   e.Name,
+			Values: values,
+		})
+	}
+
+	var messages []*tsTypesMessage
+	for _, m := range g.file.FlatMessages {
+		alias, parts := m.Lineage()
+		if alias != "" {
+			// Ignore any messages that are from imported files
+			continue
+		}
+
+		fields := make([]*tsTypesField, len(m.Fields))
+		for i, f := range m.Fields {
+			typ, err := tsTypeName(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve type of field %q in message %q: %w", f.Name, m.Name, err)
+			}
+
+			// All fields are marshaled with omitempty so only
+			// required fields are guaranteed to be present.
+			required, _ := f.Options["required"].(bool)
+
+			deprecated, _ := f.Options["deprecated"].(bool)
+
+			var def string
+			if v, ok := f.Options["default"]; ok {
+				b, err := json.Marshal(v)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal default of field %q in message %q: %w", f.Name, m.Name, err)
+				}
+				def = string(b)
+			}
+
+			fields[i] = &tsTypesField{
+				Name:       f.Name,
+				Type:       typ,
+				Optional:   !required,
+				Deprecated: deprecated,
+				Default:    def,
+			}
+		}
+
+		messages = append(messages, &tsTypesMessage{
+			Name:   strings.Join(parts, "_"),
+			Fields: fields,
+		})
+	}
+
+	imps, err := tsImports(g.options, g.file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tsTypesData{
+		Imports:  imps,
+		Enums:    enums,
+		Messages: messages,
+	}, nil
+}
+
+func (g *tsTypesGenerator) Filename() string {
+	return "types.ts"
+}
+
+type tsClientEndpoint struct {
+	Name       string
+	NameLower  string
+	InputType  string
+	OutputType string
+	HTTPMethod string
+	Path       string
+	Query      bool
+	Stream     bool
+}
+
+type tsClientData struct {
+	Imports     []*tsImport
+	LocalTypes  []string
+	RPCPath     string
+	ServiceName string
+	Endpoints   []*tsClientEndpoint
+}
+
+// tsRPCModule is the shared module, relative to the
+// module root, that the generated clients import
+const tsRPCModule = "libraries/typescript/rpc"
+
+const tsClientTemplateText = `// Code generated by jrpc. DO NOT EDIT.
+
+import { Fetch, RPCClient } from "{{ .RPCPath }}";
+{{- range .Imports }}
+import * as {{ .Alias }} from "{{ .Path }}";
+{{- end }}
+{{- if .LocalTypes }}
+import {
+  {{- range .LocalTypes }}
+  {{ . }},
+  {{- end }}
+} from "./types";
+{{- end }}
+
+export { RPCError } from "{{ .RPCPath }}";
+
+// {{ .ServiceName }}Client makes requests to the {{ .ServiceName }} service
+export class {{ .ServiceName }}Client extends RPCClient {
+  // baseURL is the URL at which the service can be reached,
+  // e.g. the URL of the api-gateway followed by the
+  // service's prefix.
+  constructor(baseURL: string, fetch?: Fetch) {
+    super(baseURL, fetch);
+  }
+{{ range .Endpoints }}
+{{- if .Stream }}
+  // {{ .NameLower }} makes a {{ .HTTPMethod }} request to {{ .Path }} and calls onMessage
+  // with each message in the stream. The promise resolves when the
+  // stream ends. The stream can be stopped early with the signal.
+  {{ .NameLower }}(
+    body: {{ .InputType }},
+    onMessage: (msg: {{ .OutputType }}) => void,
+    signal?: AbortSignal
+  ): Promise<void> {
+    return this.stream("{{ .HTTPMethod }}", "{{ .Path }}", body, {{ .Query }}, onMessage, signal);
+  }
+{{- else }}
+  // {{ .NameLower }} makes a {{ .HTTPMethod }} request to {{ .Path }}
+  {{ .NameLower }}(body: {{ .InputType }}): Promise<{{ .OutputType }}> {
+    return this.do("{{ .HTTPMethod }}", "{{ .Path }}", body, {{ .Query }});
+  }
+{{- end }}
+{{ end -}}
+}
+`
+
+type tsClientGenerator struct {
+	baseGenerator
+}
+
+func (g *tsClientGenerator) Template() (*template.Template, error) {
+	return template.New("ts_client_template").Parse(tsClientTemplateText)
+}
+
+func (g *tsClientGenerator) PackageDir() string {
+	return packageDirExternal
+}
+
+func (g *tsClientGenerator) Data(_ *imports.Manager) (interface{}, error) {
+	if g.file.Service == nil || len(g.file.Service.RPCs) == 0 {
+		return nil, nil
+	}
+
+	localTypes := map[string]bool{}
+	usedAliases := map[string]bool{}
+
+	endpoints := make([]*tsClientEndpoint, len(g.file.Service.RPCs))
+	for i, r := range g.file.Service.RPCs {
+		method, err := getMethod(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPC %q method: %w", r.Name, err)
+		}
+
+		rpcPath, err := getPath(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPC %q path: %w", r.Name, err)
+		}
+
+		inType, err := tsTypeName(r.InputType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve RPC %q input type: %w", r.Name, err)
+		}
+
+		outType, err := tsTypeName(r.OutputType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve RPC %q output type: %w", r.Name, err)
+		}
+
+		for _, t := range []*svcdef.Type{r.InputType, r.OutputType} {
+			if strings.HasPrefix(t.Qualified, ".") {
+				localTypes[strings.ReplaceAll(t.Qualified[1:], ".", "_")] = true
+			} else if i := strings.Index(t.Qualified, "."); i > 0 {
+				usedAliases[t.Qualified[:i]] = true
+			}
+		}
+
+		// Match the OpenAPI document: the bodies of these
+		// methods are sent as query parameters.
+		var query bool
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodDelete:
+			query = true
+		}
+
+		endpoints[i] = &tsClientEndpoint{
+			Name:       r.Name,
+			NameLower:  strings.ToLower(r.Name[0:1]) + r.Name[1:],
+			InputType:  inType,
+			OutputType: outType,
+			HTTPMethod: method,
+			Path:       rpcPath,
+			Query:      query,
+			Stream:     r.Stream,
+		}
+	}
+
+	var sortedTypes []string
+	for t := range localTypes {
+		sortedTypes = append(sortedTypes, t)
+	}
+	sort.Strings(sortedTypes)
+
+	allImps, err := tsImports(g.options, g.file)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only import the files that the RPCs reference
+	var imps []*tsImport
+	for _, imp := range allImps {
+		if usedAliases[imp.Alias] {
+			imps = append(imps, imp)
+		}
+	}
+
+	rpcPath, err := tsPathFromRoot(g.options, tsRPCModule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tsClientData{
+		Imports:     imps,
+		LocalTypes:  sortedTypes,
+		RPCPath:     rpcPath,
+		ServiceName: g.file.Service.Name,
+		Endpoints:   endpoints,
+	}, nil
+}
+
+func (g *tsClientGenerator) Filename() string {
+	return "client.ts"
+}
+
+// tsImports returns an import for each imported def file that has
+// messages or enums. The path is relative to the external package.
+func tsImports(opts *options, file *svcdef.File) ([]*tsImport, error) {
+	var imps []*tsImport
+	for alias, imp := range file.Imports {
+		if len(imp.File.Messages) == 0 && len(imp.File.Enums) == 0 {
+			continue
+		}
+
+		from := filepath.Join(filepath.Dir(opts.DefPath), packageDirExternal)
+		to := filepath.Join(filepath.Dir(opts.DefPath), filepath.Dir(imp.Path), packageDirExternal)
+
+		rel, err := filepath.Rel(from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get relative path to import %q: %w", alias, err)
+		}
+
+		imps = append(imps, &tsImport{
+			Alias: alias,
+			Path:  path.Join(filepath.ToSlash(rel), "types"),
+		})
+	}
+
+	sort.Slice(imps, func(i, j int) bool {
+		return imps[i].Alias < imps[j].Alias
+	})
+
+	return imps, nil
+}
+
+// tsPathFromRoot returns the import path of a module, given relative
+// to the module root, from the external package
+func tsPathFromRoot(opts *options, module string) (string, error) {
+	defPath, err := filepath.Abs(opts.DefPath)
+	if err != nil {
+		return "", err
+	}
+
+	from := filepath.Join(filepath.Dir(defPath), packageDirExternal)
+	to := filepath.Join(resolver.ModuleRoot(), filepath.FromSlash(module))
+
+	rel, err := filepath.Rel(from, to)
+	if err != nil {
+		return "", fmt.Errorf("failed to get relative path to %q: %w", module, err)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// tsTypeName returns the TypeScript type for the def type.
+// The types match the JSON produced by the generated go types.
+func tsTypeName(t *svcdef.Type) (string, error) {
+	var name string
+
+	switch {
+	case t.Map:
+		// Keys are always strings in JSON
+		value, err := tsTypeName(t.MapValue)
+		if err != nil {
+			return "", err
+		}
+		name = "{ [key: string]: " + value + " }"
+
+	case strings.HasPrefix(t.Qualified, "."): // local message or enum
+		name = strings.ReplaceAll(t.Qualified[1:], ".", "_")
+
+	case strings.Contains(t.Qualified, "."): // imported message or enum
+		parts := strings.SplitN(t.Qualified, ".", 2)
+		name = parts[0] + "." + strings.ReplaceAll(parts[1], ".", "_")
+
+	default:
+		switch t.Name {
+		case typeAny:
+			name = "any"
+		case typeBool:
+			name = "boolean"
+		case typeInt8, typeInt32, typeInt64, typeUint8, typeUint32, typeUint64, typeFloat32, typeFloat64:
+			name = "number"
+		case typeString:
+			name = "string"
+		case typeBytes:
+			// []byte is marshaled as a base64 string
+			name = "string"
+		case typeTime:
+			// time.Time is marshaled as an RFC 3339 string
+			name = "string"
+		case typeRGB:
+			// util.RGB is marshaled as a hex string e.g. "#FBEE13"
+			name = "string"
+		default:
+			return "", fmt.Errorf("invalid type %q", t.Name)
+		}
+	}
+
+	if t.Repeated {
+		if strings.HasPrefix(name, "{") {
+			name = "(" + name + ")"
+		}
+		name += "[]"
+	}
+
+	return name, nil
+}
@@ -67,6 +67,11 @@ func NewResolver() (*Resolver, error) {
 	}, nil
 }
 
+// ModuleRoot returns the absolute path to the root of the go module
+func (r *Resolver) ModuleRoot() string {
+	return r.moduleRoot
+}
+
 // Resolve returns the import path for a package in service s.
 // It assumes that the def file exists in the root of service s.
 func (r *Resolver) Resolve(defPath, pkg string) (string, error) {